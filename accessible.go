@@ -0,0 +1,73 @@
+package pretty
+
+import (
+	"context"
+	"strings"
+)
+
+// SetAccessible toggles a linearized rendering of the table for users of
+// screen readers, which read box-drawing characters and padding aloud. Each
+// row is written as a sentence that names its values:
+//
+//	Employees.
+//	Row 1: Name=Noel, Type=Human.
+//	Row 2: Name=David, Type=Cyborg.
+//
+// If column names are hidden, the values are listed alone. The header and
+// row count are kept, and colors are not used. End users can turn it on for
+// every table by setting EnvAccessible.
+func (table *Table) SetAccessible(accessible bool) {
+	table.accessible = accessible
+	table.accessibleFromEnvironment = false
+}
+
+// WithAccessible toggles the linearized rendering for screen readers. See
+// Table.SetAccessible.
+func WithAccessible(accessible bool) Option {
+	return func(table *Table) error {
+		table.SetAccessible(accessible)
+		return nil
+	}
+}
+
+// renderAccessible writes the table as sentences, one per row.
+func (table *Table) renderAccessible(
+	ctx context.Context,
+	w renderWriter,
+) error {
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+	if table.shouldPrintRowCount && table.rowCountPosition == RowCountAbove {
+		w.WriteString(table.rowCountLine(len(table.rows)))
+	}
+	if table.header != nil {
+		w.WriteString(strings.TrimSuffix(*table.header, ".") + ".\n")
+	}
+
+	names := table.ColumnNames()
+	for i, row := range table.renderedRows(table.resolvedColumnDefs()) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		fields := make([]string, len(row))
+		for j, value := range row {
+			if table.hideColumnNames {
+				fields[j] = value
+			} else {
+				fields[j] = names[j] + "=" + value
+			}
+		}
+		w.WriteString(
+			translate("Row %d: %s.", i+1, strings.Join(fields, ", ")) + "\n")
+		if annotation, ok := table.rowAnnotations[i]; ok {
+			w.WriteString(
+				translate("Note: %s", annotation) + "\n")
+		}
+	}
+
+	if table.shouldPrintRowCount && table.rowCountPosition == RowCountBelow {
+		w.WriteString(table.rowCountLine(len(table.rows)))
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestAccessible(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Type")},
+		WithHeader("Employees"),
+		WithRowCount(true),
+		WithAccessible(true))
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("Noel", "Human"))
+	assert.Nil(t, table.AddRow("David", "Cyborg"))
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"Employees.\n"+
+			"Row 1: Name=Noel, Type=Human.\n"+
+			"Row 2: Name=David, Type=Cyborg.\n"+
+			"Count: 2\n",
+		output)
+
+	table.ShowColumnNames(false)
+	table.header = nil
+	table.ShowRowCount(false)
+	output, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"Row 1: Noel, Human.\nRow 2: David, Cyborg.\n",
+		output)
+}
+
+func TestAccessibleFromEnvironment(t *testing.T) {
+	t.Setenv(EnvAccessible, "1")
+	table := createBasicTable(t)
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"Row 1: Employee Number=23, Name=Noel, Type=Human, "+
+			"Phone Number=(123) 456-7899.\n"+
+			"Row 2: Employee Number=83, Name=David, Type=Cyborg, "+
+			"Phone Number=987-654-3211.\n"+
+			"Row 3: Employee Number=52, Name=Pranava, Type=Crusher, "+
+			"Phone Number=1-800-123-4567.\n"+
+			"Row 4: Employee Number=1182, Name=Postnava, Type=Kitten, "+
+			"Phone Number=1 (800) 987-6543.\n",
+		output)
+}
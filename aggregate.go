@@ -0,0 +1,176 @@
+package pretty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Aggregation is a function used to summarize the values of a group of rows.
+type Aggregation uint
+
+const (
+	// AggregateCount counts the rows in each group.
+	AggregateCount Aggregation = iota
+	// AggregateSum sums the numeric values in each group.
+	AggregateSum Aggregation = iota
+	// AggregateAvg averages the numeric values in each group.
+	AggregateAvg Aggregation = iota
+)
+
+// String returns the name of the aggregation.
+func (aggregation Aggregation) String() string {
+	switch aggregation {
+	case AggregateCount:
+		return "Count"
+	case AggregateSum:
+		return "Sum"
+	case AggregateAvg:
+		return "Avg"
+	default:
+		return fmt.Sprintf("Aggregation(%d)", uint(aggregation))
+	}
+}
+
+// Pivot groups the rows of the table by the values of groupColumn and
+// aggregates the values of valueColumn within each group, returning a new
+// two-column table. Groups appear in the order they are first seen. Empty
+// values are ignored by AggregateSum and AggregateAvg; any other value must
+// be numeric.
+func (table *Table) Pivot(
+	groupColumn string,
+	valueColumn string,
+	aggregation Aggregation,
+) (*Table, error) {
+	if aggregation > AggregateAvg {
+		return nil, fmt.Errorf("unknown aggregation %v", aggregation)
+	}
+	if err := table.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+	groupIndex, err := table.columnIndex(groupColumn)
+	if err != nil {
+		return nil, err
+	}
+	valueIndex, err := table.columnIndex(valueColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	type group struct {
+		count int
+		stats ColumnStats
+	}
+	var groupKeys []string
+	groups := make(map[string]*group)
+	for i := range table.rows {
+		key := table.cellValue(i, groupIndex)
+		g, ok := groups[key]
+		if !ok {
+			g = &group{}
+			groups[key] = g
+			groupKeys = append(groupKeys, key)
+		}
+		g.count++
+
+		if aggregation == AggregateCount {
+			continue
+		}
+		value, ok, err := parseNumeric(table.cellValue(i, valueIndex))
+		if err != nil {
+			return nil, fmt.Errorf("column %s: %v", valueColumn, err)
+		}
+		if ok {
+			g.stats.add(value)
+		}
+	}
+
+	pivot, err := NewPrettyTable(
+		NewColumnDef(groupColumn),
+		NewColumnDef(fmt.Sprintf("%v(%s)", aggregation, valueColumn)))
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range groupKeys {
+		g := groups[key]
+		var result string
+		switch aggregation {
+		case AggregateCount:
+			result = strconv.Itoa(g.count)
+		case AggregateSum:
+			result = formatNumeric(g.stats.Sum)
+		case AggregateAvg:
+			if g.stats.Count > 0 {
+				result = formatNumeric(g.stats.Avg)
+			}
+		}
+		if err := pivot.AddRow(key, result); err != nil {
+			return nil, err
+		}
+	}
+	return pivot, nil
+}
+
+// ColumnStats summarizes the numeric values of a column. Empty values are not
+// counted.
+type ColumnStats struct {
+	Count int
+	Sum   float64
+	Min   float64
+	Max   float64
+	Avg   float64
+}
+
+// ColumnStats computes statistics over the values of the named column. Empty
+// values are ignored; any other value must be numeric.
+func (table *Table) ColumnStats(column string) (ColumnStats, error) {
+	if err := table.loadSpilledRows(); err != nil {
+		return ColumnStats{}, err
+	}
+	index, err := table.columnIndex(column)
+	if err != nil {
+		return ColumnStats{}, err
+	}
+
+	var stats ColumnStats
+	for i := range table.rows {
+		value, ok, err := parseNumeric(table.cellValue(i, index))
+		if err != nil {
+			return ColumnStats{}, fmt.Errorf("column %s: %v", column, err)
+		}
+		if ok {
+			stats.add(value)
+		}
+	}
+	return stats, nil
+}
+
+func (stats *ColumnStats) add(value float64) {
+	if stats.Count == 0 || value < stats.Min {
+		stats.Min = value
+	}
+	if stats.Count == 0 || value > stats.Max {
+		stats.Max = value
+	}
+	stats.Count++
+	stats.Sum += value
+	stats.Avg = stats.Sum / float64(stats.Count)
+}
+
+// parseNumeric parses a cell value as a number. It returns false if the value
+// is empty, and an error if it is neither empty nor numeric.
+func parseNumeric(value string) (float64, bool, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return 0, false, nil
+	}
+	number, err := strconv.ParseFloat(trimmed, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("value %q is not numeric", value)
+	}
+	return number, true, nil
+}
+
+func formatNumeric(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
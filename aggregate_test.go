@@ -0,0 +1,70 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestPivot(t *testing.T) {
+	table := createVMTable(t)
+
+	count, err := table.Pivot("Cluster", "VM", AggregateCount)
+	assert.Nil(t, err)
+	assertExpectedTable(t, count, "pivot_count.txt")
+
+	sum, err := table.Pivot("Cluster", "Disk GB", AggregateSum)
+	assert.Nil(t, err)
+	assertExpectedTable(t, sum, "pivot_sum.txt")
+
+	avg, err := table.Pivot("Cluster", "Disk GB", AggregateAvg)
+	assert.Nil(t, err)
+	assertExpectedTable(t, avg, "pivot_avg.txt")
+}
+
+func TestPivotErrors(t *testing.T) {
+	table := createVMTable(t)
+
+	_, err := table.Pivot("Region", "VM", AggregateCount)
+	assert.NotNil(t, err)
+
+	// VM names are not numeric, so they cannot be summed.
+	_, err = table.Pivot("Cluster", "VM", AggregateSum)
+	assert.NotNil(t, err)
+}
+
+func createVMTable(t *testing.T) *Table {
+	table, err := NewPrettyTable(
+		NewColumnDef("VM"),
+		NewColumnDef("Cluster"),
+		NewColumnDef("Disk GB"))
+	assert.Nil(t, err)
+
+	err = table.AddRow("web-1", "prod", "120")
+	assert.Nil(t, err)
+	err = table.AddRow("web-2", "prod", "80")
+	assert.Nil(t, err)
+	err = table.AddRow("build-1", "dev", "500.5")
+	assert.Nil(t, err)
+	err = table.AddRow("db-1", "prod", "")
+	assert.Nil(t, err)
+
+	return table
+}
+
+func TestColumnStats(t *testing.T) {
+	table := createVMTable(t)
+
+	stats, err := table.ColumnStats("Disk GB")
+	assert.Nil(t, err)
+	assert.EqualInt(t, 3, stats.Count)
+	assert.EqualFloat64(t, 700.5, stats.Sum)
+	assert.EqualFloat64(t, 80, stats.Min)
+	assert.EqualFloat64(t, 500.5, stats.Max)
+	assert.EqualFloat64(t, 233.5, stats.Avg)
+
+	_, err = table.ColumnStats("VM")
+	assert.NotNil(t, err)
+	_, err = table.ColumnStats("Latency")
+	assert.NotNil(t, err)
+}
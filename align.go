@@ -0,0 +1,55 @@
+package pretty
+
+import "strings"
+
+// AlignColumns pads the fields of each row so that they line up in columns
+// separated by two spaces, the way gofmt aligns comments, and returns a line
+// per row without a line break. Rows may have different numbers of fields,
+// and each column is as wide as its widest field.
+//
+//	AlignColumns([][]string{{"a", "b"}, {"ccc", "d"}})
+//	// "a    b"
+//	// "ccc  d"
+func AlignColumns(rows [][]string) []string {
+	var widths []int
+	for _, row := range rows {
+		for i, field := range row {
+			if i == len(widths) {
+				widths = append(widths, 0)
+			}
+			if width := strLengthWithEncoding(field); width > widths[i] {
+				widths[i] = width
+			}
+		}
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		var builder strings.Builder
+		for j, field := range row {
+			if j > 0 {
+				builder.WriteString(strings.Repeat(" ", columnGap))
+			}
+			if j < len(row)-1 {
+				field = Pad(field, widths[j], LeftJustify)
+			}
+			builder.WriteString(field)
+		}
+		lines[i] = strings.TrimRight(builder.String(), " ")
+	}
+	return lines
+}
+
+// AlignValues is like AlignColumns for values of any type, which are
+// formatted as AddValues formats them: with their String method if they
+// have one, and as empty fields if they are nil.
+func AlignValues(rows [][]interface{}) []string {
+	formatted := make([][]string, len(rows))
+	for i, row := range rows {
+		formatted[i] = make([]string, len(row))
+		for j, value := range row {
+			formatted[i][j] = formatValue(value)
+		}
+	}
+	return AlignColumns(formatted)
+}
@@ -0,0 +1,40 @@
+package pretty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestAlignColumns(t *testing.T) {
+	assert.DeepEqual(
+		t,
+		[]string{
+			"name    size  state",
+			"vm-1    10",
+			"日本語  2     ok",
+			"",
+		},
+		AlignColumns([][]string{
+			{"name", "size", "state"},
+			{"vm-1", "10", ""},
+			{"日本語", "2", "ok"},
+			{},
+		}))
+	assert.EqualInt(t, 0, len(AlignColumns(nil)))
+}
+
+func TestAlignValues(t *testing.T) {
+	var missing *int
+	assert.DeepEqual(
+		t,
+		[]string{
+			"backup  1m30s",
+			"check          5",
+		},
+		AlignValues([][]interface{}{
+			{"backup", 90 * time.Second},
+			{"check", missing, 5},
+		}))
+}
@@ -0,0 +1,85 @@
+package pretty
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// annotationIndent is the number of spaces row annotations are indented by,
+// beyond the padding of the cells.
+const annotationIndent = 2
+
+// annotationColor is the color of row annotations, which sets them apart
+// from the rows.
+const annotationColor = color.FgHiBlack
+
+// SetRowAnnotation attaches a comment to the row at index, counting from 0,
+// such as the details of an error. The annotation is shown beneath the row,
+// indented and wrapped to the width of the table, and read after the row in
+// accessible mode. An empty annotation removes it. Annotations are kept when
+// the table is sorted and cleared by SetRows.
+func (table *Table) SetRowAnnotation(index int, annotation string) error {
+	if index < 0 || index >= table.rowCount() {
+		return fmt.Errorf(
+			"row %d out of range [0, %d)",
+			index,
+			table.rowCount())
+	}
+	if annotation == "" {
+		delete(table.rowAnnotations, index)
+		return nil
+	}
+	if table.rowAnnotations == nil {
+		table.rowAnnotations = make(map[int]string)
+	}
+	table.rowAnnotations[index] = annotation
+	return nil
+}
+
+// AddAnnotatedRow adds a row to the table, as AddRow does, with an
+// annotation. See SetRowAnnotation.
+func (table *Table) AddAnnotatedRow(annotation string, row ...string) error {
+	rowCount := table.rowCount()
+	if err := table.AddRow(row...); err != nil {
+		return err
+	}
+	if table.rowCount() == rowCount {
+		// The row was dropped because errors are deferred.
+		return nil
+	}
+	return table.SetRowAnnotation(rowCount, annotation)
+}
+
+// renderAnnotation writes the lines of a row annotation, which span all of
+// the columns.
+func (renderer *rowRenderer) renderAnnotation(
+	w renderWriter,
+	annotation string,
+) {
+	vertical := renderer.border.Vertical
+	separators := len(renderer.columnSizes) - 1
+	width := separators * strLengthWithEncoding(vertical)
+	for _, columnSize := range renderer.columnSizes {
+		width += columnSize + 2
+	}
+	textWidth := width - 2 - annotationIndent
+	if textWidth < 1 {
+		textWidth = 1
+	}
+
+	prefix := renderer.colorPrefix(annotationColor)
+	for _, line := range Wrap(annotation, textWidth) {
+		line = Truncate(line, textWidth)
+		w.WriteString(vertical)
+		w.WriteString(prefix)
+		w.WriteString(renderer.padding(1 + annotationIndent))
+		w.WriteString(line)
+		w.WriteString(renderer.padding(
+			textWidth - strLengthWithEncoding(line) + 1))
+		if prefix != "" {
+			w.WriteString(colorReset)
+		}
+		w.WriteString(vertical + "\n")
+	}
+}
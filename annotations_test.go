@@ -0,0 +1,50 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRowAnnotations(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Job"), NewColumnDef("Status")},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddAnnotatedRow(
+		"disk full on node 3 while writing",
+		"backup",
+		"failed"))
+	assert.Nil(t, table.AddRow("archive", "ok"))
+	assert.True(t, table.SetRowAnnotation(2, "missing") != nil)
+	assert.Nil(t, table.SortBy("Job", false))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---------+--------+\n"+
+			"| Job     | Status |\n"+
+			"+---------+--------+\n"+
+			"| archive |     ok |\n"+
+			"|  backup | failed |\n"+
+			"|   disk full on   |\n"+
+			"|   node 3 while   |\n"+
+			"|   writing        |\n"+
+			"+---------+--------+\n",
+		rendered)
+
+	assert.Nil(t, table.SetRowAnnotation(1, ""))
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---------+--------+\n"+
+			"| Job     | Status |\n"+
+			"+---------+--------+\n"+
+			"| archive |     ok |\n"+
+			"|  backup | failed |\n"+
+			"+---------+--------+\n",
+		rendered)
+}
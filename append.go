@@ -0,0 +1,61 @@
+package pretty
+
+import "fmt"
+
+// Append adds all rows of other to the end of this table. Both tables must
+// have the same column names, although the columns of other may be declared
+// in a different order; its rows are reordered to match this table. Columns
+// with the same name are matched in the order they are declared. The rows
+// are added as with AddRows, so they are checked by the validators of this
+// table.
+func (table *Table) Append(other *Table) error {
+	if other == nil {
+		return fmt.Errorf("cannot append nil table")
+	}
+	if err := other.loadSpilledRows(); err != nil {
+		return err
+	}
+	if len(other.columnDefs) != len(table.columnDefs) {
+		return fmt.Errorf(
+			"cannot append table with %d columns to table with %d columns",
+			len(other.columnDefs),
+			len(table.columnDefs))
+	}
+
+	// Map each of our columns to its position in the other table, using each
+	// column of the other table once.
+	sourceIndices := make([]int, len(table.columnDefs))
+	matched := make([]bool, len(other.columnDefs))
+	for i, columnDef := range table.columnDefs {
+		sourceIndices[i] = -1
+		for j, otherDef := range other.columnDefs {
+			if !matched[j] && otherDef.name == columnDef.name {
+				sourceIndices[i] = j
+				matched[j] = true
+				break
+			}
+		}
+		if sourceIndices[i] < 0 {
+			return fmt.Errorf(
+				"cannot append table: column %s does not exist",
+				columnDef.name)
+		}
+	}
+
+	rows := make([][]string, 0, len(other.rows))
+	for otherIndex, otherRow := range other.rows {
+		if err := other.validateStoredRow(otherRow); err != nil {
+			return err
+		}
+		row := make([]string, 0, len(sourceIndices))
+		for i, sourceIndex := range sourceIndices {
+			// Derived columns of this table are computed at render time.
+			if table.columnDefs[i].derive == nil {
+				value := other.cellValue(otherIndex, sourceIndex)
+				row = append(row, value)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return table.AddRows(rows)
+}
@@ -0,0 +1,94 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestAppendTables(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Phone Number"))
+	assert.Nil(t, err)
+	err = table.AddRow("23", "Noel", "Human", "(123) 456-7899")
+	assert.Nil(t, err)
+	err = table.AddRow("83", "David", "Cyborg", "987-654-3211")
+	assert.Nil(t, err)
+
+	// Columns of the appended table are declared in a different order.
+	other, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Phone Number"),
+		NewColumnDef("Type"))
+	assert.Nil(t, err)
+	err = other.AddRow("Pranava", "52", "1-800-123-4567", "Crusher")
+	assert.Nil(t, err)
+	err = other.AddRow("Postnava", "1182", "1 (800) 987-6543", "Kitten")
+	assert.Nil(t, err)
+
+	err = table.Append(other)
+	assert.Nil(t, err)
+
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestAppendTablesWithIncompatibleColumns(t *testing.T) {
+	table := createBasicTable(t)
+
+	fewerColumns, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDef("Type"))
+	assert.Nil(t, err)
+	assert.NotNil(t, table.Append(fewerColumns))
+
+	differentColumns, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Email"))
+	assert.Nil(t, err)
+	assert.NotNil(t, table.Append(differentColumns))
+
+	// Failed appends must not modify the table.
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestAppendTablesWithDuplicateColumns(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("A"), NewColumnDef("A"))
+	assert.Nil(t, err)
+	other, err := NewPrettyTable(NewColumnDef("A"), NewColumnDef("B"))
+	assert.Nil(t, err)
+	assert.Nil(t, other.AddRow("1", "2"))
+	assert.NotNil(t, table.Append(other))
+	assert.Equal(t, 0, table.rowCount())
+
+	same, err := NewPrettyTable(NewColumnDef("A"), NewColumnDef("A"))
+	assert.Nil(t, err)
+	assert.Nil(t, same.AddRow("1", "2"))
+	assert.Nil(t, table.Append(same))
+	assert.DeepEqual(t, [][]string{{"1", "2"}}, table.rows)
+}
+
+func TestAppendTablesValidatesRows(t *testing.T) {
+	table, err := NewTable([]ColumnDef{
+		NewColumnDef("State").
+			WithValidators(ValidateOneOf("ok", "failed")),
+	})
+	assert.Nil(t, err)
+	other, err := NewPrettyTable(NewColumnDef("State"))
+	assert.Nil(t, err)
+	assert.Nil(t, other.AddRow("ok"))
+	assert.Nil(t, other.AddRow("gone"))
+
+	assert.NotNil(t, table.Append(other))
+	assert.Equal(t, 0, table.rowCount())
+
+	table.SetDeferErrors(true)
+	assert.Nil(t, table.Append(other))
+	assert.DeepEqual(t, [][]string{{"ok"}}, table.rows)
+	assert.NotNil(t, table.Err())
+}
@@ -0,0 +1,113 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// BarChart draws a horizontal bar for each label, proportional to its value
+// and colored in turn, with the labels along the vertical axis and the scale
+// along the horizontal one. The chart fills the width of the terminal, or
+// defaultTerminalWidth when stdout is not one. Negative values are drawn as
+// empty bars, and NaN and infinite values are rejected.
+//
+//	vm-1     │████████████████████ 120
+//	database │██████▋ 40
+//	         └────────────────────
+//	          0                120
+func BarChart(labels []string, values []float64) (string, error) {
+	if len(labels) != len(values) {
+		return "", fmt.Errorf(
+			"label count %d must match value count %d",
+			len(labels),
+			len(values))
+	}
+	for i, value := range values {
+		if !isFinite(value) {
+			return "", fmt.Errorf(
+				"value %v of %s must be finite",
+				value,
+				labels[i])
+		}
+	}
+	chart := barChart{
+		labels:       labels,
+		values:       values,
+		width:        lineWidth(0),
+		colorEnabled: colorsEnabled(nil),
+	}
+	return chart.render(), nil
+}
+
+// barChart holds what is needed to draw a bar chart.
+type barChart struct {
+	labels       []string
+	values       []float64
+	width        int
+	colorEnabled bool
+}
+
+// minBarWidth is the narrowest a bar chart draws its longest bar.
+const minBarWidth = 10
+
+func (chart barChart) render() string {
+	if len(chart.values) == 0 {
+		return ""
+	}
+
+	labelWidth := 0
+	valueWidth := 0
+	scale := 0.0
+	formatted := make([]string, len(chart.values))
+	for i, value := range chart.values {
+		if width := strLengthWithEncoding(chart.labels[i]); width > labelWidth {
+			labelWidth = width
+		}
+		formatted[i] = formatNumeric(value)
+		if len(formatted[i]) > valueWidth {
+			valueWidth = len(formatted[i])
+		}
+		scale = math.Max(scale, value)
+	}
+
+	// Each line has the label, a space and the axis before the bar, and a
+	// space and the value after it.
+	barWidth := chart.width - labelWidth - valueWidth - 3
+	if barWidth < minBarWidth {
+		barWidth = minBarWidth
+	}
+
+	var builder strings.Builder
+	for i, value := range chart.values {
+		builder.WriteString(chart.labels[i])
+		builder.WriteString(strings.Repeat(
+			" ",
+			labelWidth-strLengthWithEncoding(chart.labels[i])+1))
+		builder.WriteString(BorderLight.Vertical)
+		fraction := 0.0
+		if scale > 0 {
+			fraction = value / scale
+		}
+		bar := strings.TrimRight(renderBar(fraction, barWidth), " ")
+		builder.WriteString(colorize(
+			bar,
+			chart.colorEnabled,
+			columnColors[i%len(columnColors)]))
+		builder.WriteString(" " + formatted[i] + "\n")
+	}
+
+	// The scale runs from 0 at the axis to the largest value at the end of
+	// the longest bar.
+	indent := strings.Repeat(" ", labelWidth+1)
+	builder.WriteString(indent + BorderLight.BottomLeft +
+		strings.Repeat(BorderLight.Horizontal, barWidth) + "\n")
+	maxLabel := formatNumeric(scale)
+	gap := barWidth - 1 - len(maxLabel)
+	if gap < 1 {
+		gap = 1
+	}
+	builder.WriteString(
+		indent + " 0" + strings.Repeat(" ", gap) + maxLabel + "\n")
+	return builder.String()
+}
@@ -0,0 +1,42 @@
+package pretty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestBarChart(t *testing.T) {
+	_, err := BarChart([]string{"a"}, nil)
+	assert.NotNil(t, err)
+	_, err = BarChart([]string{"a", "b"}, []float64{math.Inf(1), 2})
+	assert.NotNil(t, err)
+	_, err = BarChart([]string{"a"}, []float64{math.NaN()})
+	assert.NotNil(t, err)
+
+	chart := barChart{
+		labels: []string{"vm-1", "database", "empty"},
+		values: []float64{120, 40, -5},
+		width:  34,
+	}
+	assert.EqualString(
+		t,
+		"vm-1     │████████████████████ 120\n"+
+			"database │██████▋ 40\n"+
+			"empty    │ -5\n"+
+			"         └────────────────────\n"+
+			"          0                120\n",
+		chart.render())
+
+	chart.colorEnabled = true
+	chart.labels = []string{"a"}
+	chart.values = []float64{1}
+	chart.width = 0
+	assert.EqualString(
+		t,
+		"a │\x1b[31m██████████\x1b[0m 1\n"+
+			"  └──────────\n"+
+			"   0        1\n",
+		chart.render())
+}
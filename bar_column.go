@@ -0,0 +1,53 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+)
+
+// barEighths are the characters of a partly filled cell of a bar, by the
+// number of eighths filled.
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// NewBarColumnDef creates a ColumnDef whose numeric values are drawn as
+// horizontal bars width characters wide, followed by the value as a
+// percentage of max, for capacity and utilization tables:
+//
+//	████▌      45%
+//
+// Values may be of any numeric type, or numeric strings as stored by AddRow.
+// Values outside 0 to max are drawn as an empty or full bar, and other values,
+// including NaN and infinities, are shown as they are. A negative width is
+// treated as 0.
+func NewBarColumnDef(name string, width int, max float64) ColumnDef {
+	if width < 0 {
+		width = 0
+	}
+	formatter := func(value interface{}) string {
+		number, ok := numericValue(reflect.ValueOf(value))
+		if str, isString := value.(string); isString {
+			var err error
+			number, ok, err = parseNumeric(str)
+			ok = ok && err == nil
+		}
+		if !ok || !isFinite(number) || !isFinite(max) || max <= 0 {
+			return formatValue(value)
+		}
+		return renderBar(number/max, width) +
+			fmt.Sprintf(" %3d%%", int(math.Round(number/max*100)))
+	}
+	return NewColumnDef(name).
+		WithAlignment(LeftJustify).
+		WithFormatter(formatter)
+}
+
+// renderBar draws a bar width characters wide filled to fraction, in eighths
+// of a character, padded with spaces.
+func renderBar(fraction float64, width int) string {
+	fraction = math.Max(0, math.Min(1, fraction))
+	eighths := int(math.Round(fraction * float64(width) * 8))
+	bar := strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+	return bar + strings.Repeat(" ", width-strLengthWithEncoding(bar))
+}
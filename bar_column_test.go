@@ -0,0 +1,47 @@
+package pretty
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRenderBar(t *testing.T) {
+	assert.EqualString(t, "████▌     ", renderBar(0.45, 10))
+	assert.EqualString(t, "          ", renderBar(-1, 10))
+	assert.EqualString(t, "██████████", renderBar(1.5, 10))
+	assert.EqualString(t, "▏   ", renderBar(0.03, 4))
+}
+
+func TestBarColumnDef(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Name").WithAlignment(LeftJustify),
+		NewBarColumnDef("Used", 10, 200))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	assert.Nil(t, table.AddValues("vm-1", 90))
+	assert.Nil(t, table.AddValues("vm-2", 200.0))
+	assert.Nil(t, table.AddRow("vm-3", "0"))
+	assert.Nil(t, table.AddRow("vm-4", "unknown"))
+	assert.Nil(t, table.AddRow("vm-5", "NaN"))
+	assert.Nil(t, table.AddValues("vm-6", math.Inf(1)))
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(output, "| vm-1 | ████▌       45% |"))
+	assert.True(t, strings.Contains(output, "| vm-2 | ██████████ 100% |"))
+	assert.True(t, strings.Contains(output, "| vm-3 |              0% |"))
+	assert.True(t, strings.Contains(output, "| vm-4 | unknown         |"))
+	assert.True(t, strings.Contains(output, "| vm-5 | NaN             |"))
+	assert.True(t, strings.Contains(output, "| vm-6 | +Inf            |"))
+
+	narrow, err := NewPrettyTable(NewBarColumnDef("Used", -3, 10))
+	assert.Nil(t, err)
+	narrow.SetColor(false)
+	assert.Nil(t, narrow.AddRow("5"))
+	output, err = narrow.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(output, "|   50% |"))
+}
@@ -0,0 +1,157 @@
+package pretty
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// BorderStyle is the set of characters used to draw the borders of a table.
+type BorderStyle struct {
+	Horizontal string
+	Vertical   string
+
+	TopLeft     string
+	TopJunction string
+	TopRight    string
+
+	MiddleLeft     string
+	MiddleJunction string
+	MiddleRight    string
+
+	BottomLeft     string
+	BottomJunction string
+	BottomRight    string
+}
+
+var (
+	// BorderASCII draws borders with plain ASCII characters. It is the
+	// default.
+	BorderASCII = BorderStyle{
+		Horizontal:     "-",
+		Vertical:       "|",
+		TopLeft:        "+",
+		TopJunction:    "+",
+		TopRight:       "+",
+		MiddleLeft:     "+",
+		MiddleJunction: "+",
+		MiddleRight:    "+",
+		BottomLeft:     "+",
+		BottomJunction: "+",
+		BottomRight:    "+",
+	}
+
+	// BorderLight draws borders with light box-drawing characters.
+	BorderLight = BorderStyle{
+		Horizontal:     "─",
+		Vertical:       "│",
+		TopLeft:        "┌",
+		TopJunction:    "┬",
+		TopRight:       "┐",
+		MiddleLeft:     "├",
+		MiddleJunction: "┼",
+		MiddleRight:    "┤",
+		BottomLeft:     "└",
+		BottomJunction: "┴",
+		BottomRight:    "┘",
+	}
+
+	// BorderRounded is BorderLight with rounded corners.
+	BorderRounded = BorderStyle{
+		Horizontal:     "─",
+		Vertical:       "│",
+		TopLeft:        "╭",
+		TopJunction:    "┬",
+		TopRight:       "╮",
+		MiddleLeft:     "├",
+		MiddleJunction: "┼",
+		MiddleRight:    "┤",
+		BottomLeft:     "╰",
+		BottomJunction: "┴",
+		BottomRight:    "╯",
+	}
+
+	// BorderHeavy draws borders with heavy box-drawing characters.
+	BorderHeavy = BorderStyle{
+		Horizontal:     "━",
+		Vertical:       "┃",
+		TopLeft:        "┏",
+		TopJunction:    "┳",
+		TopRight:       "┓",
+		MiddleLeft:     "┣",
+		MiddleJunction: "╋",
+		MiddleRight:    "┫",
+		BottomLeft:     "┗",
+		BottomJunction: "┻",
+		BottomRight:    "┛",
+	}
+
+	// BorderDouble draws borders with double-line box-drawing characters.
+	BorderDouble = BorderStyle{
+		Horizontal:     "═",
+		Vertical:       "║",
+		TopLeft:        "╔",
+		TopJunction:    "╦",
+		TopRight:       "╗",
+		MiddleLeft:     "╠",
+		MiddleJunction: "╬",
+		MiddleRight:    "╣",
+		BottomLeft:     "╚",
+		BottomJunction: "╩",
+		BottomRight:    "╝",
+	}
+)
+
+var borderStylesByName = map[string]BorderStyle{
+	"ascii":   BorderASCII,
+	"light":   BorderLight,
+	"rounded": BorderRounded,
+	"heavy":   BorderHeavy,
+	"double":  BorderDouble,
+}
+
+// BorderStyleByName returns the predefined border style with the given name:
+// ascii, light, rounded, heavy or double.
+func BorderStyleByName(name string) (BorderStyle, error) {
+	style, ok := borderStylesByName[strings.ToLower(name)]
+	if !ok {
+		names := make([]string, 0, len(borderStylesByName))
+		for styleName := range borderStylesByName {
+			names = append(names, styleName)
+		}
+		sort.Strings(names)
+		return BorderStyle{}, fmt.Errorf(
+			"unknown border style %q, must be one of %s",
+			name,
+			strings.Join(names, ", "))
+	}
+	return style, nil
+}
+
+// SetBorderStyle sets the characters used to draw the borders of the table.
+func (table *Table) SetBorderStyle(style BorderStyle) {
+	table.border = style
+	table.borderFromEnvironment = false
+}
+
+func (table *Table) borderStyle() BorderStyle {
+	if table.border == (BorderStyle{}) {
+		return BorderASCII
+	}
+	return table.border
+}
+
+// line draws a horizontal border across cells of the given widths, padded by
+// a space on each side.
+func (style BorderStyle) line(
+	columnSizes []int,
+	left string,
+	junction string,
+	right string,
+) string {
+	segments := make([]string, len(columnSizes))
+	for i, columnSize := range columnSizes {
+		segments[i] = strings.Repeat(style.Horizontal, columnSize+2)
+	}
+	return left + strings.Join(segments, junction) + right
+}
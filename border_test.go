@@ -0,0 +1,32 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTableWithBorderStyles(t *testing.T) {
+	for _, name := range []string{"light", "double"} {
+		style, err := BorderStyleByName(name)
+		assert.Nil(t, err)
+
+		table := createBasicTable(t)
+		table.SetHeader("Employees")
+		table.SetBorderStyle(style)
+		assertExpectedTable(t, table, "table_with_"+name+"_border.txt")
+	}
+
+	_, err := BorderStyleByName("fancy")
+	assert.NotNil(t, err)
+}
+
+func TestTableWithMaxWidth(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetMaxWidth(40)
+	assertExpectedTable(t, table, "table_with_max_width.txt")
+
+	// Columns are never shrunk below the minimum width.
+	table.SetMaxWidth(10)
+	assertExpectedTable(t, table, "table_with_tiny_max_width.txt")
+}
@@ -0,0 +1,181 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Box renders text inside a bordered panel, with an optional title in the top
+// border, for warnings, summaries and banners:
+//
+//	┌─ Warning ────────────────┐
+//	│ The cluster is degraded. │
+//	└──────────────────────────┘
+//
+// The box fits the text, but is no wider than the terminal; longer lines are
+// wrapped.
+type Box struct {
+	title   string
+	content string
+
+	border            BorderStyle
+	verticalPadding   int
+	horizontalPadding int
+	width             int
+	attributes        []color.Attribute
+	colorEnabled      *bool
+}
+
+// NewBox creates a Box with the given title and content. The title may be
+// empty.
+func NewBox(title string, content string) *Box {
+	return &Box{
+		title:             title,
+		content:           content,
+		horizontalPadding: 1,
+	}
+}
+
+// SetBorderStyle sets the characters used to draw the border, which default
+// to BorderLight.
+func (box *Box) SetBorderStyle(style BorderStyle) {
+	box.border = style
+}
+
+// SetPadding sets the number of blank lines above and below the content, and
+// of spaces on either side of it. The default is no lines and 1 space.
+// Negative paddings are treated as 0.
+func (box *Box) SetPadding(vertical int, horizontal int) {
+	if vertical < 0 {
+		vertical = 0
+	}
+	if horizontal < 0 {
+		horizontal = 0
+	}
+	box.verticalPadding = vertical
+	box.horizontalPadding = horizontal
+}
+
+// SetWidth sets the total width of the box, including its border. A width of
+// 0, the default, fits the content, up to the width of the terminal.
+func (box *Box) SetWidth(width int) {
+	box.width = width
+}
+
+// SetBorderColor sets the color attributes of the border and title.
+func (box *Box) SetBorderColor(attributes ...color.Attribute) {
+	box.attributes = attributes
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (box *Box) SetColor(enabled bool) {
+	box.colorEnabled = &enabled
+}
+
+// PrettyString renders the box, sized as if written to stdout.
+func (box *Box) PrettyString() string {
+	return box.render(os.Stdout)
+}
+
+// Print prints the box to stdout.
+func (box *Box) Print() error {
+	return box.Fprint(os.Stdout)
+}
+
+// Fprint prints the box to w.
+func (box *Box) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), box.render(w))
+	return err
+}
+
+// render renders the box, limited to the width of w if it is a terminal.
+func (box *Box) render(w io.Writer) string {
+	style := box.border
+	if style == (BorderStyle{}) {
+		style = BorderLight
+	}
+	colorEnabled := colorsEnabled(box.colorEnabled)
+
+	// The border and padding take this much of the width.
+	frameWidth := 2 + 2*box.horizontalPadding
+	titleWidth := 0
+	if box.title != "" {
+		// The title is surrounded by spaces and horizontals.
+		titleWidth = strLengthWithEncoding(box.title) + 4
+	}
+
+	innerWidth := box.width - frameWidth
+	if box.width == 0 {
+		innerWidth = 0
+		for _, line := range strings.Split(box.content, "\n") {
+			if strLengthWithEncoding(line) > innerWidth {
+				innerWidth = strLengthWithEncoding(line)
+			}
+		}
+		if titleWidth > innerWidth+2*box.horizontalPadding {
+			innerWidth = titleWidth - 2*box.horizontalPadding
+		}
+		if maxWidth, ok := terminalWidth(w); ok &&
+			innerWidth+frameWidth > maxWidth {
+			innerWidth = maxWidth - frameWidth
+		}
+	}
+	if innerWidth < minWrapWidth {
+		innerWidth = minWrapWidth
+	}
+	lines := Wrap(box.content, innerWidth)
+
+	var builder strings.Builder
+	writeBorder := func(text string) {
+		builder.WriteString(colorize(text, colorEnabled, box.attributes...))
+	}
+
+	// Write the top border, with the title if there is one.
+	horizontalWidth := innerWidth + 2*box.horizontalPadding
+	if box.title != "" {
+		title := box.title
+		if titleWidth > horizontalWidth {
+			title = truncateStringWithEncoding(title, horizontalWidth-7) + "..."
+			titleWidth = strLengthWithEncoding(title) + 4
+		}
+		writeBorder(style.TopLeft + style.Horizontal + " " + title + " " +
+			strings.Repeat(style.Horizontal, horizontalWidth-titleWidth+1) +
+			style.TopRight)
+	} else {
+		writeBorder(style.TopLeft +
+			strings.Repeat(style.Horizontal, horizontalWidth) +
+			style.TopRight)
+	}
+	builder.WriteString("\n")
+
+	padding := strings.Repeat(" ", box.horizontalPadding)
+	writeLine := func(line string) {
+		writeBorder(style.Vertical)
+		builder.WriteString(padding)
+		builder.WriteString(line)
+		builder.WriteString(
+			strings.Repeat(" ", innerWidth-strLengthWithEncoding(line)))
+		builder.WriteString(padding)
+		writeBorder(style.Vertical)
+		builder.WriteString("\n")
+	}
+	for i := 0; i < box.verticalPadding; i++ {
+		writeLine("")
+	}
+	for _, line := range lines {
+		writeLine(line)
+	}
+	for i := 0; i < box.verticalPadding; i++ {
+		writeLine("")
+	}
+
+	writeBorder(style.BottomLeft +
+		strings.Repeat(style.Horizontal, horizontalWidth) +
+		style.BottomRight)
+	builder.WriteString("\n")
+	return builder.String()
+}
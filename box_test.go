@@ -0,0 +1,50 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestBox(t *testing.T) {
+	box := NewBox("Warning", "The cluster is degraded.\nRun a health check.")
+
+	var buffer bytes.Buffer
+	assert.Nil(t, box.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/box.txt"),
+		buffer.String())
+}
+
+func TestBoxWithWidthAndPadding(t *testing.T) {
+	box := NewBox(
+		"A title that is far too long",
+		"Three nodes were added to the cluster and are now replicating.")
+	box.SetWidth(30)
+	box.SetPadding(1, 2)
+	box.SetBorderStyle(BorderASCII)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, box.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/box_with_width_and_padding.txt"),
+		buffer.String())
+}
+
+func TestBoxWithNegativePadding(t *testing.T) {
+	box := NewBox("", "ok")
+	box.SetPadding(-1, -2)
+	box.SetBorderStyle(BorderASCII)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, box.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"+----------+\n"+
+			"|ok        |\n"+
+			"+----------+\n",
+		buffer.String())
+}
@@ -0,0 +1,157 @@
+package pretty
+
+import (
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// heatLevels are the characters of the cells of a CalendarHeatmap, from no
+// value to the largest values, so that the levels can be told apart without
+// colors.
+var heatLevels = []string{"·", "░", "▒", "▓", "█"}
+
+// heatColors are the color attributes of each of heatLevels.
+var heatColors = [][]color.Attribute{
+	{color.FgHiBlack},
+	{color.FgGreen, color.Faint},
+	{color.FgGreen},
+	{color.FgHiGreen},
+	{color.FgHiGreen, color.Bold},
+}
+
+// CalendarHeatmap shows a value per day as a grid with a column per week and
+// a row per weekday, shaded by value, like the contributions calendar of
+// GitHub:
+//
+//	    Mar   Apr
+//	      █ ░ ▓
+//	Mon   · ▒ █
+//	      ░ ▓ ·
+//	Wed ░ ▒ █
+//	    · ▓ ·
+//	Fri ▒ █ ░
+//	    ▓ · ▒
+//
+// The calendar spans the weeks from the first to the last day with a value.
+type CalendarHeatmap struct {
+	days         map[time.Time]float64
+	colorEnabled *bool
+}
+
+// NewCalendarHeatmap creates an empty CalendarHeatmap.
+func NewCalendarHeatmap() *CalendarHeatmap {
+	return &CalendarHeatmap{days: map[time.Time]float64{}}
+}
+
+// Add adds value to the value of the day of t, in the location of t, and
+// returns the CalendarHeatmap. Negative totals are shown as no value.
+func (heatmap *CalendarHeatmap) Add(
+	t time.Time,
+	value float64,
+) *CalendarHeatmap {
+	heatmap.days[calendarDay(t)] += value
+	return heatmap
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (heatmap *CalendarHeatmap) SetColor(enabled bool) {
+	heatmap.colorEnabled = &enabled
+}
+
+// PrettyString renders the calendar.
+func (heatmap *CalendarHeatmap) PrettyString() string {
+	return heatmap.render()
+}
+
+// Print prints the calendar to stdout.
+func (heatmap *CalendarHeatmap) Print() error {
+	return heatmap.Fprint(os.Stdout)
+}
+
+// Fprint prints the calendar to w.
+func (heatmap *CalendarHeatmap) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), heatmap.render())
+	return err
+}
+
+// calendarWeekdayWidth is the width of the weekday labels before the grid.
+const calendarWeekdayWidth = 4
+
+func (heatmap *CalendarHeatmap) render() string {
+	if len(heatmap.days) == 0 {
+		return ""
+	}
+	colorEnabled := colorsEnabled(heatmap.colorEnabled)
+
+	var first, last time.Time
+	highest := 0.0
+	for day, value := range heatmap.days {
+		if first.IsZero() || day.Before(first) {
+			first = day
+		}
+		if day.After(last) {
+			last = day
+		}
+		highest = math.Max(highest, value)
+	}
+	// Weeks start on Sunday.
+	start := first.AddDate(0, 0, -int(first.Weekday()))
+	weekCount := int(last.Sub(start).Hours()/24)/7 + 1
+
+	// Label the first week of each month, if there is room.
+	var builder strings.Builder
+	labels := []byte(strings.Repeat(" ", calendarWeekdayWidth+2*weekCount+2))
+	var previous time.Month
+	free := 0
+	for week := 0; week < weekCount; week++ {
+		month := start.AddDate(0, 0, 7*week).Month()
+		if week == 0 {
+			month = first.Month()
+		}
+		position := calendarWeekdayWidth + 2*week
+		if month == previous || position < free {
+			continue
+		}
+		copy(labels[position:], month.String()[:3])
+		previous = month
+		free = position + 4
+	}
+	builder.WriteString(strings.TrimRight(string(labels), " ") + "\n")
+
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		line := "    "
+		if weekday == time.Monday || weekday == time.Wednesday ||
+			weekday == time.Friday {
+			line = weekday.String()[:3] + " "
+		}
+		for week := 0; week < weekCount; week++ {
+			day := start.AddDate(0, 0, 7*week+int(weekday))
+			if week > 0 {
+				line += " "
+			}
+			if day.Before(first) || day.After(last) {
+				line += " "
+				continue
+			}
+			level := 0
+			if value := heatmap.days[day]; value > 0 && highest > 0 {
+				level = int(math.Ceil(value / highest * 4))
+			}
+			line += colorize(heatLevels[level], colorEnabled, heatColors[level]...)
+		}
+		builder.WriteString(strings.TrimRight(line, " ") + "\n")
+	}
+	return builder.String()
+}
+
+// calendarDay returns midnight UTC of the date of t in its location.
+func calendarDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
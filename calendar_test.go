@@ -0,0 +1,32 @@
+package pretty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestCalendarHeatmap(t *testing.T) {
+	heatmap := NewCalendarHeatmap()
+	heatmap.SetColor(false)
+	assert.EqualString(t, "", heatmap.PrettyString())
+
+	day := time.Date(2019, 3, 20, 23, 0, 0, 0, time.UTC)
+	for i := 0; i < 21; i++ {
+		heatmap.Add(day.AddDate(0, 0, i), float64(i%5))
+	}
+	heatmap.Add(day, 1)
+	heatmap.Add(day.AddDate(0, 0, 1), -1)
+	assert.EqualString(
+		t,
+		"    Mar   Apr\n"+
+			"      █ ░ ▓\n"+
+			"Mon   · ▒ █\n"+
+			"      ░ ▓ ·\n"+
+			"Wed ░ ▒ █\n"+
+			"    · ▓ ·\n"+
+			"Fri ▒ █ ░\n"+
+			"    ▓ · ▒\n",
+		heatmap.PrettyString())
+}
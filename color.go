@@ -0,0 +1,23 @@
+package pretty
+
+import "github.com/fatih/color"
+
+// colorsEnabled reports whether to use colors, given a setting that overrides
+// the global default of the color package when set.
+func colorsEnabled(setting *bool) bool {
+	if setting != nil {
+		return *setting
+	}
+	return !color.NoColor
+}
+
+// colorize wraps text in the escape sequences for attributes if enabled is
+// true.
+func colorize(text string, enabled bool, attributes ...color.Attribute) string {
+	if !enabled || len(attributes) == 0 {
+		return text
+	}
+	printer := color.New(attributes...)
+	printer.EnableColor()
+	return printer.Sprint(text)
+}
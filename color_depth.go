@@ -0,0 +1,229 @@
+package pretty
+
+import (
+	"os"
+	"strconv"
+	"strings"
+)
+
+// ColorDepth is the number of colors a terminal can display.
+type ColorDepth uint
+
+const (
+	// ColorDepthDefault uses the depth detected by DetectColorDepth.
+	ColorDepthDefault ColorDepth = iota
+	// ColorDepth16 is the 16 standard and bright ANSI colors.
+	ColorDepth16 ColorDepth = iota
+	// ColorDepth256 is the 256-color palette of xterm.
+	ColorDepth256 ColorDepth = iota
+	// ColorDepthTrueColor is 24-bit RGB color.
+	ColorDepthTrueColor ColorDepth = iota
+)
+
+// DetectColorDepth returns the color depth of the terminal as advertised by
+// the COLORTERM and TERM environment variables, assuming 16 colors when they
+// claim no more.
+func DetectColorDepth() ColorDepth {
+	switch strings.ToLower(os.Getenv("COLORTERM")) {
+	case "truecolor", "24bit":
+		return ColorDepthTrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return ColorDepth256
+	}
+	return ColorDepth16
+}
+
+// SetColorDepth sets the color depth that colors within values are reduced
+// to, as by DowngradeColors, which defaults to the detected depth of the
+// terminal.
+func (table *Table) SetColorDepth(depth ColorDepth) {
+	table.colorDepth = depth
+}
+
+// WithColorDepth sets the color depth that colors within values are reduced
+// to. See Table.SetColorDepth.
+func WithColorDepth(depth ColorDepth) Option {
+	return func(table *Table) error {
+		table.SetColorDepth(depth)
+		return nil
+	}
+}
+
+// resolvedColorDepth returns the color depth of the table, detecting it if
+// it is not set.
+func (table *Table) resolvedColorDepth() ColorDepth {
+	if table.colorDepth == ColorDepthDefault {
+		return DetectColorDepth()
+	}
+	return table.colorDepth
+}
+
+// DowngradeColors rewrites the 24-bit and 256-color escape sequences in text
+// as the nearest colors available at the given depth, so that themes written
+// for rich terminals still render sanely on others.
+func DowngradeColors(text string, depth ColorDepth) string {
+	if !strings.Contains(text, "\x1b[") {
+		return text
+	}
+	if depth == ColorDepthDefault {
+		depth = DetectColorDepth()
+	}
+	if depth >= ColorDepthTrueColor {
+		return text
+	}
+
+	var builder strings.Builder
+	for {
+		start := strings.Index(text, "\x1b[")
+		if start < 0 {
+			break
+		}
+		end := start + 2
+		for end < len(text) && (text[end] == ';' ||
+			text[end] >= '0' && text[end] <= '9') {
+			end++
+		}
+		builder.WriteString(text[:start])
+		if end < len(text) && text[end] == 'm' {
+			params := downgradeParams(text[start+2:end], depth)
+			builder.WriteString("\x1b[" + params + "m")
+			end++
+		} else {
+			builder.WriteString(text[start:end])
+		}
+		text = text[end:]
+	}
+	builder.WriteString(text)
+	return builder.String()
+}
+
+// downgradeParams rewrites the color parameters of a graphics escape
+// sequence for the given depth.
+func downgradeParams(params string, depth ColorDepth) string {
+	fields := strings.Split(params, ";")
+	downgraded := make([]string, 0, len(fields))
+	for i := 0; i < len(fields); i++ {
+		field := fields[i]
+		if field != "38" && field != "48" || i+1 >= len(fields) {
+			downgraded = append(downgraded, field)
+			continue
+		}
+
+		background := field == "48"
+		var r, g, b, index int
+		switch {
+		case fields[i+1] == "2" && i+4 < len(fields):
+			r = atoi(fields[i+2])
+			g = atoi(fields[i+3])
+			b = atoi(fields[i+4])
+			index = rgbTo256(r, g, b)
+			i += 4
+		case fields[i+1] == "5" && i+2 < len(fields):
+			index = atoi(fields[i+2])
+			r, g, b = rgbOf256(index)
+			i += 2
+		default:
+			downgraded = append(downgraded, field)
+			continue
+		}
+
+		if depth == ColorDepth256 {
+			downgraded = append(downgraded, field, "5", strconv.Itoa(index))
+			continue
+		}
+		code := nearestANSI(r, g, b)
+		offset := 30
+		if code >= 8 {
+			offset = 90 - 8
+		}
+		if background {
+			offset += 10
+		}
+		downgraded = append(downgraded, strconv.Itoa(offset+code))
+	}
+	return strings.Join(downgraded, ";")
+}
+
+// ansiColors are the typical RGB values of the 16 ANSI colors, as in xterm.
+var ansiColors = [16][3]int{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// cubeLevels are the intensities of the 6x6x6 color cube of the 256-color
+// palette.
+var cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+
+// rgbTo256 returns the 256-color palette index nearest to an RGB color, from
+// the color cube or the gray ramp.
+func rgbTo256(r int, g int, b int) int {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		default:
+			return 232 + (r-8)*24/241
+		}
+	}
+	return 16 + 36*cubeIndex(r) + 6*cubeIndex(g) + cubeIndex(b)
+}
+
+// cubeIndex returns the index of the color cube level nearest to v.
+func cubeIndex(v int) int {
+	nearest := 0
+	for i, level := range cubeLevels {
+		if abs(v-level) < abs(v-cubeLevels[nearest]) {
+			nearest = i
+		}
+	}
+	return nearest
+}
+
+// rgbOf256 returns the RGB color of a 256-color palette index.
+func rgbOf256(index int) (int, int, int) {
+	switch {
+	case index < 0 || index > 255:
+		return 0, 0, 0
+	case index < 16:
+		color := ansiColors[index]
+		return color[0], color[1], color[2]
+	case index < 232:
+		index -= 16
+		return cubeLevels[index/36],
+			cubeLevels[index/6%6],
+			cubeLevels[index%6]
+	default:
+		gray := 8 + 10*(index-232)
+		return gray, gray, gray
+	}
+}
+
+// nearestANSI returns the ANSI color, from 0 to 15, nearest to an RGB color.
+func nearestANSI(r int, g int, b int) int {
+	nearest, nearestDistance := 0, -1
+	for i, color := range ansiColors {
+		dr, dg, db := r-color[0], g-color[1], b-color[2]
+		distance := dr*dr + dg*dg + db*db
+		if nearestDistance < 0 || distance < nearestDistance {
+			nearest, nearestDistance = i, distance
+		}
+	}
+	return nearest
+}
+
+func atoi(str string) int {
+	n, _ := strconv.Atoi(str)
+	return n
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
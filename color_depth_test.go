@@ -0,0 +1,69 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestDetectColorDepth(t *testing.T) {
+	t.Setenv("COLORTERM", "truecolor")
+	t.Setenv("TERM", "xterm")
+	assert.Equal(t, ColorDepthTrueColor, DetectColorDepth())
+
+	t.Setenv("COLORTERM", "")
+	t.Setenv("TERM", "xterm-256color")
+	assert.Equal(t, ColorDepth256, DetectColorDepth())
+
+	t.Setenv("TERM", "xterm")
+	assert.Equal(t, ColorDepth16, DetectColorDepth())
+}
+
+func TestDowngradeColors(t *testing.T) {
+	orange := "\x1b[1;38;2;255;135;0mwarn\x1b[0m"
+	assert.EqualString(
+		t,
+		orange,
+		DowngradeColors(orange, ColorDepthTrueColor),
+	)
+	assert.EqualString(
+		t,
+		"\x1b[1;38;5;208mwarn\x1b[0m",
+		DowngradeColors(orange, ColorDepth256),
+	)
+	assert.EqualString(
+		t,
+		"\x1b[1;33mwarn\x1b[0m",
+		DowngradeColors(orange, ColorDepth16),
+	)
+	assert.EqualString(
+		t,
+		"\x1b[44;97mok\x1b[0m",
+		DowngradeColors("\x1b[48;5;19;38;5;231mok\x1b[0m", ColorDepth16),
+	)
+	assert.EqualString(
+		t,
+		"\x1b[2K plain",
+		DowngradeColors("\x1b[2K plain", ColorDepth16),
+	)
+}
+
+func TestTableColorDepth(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Status")},
+		WithColorDepth(ColorDepth16),
+		WithColor(true),
+		WithColumnNames(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("\x1b[38;5;196mdown\x1b[0m"))
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+\n"+
+			"|\x1b[33;1m \x1b[91mdown\x1b[0m \x1b[0m|\n"+
+			"+------+\n",
+		rendered,
+	)
+}
@@ -0,0 +1,63 @@
+package pretty
+
+// UnitPlacement is where the units of columns are shown in the header.
+type UnitPlacement uint
+
+const (
+	// UnitAfterName shows the unit in parentheses after the column name, as
+	// in "Latency (ms)".
+	UnitAfterName UnitPlacement = iota
+	// UnitBelowName shows the unit on a second header line below the column
+	// name.
+	UnitBelowName
+)
+
+// WithUnit returns a copy of the ColumnDef whose values are in unit, which
+// is shown in the header. The unit is not part of the column name, so
+// columns are still looked up and sorted by name alone.
+func (columnDef ColumnDef) WithUnit(unit string) ColumnDef {
+	columnDef.unit = unit
+	return columnDef
+}
+
+// SetUnitPlacement sets where the units of columns are shown in the header.
+func (table *Table) SetUnitPlacement(placement UnitPlacement) {
+	table.unitPlacement = placement
+}
+
+// WithUnitPlacement sets where the units of columns are shown in the header.
+// See Table.SetUnitPlacement.
+func WithUnitPlacement(placement UnitPlacement) Option {
+	return func(table *Table) error {
+		table.SetUnitPlacement(placement)
+		return nil
+	}
+}
+
+// columnLabel returns the name of the column as shown in the header,
+// including its unit if it follows the name.
+func (table *Table) columnLabel(columnDef ColumnDef) string {
+	if columnDef.unit == "" || table.unitPlacement != UnitAfterName {
+		return columnDef.name
+	}
+	return columnDef.name + " (" + columnDef.unit + ")"
+}
+
+// unitLine returns the units of the columns to show on the header line below
+// their names, or nil if there are none.
+func (table *Table) unitLine(columnDefs []ColumnDef) []string {
+	if table.unitPlacement != UnitBelowName {
+		return nil
+	}
+	var units []string
+	for i, columnDef := range columnDefs {
+		if columnDef.unit == "" {
+			continue
+		}
+		if units == nil {
+			units = make([]string, len(columnDefs))
+		}
+		units[i] = columnDef.unit
+	}
+	return units
+}
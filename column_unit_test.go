@@ -0,0 +1,57 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestUnitAfterName(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Host"),
+			NewColumnDef("Latency").WithUnit("ms"),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{{"a", "12"}, {"b", "3"}}))
+	assert.Nil(t, table.SortBy("Latency", false))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+--------------+\n"+
+			"| Host | Latency (ms) |\n"+
+			"+------+--------------+\n"+
+			"|    b |            3 |\n"+
+			"|    a |           12 |\n"+
+			"+------+--------------+\n",
+		rendered)
+}
+
+func TestUnitBelowName(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Host"),
+			NewColumnDef("Used").WithUnit("bytes"),
+		},
+		WithColor(false),
+		WithUnitPlacement(UnitBelowName),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("a", "12"))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+-------+\n"+
+			"| Host | Used  |\n"+
+			"|      | bytes |\n"+
+			"+------+-------+\n"+
+			"|    a |    12 |\n"+
+			"+------+-------+\n",
+		rendered)
+}
@@ -0,0 +1,129 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// columnGap is the number of spaces between columns laid out by Columns.
+const columnGap = 2
+
+// Columns arranges short strings into as many balanced columns as fit in a
+// line, filling each column from top to bottom like ls does:
+//
+//	alpha    delta    golf
+//	bravo    echo     hotel
+//	charlie  foxtrot
+type Columns struct {
+	items  []string
+	header string
+	width  int
+}
+
+// NewColumns creates a Columns laying out the given items.
+func NewColumns(items ...string) *Columns {
+	return &Columns{items: items}
+}
+
+// SetHeader sets a line printed above the columns, underlined like a
+// Subheading.
+func (columns *Columns) SetHeader(header string) {
+	columns.header = header
+}
+
+// SetWidth sets the width of a line. A width of 0, the default, uses the width
+// of the terminal, or defaultTerminalWidth when not writing to one.
+func (columns *Columns) SetWidth(width int) {
+	columns.width = width
+}
+
+// PrettyString renders the columns, sized as if written to stdout.
+func (columns *Columns) PrettyString() string {
+	return columns.render(os.Stdout)
+}
+
+// Print prints the columns to stdout.
+func (columns *Columns) Print() error {
+	return columns.Fprint(os.Stdout)
+}
+
+// Fprint prints the columns to w.
+func (columns *Columns) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), columns.render(w))
+	return err
+}
+
+// render lays out the items to fit the width of w if it is a terminal.
+func (columns *Columns) render(w io.Writer) string {
+	width := columns.width
+	if width == 0 {
+		var ok bool
+		if width, ok = terminalWidth(w); !ok {
+			width = defaultTerminalWidth
+		}
+	}
+
+	var builder strings.Builder
+	if columns.header != "" {
+		builder.WriteString(Subheading(columns.header))
+	}
+	if len(columns.items) == 0 {
+		return builder.String()
+	}
+
+	itemWidths := make([]int, len(columns.items))
+	for i, item := range columns.items {
+		itemWidths[i] = strLengthWithEncoding(item)
+	}
+	rowCount, columnWidths := fitColumns(itemWidths, width)
+
+	for row := 0; row < rowCount; row++ {
+		line := ""
+		for column, columnWidth := range columnWidths {
+			i := column*rowCount + row
+			if i >= len(columns.items) {
+				break
+			}
+			if column > 0 {
+				line += strings.Repeat(" ", columnGap)
+			}
+			line += columns.items[i]
+			line += strings.Repeat(" ", columnWidth-itemWidths[i])
+		}
+		builder.WriteString(strings.TrimRight(line, " "))
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// fitColumns finds the fewest rows that items of the given widths can be
+// arranged in, column by column, within width. It returns the number of rows
+// and the width of each column. Items wider than width get a row each.
+func fitColumns(itemWidths []int, width int) (int, []int) {
+	for rowCount := 1; rowCount < len(itemWidths); rowCount++ {
+		columnCount := (len(itemWidths) + rowCount - 1) / rowCount
+		columnWidths := make([]int, columnCount)
+		for i, itemWidth := range itemWidths {
+			if column := i / rowCount; itemWidth > columnWidths[column] {
+				columnWidths[column] = itemWidth
+			}
+		}
+
+		total := (columnCount - 1) * columnGap
+		for _, columnWidth := range columnWidths {
+			total += columnWidth
+		}
+		if total <= width {
+			return rowCount, columnWidths
+		}
+	}
+
+	widest := 0
+	for _, itemWidth := range itemWidths {
+		if itemWidth > widest {
+			widest = itemWidth
+		}
+	}
+	return len(itemWidths), []int{widest}
+}
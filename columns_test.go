@@ -0,0 +1,38 @@
+package pretty
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestColumns(t *testing.T) {
+	columns := NewColumns(
+		"alpha", "bravo", "charlie", "delta", "echo", "foxtrot", "golf",
+		"hotel")
+	columns.SetHeader("Hosts")
+	columns.SetWidth(25)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, columns.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"Hosts\n"+
+			"-----\n"+
+			"alpha    delta    golf\n"+
+			"bravo    echo     hotel\n"+
+			"charlie  foxtrot\n",
+		buffer.String())
+
+	// Everything fits on one line, or nothing fits beside anything else.
+	columns.SetHeader("")
+	columns.SetWidth(100)
+	assert.EqualString(
+		t,
+		"alpha  bravo  charlie  delta  echo  foxtrot  golf  hotel\n",
+		columns.PrettyString())
+	columns.SetWidth(3)
+	assert.EqualInt(t, 8, strings.Count(columns.PrettyString(), "\n"))
+}
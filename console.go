@@ -0,0 +1,20 @@
+package pretty
+
+import (
+	"io"
+	"os"
+
+	"github.com/mattn/go-colorable"
+)
+
+// consoleWriter returns a writer to w that displays escape sequences as
+// colors. Most terminals do so already, but on Windows the console must have
+// virtual terminal processing turned on, which is done here; older consoles
+// that lack it have colors translated into console calls instead.
+func consoleWriter(w io.Writer) io.Writer {
+	file, ok := w.(*os.File)
+	if !ok || !isTerminal(w) || enableVirtualTerminal(file) {
+		return w
+	}
+	return colorable.NewColorable(file)
+}
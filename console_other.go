@@ -0,0 +1,11 @@
+//go:build !windows
+
+package pretty
+
+import "os"
+
+// enableVirtualTerminal reports whether the terminal that file writes to
+// interprets escape sequences, which terminals outside Windows always do.
+func enableVirtualTerminal(file *os.File) bool {
+	return true
+}
@@ -0,0 +1,19 @@
+package pretty
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestConsoleWriter(t *testing.T) {
+	var buffer bytes.Buffer
+	assert.True(t, consoleWriter(&buffer) == &buffer)
+
+	file, err := os.CreateTemp(t.TempDir(), "table")
+	assert.Nil(t, err)
+	defer file.Close()
+	assert.True(t, consoleWriter(file) == file)
+}
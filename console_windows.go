@@ -0,0 +1,32 @@
+//go:build windows
+
+package pretty
+
+import (
+	"os"
+	"syscall"
+)
+
+// enableVirtualTerminalProcessing is the console mode that interprets escape
+// sequences, available since Windows 10.
+const enableVirtualTerminalProcessing = 0x0004
+
+var setConsoleMode = syscall.NewLazyDLL("kernel32.dll").
+	NewProc("SetConsoleMode")
+
+// enableVirtualTerminal turns on virtual terminal processing for the console
+// that file writes to, and reports whether it is on.
+func enableVirtualTerminal(file *os.File) bool {
+	handle := syscall.Handle(file.Fd())
+	var mode uint32
+	if err := syscall.GetConsoleMode(handle, &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	ok, _, _ := setConsoleMode.Call(
+		uintptr(handle),
+		uintptr(mode|enableVirtualTerminalProcessing))
+	return ok != 0
+}
@@ -0,0 +1,196 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Panel renders the current content of a region of a Dashboard.
+type Panel func() (string, error)
+
+// Dashboard shows several tables, gauges, progress bars or other panels
+// stacked in titled regions, and redraws them in place on an interval, for
+// top-like monitoring commands. When not writing to a terminal, the panels
+// are only written once, by Stop.
+type Dashboard struct {
+	mu sync.Mutex
+
+	w          io.Writer
+	isTerminal bool
+	interval   time.Duration
+	regions    []dashboardRegion
+	// drawnLines is the number of lines drawn by the previous refresh, which
+	// the cursor must move up to draw over them.
+	drawnLines int
+	stop       chan struct{}
+	done       chan struct{}
+	// stopped is whether Stop has drawn the final frame since the last
+	// Start.
+	stopped bool
+	err     error
+}
+
+type dashboardRegion struct {
+	title string
+	panel Panel
+}
+
+// NewDashboard creates a Dashboard writing to w, which refreshes every
+// interval once started.
+func NewDashboard(w io.Writer, interval time.Duration) *Dashboard {
+	return &Dashboard{
+		w:          w,
+		isTerminal: isTerminal(w),
+		interval:   interval,
+	}
+}
+
+// Add adds a region showing panel below the existing ones, under a divider
+// with the given title, or none if the title is empty.
+func (dashboard *Dashboard) Add(title string, panel Panel) {
+	dashboard.mu.Lock()
+	defer dashboard.mu.Unlock()
+	dashboard.regions = append(
+		dashboard.regions,
+		dashboardRegion{title: title, panel: panel})
+}
+
+// AddTable adds a region showing table, which may be changed while shown.
+func (dashboard *Dashboard) AddTable(title string, table *SyncTable) {
+	dashboard.Add(title, table.PrettyString)
+}
+
+// AddStringer adds a region showing the String of component, such as a Gauge
+// or ProgressBar. Progress bars shown on a dashboard should write to
+// ioutil.Discard, so that they are only drawn by the dashboard.
+func (dashboard *Dashboard) AddStringer(title string, component fmt.Stringer) {
+	dashboard.Add(title, func() (string, error) {
+		return component.String(), nil
+	})
+}
+
+// Start refreshes the dashboard now and then every interval, until Stop is
+// called.
+func (dashboard *Dashboard) Start() {
+	dashboard.mu.Lock()
+	if dashboard.stop != nil {
+		dashboard.mu.Unlock()
+		return
+	}
+	stop, done := make(chan struct{}), make(chan struct{})
+	dashboard.stop, dashboard.done = stop, done
+	dashboard.stopped = false
+	dashboard.mu.Unlock()
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(dashboard.interval)
+		defer ticker.Stop()
+		for {
+			if err := dashboard.refresh(false); err != nil {
+				return
+			}
+			select {
+			case <-ticker.C:
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops refreshing the dashboard and draws it a final time. It returns
+// the first error from rendering a panel or writing the dashboard. Calling
+// Stop again does nothing but return the same error.
+func (dashboard *Dashboard) Stop() error {
+	dashboard.mu.Lock()
+	if dashboard.stopped {
+		err := dashboard.err
+		dashboard.mu.Unlock()
+		return err
+	}
+	dashboard.stopped = true
+	stop, done := dashboard.stop, dashboard.done
+	dashboard.stop, dashboard.done = nil, nil
+	dashboard.mu.Unlock()
+	if stop != nil {
+		close(stop)
+		<-done
+	}
+	return dashboard.refresh(true)
+}
+
+// Refresh redraws the dashboard now, if writing to a terminal.
+func (dashboard *Dashboard) Refresh() error {
+	return dashboard.refresh(false)
+}
+
+// refresh draws the dashboard if writing to a terminal, or if final is true.
+// Once drawing fails, it returns the same error without drawing again.
+func (dashboard *Dashboard) refresh(final bool) error {
+	dashboard.mu.Lock()
+	defer dashboard.mu.Unlock()
+	if dashboard.err != nil {
+		return dashboard.err
+	}
+	if !dashboard.isTerminal && !final {
+		return nil
+	}
+
+	frame, err := dashboard.frame()
+	if err == nil {
+		_, err = io.WriteString(consoleWriter(dashboard.w), frame)
+	}
+	dashboard.err = err
+	return err
+}
+
+// frame renders the regions, preceded by the escape sequences that move the
+// cursor back over the previous frame if writing to a terminal.
+func (dashboard *Dashboard) frame() (string, error) {
+	width, ok := terminalWidth(dashboard.w)
+	if !ok {
+		width = defaultTerminalWidth
+	}
+
+	var content strings.Builder
+	for i, region := range dashboard.regions {
+		if i > 0 {
+			content.WriteString("\n")
+		}
+		if region.title != "" {
+			content.WriteString(Divider(region.title, width))
+		}
+		text, err := region.panel()
+		if err != nil {
+			return "", err
+		}
+		content.WriteString(text)
+		if text != "" && !strings.HasSuffix(text, "\n") {
+			content.WriteString("\n")
+		}
+	}
+	if !dashboard.isTerminal {
+		return content.String(), nil
+	}
+
+	// Draw over the previous frame, clearing what is left of each line and
+	// any lines below the new frame.
+	var builder strings.Builder
+	if dashboard.drawnLines > 0 {
+		fmt.Fprintf(&builder, "\x1b[%dA", dashboard.drawnLines)
+	}
+	lines := strings.SplitAfter(content.String(), "\n")
+	for _, line := range lines {
+		if line == "" {
+			continue
+		}
+		builder.WriteString("\r" + strings.TrimSuffix(line, "\n") + "\x1b[K\n")
+	}
+	builder.WriteString("\x1b[J")
+	dashboard.drawnLines = strings.Count(content.String(), "\n")
+	return builder.String(), nil
+}
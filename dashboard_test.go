@@ -0,0 +1,81 @@
+package pretty
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestDashboard(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	syncTable := NewSyncTable(table)
+	assert.Nil(t, syncTable.AddRow("vm-1"))
+	gauge := NewGauge(5, 10)
+	gauge.SetColor(false)
+
+	var buffer bytes.Buffer
+	dashboard := NewDashboard(&buffer, time.Millisecond)
+	dashboard.AddTable("VMs", syncTable)
+	dashboard.AddStringer("", gauge)
+	dashboard.Start()
+	assert.Nil(t, syncTable.AddRow("vm-2"))
+	time.Sleep(5 * time.Millisecond)
+
+	// Nothing is drawn in place when not writing to a terminal.
+	assert.EqualString(t, "", buffer.String())
+	assert.Nil(t, dashboard.Stop())
+	assert.EqualString(
+		t,
+		"── VMs "+Rule(73, BorderLight)+
+			"+------+\n"+
+			"| Name |\n"+
+			"+------+\n"+
+			"| vm-1 |\n"+
+			"| vm-2 |\n"+
+			"+------+\n"+
+			"\n"+
+			"[█████-----] 50%\n",
+		buffer.String())
+}
+
+func TestDashboardInPlace(t *testing.T) {
+	var buffer bytes.Buffer
+	dashboard := NewDashboard(&buffer, time.Hour)
+	dashboard.isTerminal = true
+	lines := "a\nb\n"
+	dashboard.Add("", func() (string, error) {
+		return lines, nil
+	})
+	assert.Nil(t, dashboard.Refresh())
+	lines = "c"
+	assert.Nil(t, dashboard.Refresh())
+	assert.EqualString(
+		t,
+		"\ra\x1b[K\n\rb\x1b[K\n\x1b[J"+
+			"\x1b[2A\rc\x1b[K\n\x1b[J",
+		buffer.String())
+
+	errPanel := errors.New("panel failed")
+	dashboard.Add("", func() (string, error) {
+		return "", errPanel
+	})
+	assert.Equal(t, errPanel, dashboard.Refresh())
+	assert.Equal(t, errPanel, dashboard.Stop())
+}
+
+func TestDashboardStopTwice(t *testing.T) {
+	var buffer bytes.Buffer
+	dashboard := NewDashboard(&buffer, time.Millisecond)
+	dashboard.Add("", func() (string, error) {
+		return "up", nil
+	})
+	dashboard.Start()
+	assert.Nil(t, dashboard.Stop())
+	assert.Nil(t, dashboard.Stop())
+	assert.EqualString(t, "up\n", buffer.String())
+}
@@ -0,0 +1,53 @@
+package pretty
+
+import "errors"
+
+// SetDeferErrors toggles a mode in which adding rows never fails, for call
+// sites that would otherwise ignore the errors. Rows that cannot be added,
+// such as rows of the wrong length, are left out, and the problems are
+// returned together by Err and by every method that renders or exports the
+// table, so that they are reported once, when the table is printed.
+func (table *Table) SetDeferErrors(deferErrors bool) {
+	table.deferErrors = deferErrors
+}
+
+// WithDeferredErrors toggles the mode in which adding rows never fails. See
+// Table.SetDeferErrors.
+func WithDeferredErrors(deferErrors bool) Option {
+	return func(table *Table) error {
+		table.SetDeferErrors(deferErrors)
+		return nil
+	}
+}
+
+// Err returns the problems found while adding rows since errors were
+// deferred, or nil if there were none.
+func (table *Table) Err() error {
+	return errors.Join(table.deferredErrors...)
+}
+
+// deferError records err to be returned by Err and returns nil, if errors
+// are deferred. Otherwise it returns err.
+func (table *Table) deferError(err error) error {
+	if err == nil || !table.deferErrors {
+		return err
+	}
+	table.deferredErrors = append(table.deferredErrors, err)
+	return nil
+}
+
+// validRows returns the rows of the right length, recording errors for the
+// others. Rows are numbered by their position in rows, from offset+1, in the
+// errors.
+func (table *Table) validRows(rows [][]string, offset int) [][]string {
+	valid := rows[:0:0]
+	for i, row := range rows {
+		err := table.validateRow(row, offset+i+1)
+		if err != nil {
+			table.deferredErrors = append(table.deferredErrors, err)
+			continue
+		}
+		valid = append(valid, row)
+	}
+	return valid
+}
@@ -0,0 +1,75 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestDeferErrors(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Count")},
+		WithDeferredErrors(true),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("a", "1"))
+	assert.Nil(t, table.AddRow("b"))
+	assert.Nil(t, table.AddRows([][]string{{"c", "3"}, {"d", "4", "x"}}))
+	assert.Nil(t, table.AddValues("e"))
+
+	rows := table.rows
+	assert.DeepEqual(t, [][]string{{"a", "1"}, {"c", "3"}}, rows)
+
+	err = table.Err()
+	assert.True(t, err != nil)
+	assert.EqualString(
+		t,
+		"row 2: length 1 must match columns 2 (got [\"b\"])\n"+
+			"row 3: length 3 must match columns 2 "+
+			"(got [\"d\" \"4\" \"x\"])\n"+
+			"row 3: length 1 must match columns 2 (got [e])",
+		err.Error(),
+	)
+
+	var buf bytes.Buffer
+	assert.Equal(t, err.Error(), table.Fprint(&buf).Error())
+	assert.EqualInt(t, 0, buf.Len())
+	assert.Equal(
+		t,
+		err.Error(),
+		table.WriteFormat(&buf, FormatCSV).Error(),
+	)
+	_, rowsErr := table.Rows()
+	assert.True(t, rowsErr != nil)
+}
+
+func TestDeferErrorsOff(t *testing.T) {
+	table, err := NewTable([]ColumnDef{NewColumnDef("Name"), NewColumnDef("Count")})
+	assert.Nil(t, err)
+	assert.NotNil(t, table.AddRow("a"))
+	assert.NotNil(t, table.AddRows([][]string{{"b", "2"}, {"c"}}))
+	assert.Nil(t, table.Err())
+	assert.EqualInt(t, 0, len(table.rows))
+}
+
+func TestDeferErrorsNumbersConsecutiveRows(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Count")},
+		WithDeferredErrors(true),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"a", "1"},
+		{"b"},
+		{"c"},
+		{"d", "4"},
+	}))
+	assert.DeepEqual(t, [][]string{{"a", "1"}, {"d", "4"}}, table.rows)
+	assert.EqualString(
+		t,
+		"row 2: length 1 must match columns 2 (got [\"b\"])\n"+
+			"row 3: length 1 must match columns 2 (got [\"c\"])",
+		table.Err().Error(),
+	)
+}
@@ -0,0 +1,208 @@
+package pretty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+const (
+	diffMarkerAdded   = "+"
+	diffMarkerRemoved = "-"
+	diffMarkerChanged = "~"
+)
+
+// DiffTables compares two snapshots of a table and returns a table of the
+// rows that were added (+), removed (-) or changed (~) between them. Rows are
+// matched by the values of keyColumns, or by all of their values if no key
+// columns are given, in which case repeated rows are counted, so that a row
+// that appears twice before and once after is shown as removed once. Changed
+// cells are shown as "before -> after".
+//
+// The returned table has an unnamed marker column followed by the columns of
+// before; after must have the same set of columns. Removed and changed rows
+// are listed in the order of before, followed by added rows in the order of
+// after.
+func DiffTables(
+	before *Table,
+	after *Table,
+	keyColumns ...string,
+) (*Table, error) {
+	if before == nil || after == nil {
+		return nil, fmt.Errorf("cannot diff nil table")
+	}
+	if err := before.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+	if err := after.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+
+	// Compare the values as they are displayed, with the columns of after
+	// reordered to match before.
+	before = before.renderedTable()
+	aligned := &Table{columnDefs: before.columnDefs}
+	if err := aligned.Append(after.renderedTable()); err != nil {
+		return nil, err
+	}
+
+	keyIndices := make([]int, len(keyColumns))
+	for i, keyColumn := range keyColumns {
+		index, err := before.columnIndex(keyColumn)
+		if err != nil {
+			return nil, err
+		}
+		keyIndices[i] = index
+	}
+
+	diffColumnDefs := append(
+		[]ColumnDef{NewColumnDef("")},
+		before.columnDefs...)
+	diff, err := NewPrettyTable(diffColumnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	diff.rowColorOverrides = make(map[int]color.Attribute)
+	addDiffRow := func(marker string, row []string, attribute color.Attribute) {
+		diff.rowColorOverrides[len(diff.rows)] = attribute
+		diff.rows = append(diff.rows, append([]string{marker}, row...))
+	}
+
+	beforeRows := before.rows
+	afterRows := aligned.rows
+	if len(keyIndices) == 0 {
+		// Rows without keys are either equal or unrelated, so they are
+		// only added or removed.
+		afterCounts := countRows(afterRows)
+		for _, beforeRow := range beforeRows {
+			key := rowKey(beforeRow, nil)
+			if afterCounts[key] > 0 {
+				afterCounts[key]--
+				continue
+			}
+			addDiffRow(diffMarkerRemoved, beforeRow, color.FgRed)
+		}
+		beforeCounts := countRows(beforeRows)
+		for _, afterRow := range afterRows {
+			key := rowKey(afterRow, nil)
+			if beforeCounts[key] > 0 {
+				beforeCounts[key]--
+				continue
+			}
+			addDiffRow(diffMarkerAdded, afterRow, color.FgGreen)
+		}
+		return diff, nil
+	}
+
+	afterByKey, err := indexRowsByKey(afterRows, keyIndices)
+	if err != nil {
+		return nil, fmt.Errorf("after: %v", err)
+	}
+	beforeByKey, err := indexRowsByKey(beforeRows, keyIndices)
+	if err != nil {
+		return nil, fmt.Errorf("before: %v", err)
+	}
+
+	for _, beforeRow := range beforeRows {
+		afterIndex, ok := afterByKey[rowKey(beforeRow, keyIndices)]
+		if !ok {
+			addDiffRow(diffMarkerRemoved, beforeRow, color.FgRed)
+			continue
+		}
+
+		afterRow := afterRows[afterIndex]
+		changed := false
+		row := make([]string, len(beforeRow))
+		for i := range beforeRow {
+			row[i] = afterRow[i]
+			if beforeRow[i] != afterRow[i] {
+				row[i] = fmt.Sprintf("%s -> %s", beforeRow[i], afterRow[i])
+				changed = true
+			}
+		}
+		if changed {
+			addDiffRow(diffMarkerChanged, row, color.FgYellow)
+		}
+	}
+	for _, afterRow := range afterRows {
+		if _, ok := beforeByKey[rowKey(afterRow, keyIndices)]; !ok {
+			addDiffRow(diffMarkerAdded, afterRow, color.FgGreen)
+		}
+	}
+
+	return diff, nil
+}
+
+// renderedTable returns a copy of the table holding its rows as they are
+// displayed, with derived columns and formatters replaced by their output.
+func (table *Table) renderedTable() *Table {
+	columnDefs := table.resolvedColumnDefs()
+	plainColumnDefs := make([]ColumnDef, len(columnDefs))
+	for i, columnDef := range columnDefs {
+		columnDef.derive = nil
+		columnDef.formatter = nil
+		columnDef.prefix = ""
+		columnDef.suffix = ""
+		columnDef.timeZone = nil
+		columnDef.shortIDLength = 0
+		columnDef.statusSymbols = false
+		columnDef.validators = nil
+		plainColumnDefs[i] = columnDef
+	}
+	return &Table{
+		columnDefs:      plainColumnDefs,
+		rows:            table.renderedRows(columnDefs),
+		hideColumnNames: table.hideColumnNames,
+	}
+}
+
+func indexRowsByKey(rows [][]string, keyIndices []int) (map[string]int, error) {
+	index := make(map[string]int, len(rows))
+	for i, row := range rows {
+		key := rowKey(row, keyIndices)
+		if _, ok := index[key]; ok {
+			return nil, fmt.Errorf(
+				"duplicate key %q",
+				keyValues(row, keyIndices))
+		}
+		index[key] = i
+	}
+	return index, nil
+}
+
+// countRows returns the number of times each row appears, keyed by rowKey.
+func countRows(rows [][]string) map[string]int {
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[rowKey(row, nil)]++
+	}
+	return counts
+}
+
+// rowKey joins the key values of a row, or all values if there are no key
+// columns, into a map key. Each value is prefixed with its length, so that
+// different values never give the same key.
+func rowKey(row []string, keyIndices []int) string {
+	var builder strings.Builder
+	for _, value := range keyValues(row, keyIndices) {
+		builder.WriteString(strconv.Itoa(len(value)))
+		builder.WriteByte(':')
+		builder.WriteString(value)
+	}
+	return builder.String()
+}
+
+// keyValues returns the key values of a row, or all values if there are no
+// key columns.
+func keyValues(row []string, keyIndices []int) []string {
+	if len(keyIndices) == 0 {
+		return row
+	}
+	values := make([]string, len(keyIndices))
+	for i, keyIndex := range keyIndices {
+		values[i] = row[keyIndex]
+	}
+	return values
+}
@@ -0,0 +1,92 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestDiffTables(t *testing.T) {
+	before := createBasicTable(t)
+
+	after, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Phone Number"))
+	assert.Nil(t, err)
+	err = after.AddRow("23", "Noel", "Human", "(123) 456-7899")
+	assert.Nil(t, err)
+	err = after.AddRow("52", "Pranava", "Android", "1-800-123-4567")
+	assert.Nil(t, err)
+	err = after.AddRow("1182", "Postnava", "Kitten", "1 (800) 987-6543")
+	assert.Nil(t, err)
+	err = after.AddRow("7", "Ada", "Human", "555-0100")
+	assert.Nil(t, err)
+
+	diff, err := DiffTables(before, after, "Employee Number")
+	assert.Nil(t, err)
+	assertExpectedTable(t, diff, "diff_tables.txt")
+}
+
+func TestDiffTablesErrors(t *testing.T) {
+	before := createBasicTable(t)
+
+	other, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	_, err = DiffTables(before, other)
+	assert.NotNil(t, err)
+
+	_, err = DiffTables(before, createBasicTable(t), "Salary")
+	assert.NotNil(t, err)
+
+	// Keys must identify rows uniquely.
+	duplicate := createBasicTable(t)
+	err = duplicate.AddRow("23", "Noel", "Clone", "(123) 456-7899")
+	assert.Nil(t, err)
+	_, err = DiffTables(before, duplicate, "Employee Number")
+	assert.NotNil(t, err)
+}
+
+func TestDiffTablesWithoutKeysCountsRepeatedRows(t *testing.T) {
+	before, err := NewPrettyTable(NewColumnDef("Name"), NewColumnDef("Type"))
+	assert.Nil(t, err)
+	assert.Nil(t, before.AddRows([][]string{
+		{"Noel", "Human"},
+		{"Noel", "Human"},
+		{"David", "Cyborg"},
+	}))
+	after, err := NewPrettyTable(NewColumnDef("Name"), NewColumnDef("Type"))
+	assert.Nil(t, err)
+	assert.Nil(t, after.AddRows([][]string{
+		{"David", "Cyborg"},
+		{"Noel", "Human"},
+		{"David", "Cyborg"},
+	}))
+
+	diff, err := DiffTables(before, after)
+	assert.Nil(t, err)
+	assert.DeepEqual(
+		t,
+		[][]string{{"-", "Noel", "Human"}, {"+", "David", "Cyborg"}},
+		diff.rows)
+}
+
+func TestDiffTablesKeysDoNotCollide(t *testing.T) {
+	// Joined with a separator, both rows would have the same key.
+	before, err := NewPrettyTable(NewColumnDef("A"), NewColumnDef("B"))
+	assert.Nil(t, err)
+	assert.Nil(t, before.AddRow("a\x00", "b"))
+	after, err := NewPrettyTable(NewColumnDef("A"), NewColumnDef("B"))
+	assert.Nil(t, err)
+	assert.Nil(t, after.AddRow("a", "\x00b"))
+
+	for _, keys := range [][]string{nil, {"A", "B"}} {
+		diff, err := DiffTables(before, after, keys...)
+		assert.Nil(t, err)
+		assert.DeepEqual(
+			t,
+			[][]string{{"-", "a\x00", "b"}, {"+", "a", "\x00b"}},
+			diff.rows)
+	}
+}
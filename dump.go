@@ -0,0 +1,284 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// typeColor is the color of type names in the output of ValuePrinter.
+var typeColor = color.FgYellow
+
+// Dump returns a Go-syntax representation of v showing its types and nested
+// fields, with colors if stdout is a terminal. See ValuePrinter for other
+// settings.
+func Dump(v interface{}) string {
+	return NewValuePrinter().Format(v)
+}
+
+// ValuePrinter formats Go values for debugging, with each field of structs
+// and each element of slices and maps on its own line:
+//
+//	pretty.snapshot{
+//	  Name: "daily",
+//	  Tags: []string{
+//	    "vm",
+//	  },
+//	  Parent: &pretty.snapshot{...},
+//	}
+//
+// Unexported fields are included. Pointers already being formatted are shown
+// as <cycle> instead of being followed again, and map entries are sorted by
+// key.
+type ValuePrinter struct {
+	indent       string
+	maxDepth     int
+	colorEnabled *bool
+}
+
+// NewValuePrinter creates a ValuePrinter indenting by two spaces, with no
+// depth limit.
+func NewValuePrinter() *ValuePrinter {
+	return &ValuePrinter{indent: "  "}
+}
+
+// SetIndent sets the string that each level is indented by.
+func (printer *ValuePrinter) SetIndent(indent string) {
+	printer.indent = indent
+}
+
+// SetMaxDepth sets the number of levels of nested values that are shown.
+// Deeper ones are elided as {...}. A depth of 0, the default, shows all
+// levels.
+func (printer *ValuePrinter) SetMaxDepth(maxDepth int) {
+	printer.maxDepth = maxDepth
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (printer *ValuePrinter) SetColor(enabled bool) {
+	printer.colorEnabled = &enabled
+}
+
+// Format returns the representation of v, followed by a newline.
+func (printer *ValuePrinter) Format(v interface{}) string {
+	dumper := valueDumper{
+		printer:      printer,
+		colorEnabled: colorsEnabled(printer.colorEnabled),
+		visiting:     map[uintptr]bool{},
+	}
+	if v == nil {
+		dumper.write("nil", literalColor)
+	} else {
+		dumper.dump(reflect.ValueOf(v), 0)
+	}
+	dumper.builder.WriteString("\n")
+	return dumper.builder.String()
+}
+
+// Print prints the representation of v to stdout.
+func (printer *ValuePrinter) Print(v interface{}) error {
+	return printer.Fprint(os.Stdout, v)
+}
+
+// Fprint prints the representation of v to w.
+func (printer *ValuePrinter) Fprint(w io.Writer, v interface{}) error {
+	_, err := io.WriteString(consoleWriter(w), printer.Format(v))
+	return err
+}
+
+// valueDumper holds the state of formatting a single value.
+type valueDumper struct {
+	printer      *ValuePrinter
+	colorEnabled bool
+	builder      strings.Builder
+	// visiting holds the addresses of the pointers being formatted, to
+	// detect cycles.
+	visiting map[uintptr]bool
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (dumper *valueDumper) write(text string, attributes ...color.Attribute) {
+	dumper.builder.WriteString(
+		colorize(text, dumper.colorEnabled, attributes...))
+}
+
+func (dumper *valueDumper) dump(value reflect.Value, depth int) {
+	valueType := value.Type()
+	switch value.Kind() {
+	case reflect.Bool:
+		dumper.dumpLiteral(
+			valueType,
+			strconv.FormatBool(value.Bool()),
+			literalColor)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		dumper.dumpNumber(value)
+	case reflect.String:
+		dumper.dumpLiteral(
+			valueType,
+			strconv.Quote(value.String()),
+			stringColor)
+	case reflect.Interface:
+		if value.IsNil() {
+			dumper.write("nil", literalColor)
+			return
+		}
+		dumper.dump(value.Elem(), depth)
+	case reflect.Ptr:
+		if value.IsNil() {
+			dumper.write("("+valueType.String()+")", typeColor)
+			dumper.write("(nil)", literalColor)
+			return
+		}
+		if dumper.visiting[value.Pointer()] {
+			dumper.write("&" + valueType.Elem().String() + "{<cycle>}")
+			return
+		}
+		dumper.visiting[value.Pointer()] = true
+		dumper.write("&")
+		dumper.dump(value.Elem(), depth)
+		delete(dumper.visiting, value.Pointer())
+	case reflect.Struct:
+		if valueType == timeType && value.CanInterface() {
+			dumper.write(valueType.String(), typeColor)
+			dumper.write("(")
+			dumper.write(
+				strconv.Quote(value.Interface().(time.Time).Format(
+					time.RFC3339Nano)),
+				stringColor)
+			dumper.write(")")
+			return
+		}
+		dumper.dumpComposite(
+			valueType,
+			value.NumField(),
+			depth,
+			func(i int) {
+				dumper.write(valueType.Field(i).Name, keyColor)
+				dumper.write(": ")
+				dumper.dump(value.Field(i), depth+1)
+			})
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			dumper.write(valueType.String(), typeColor)
+			dumper.write("(nil)", literalColor)
+			return
+		}
+		dumper.dumpComposite(valueType, value.Len(), depth, func(i int) {
+			dumper.dump(value.Index(i), depth+1)
+		})
+	case reflect.Map:
+		if value.IsNil() {
+			dumper.write(valueType.String(), typeColor)
+			dumper.write("(nil)", literalColor)
+			return
+		}
+		keys := value.MapKeys()
+		formatted := make([]string, len(keys))
+		for i, key := range keys {
+			formatted[i] = fmt.Sprint(key)
+		}
+		order := make([]int, len(keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return formatted[order[i]] < formatted[order[j]]
+		})
+		dumper.dumpComposite(valueType, len(keys), depth, func(i int) {
+			key := keys[order[i]]
+			dumper.dump(key, depth+1)
+			dumper.write(": ")
+			dumper.dump(value.MapIndex(key), depth+1)
+		})
+	default:
+		// Functions, channels and unsafe pointers are shown by address.
+		dumper.write("("+valueType.String()+")", typeColor)
+		dumper.write(fmt.Sprintf("(%#x)", value.Pointer()), numberColor)
+	}
+}
+
+// dumpNumber writes a number, converted to its type unless the type is the
+// default for constants.
+func (dumper *valueDumper) dumpNumber(value reflect.Value) {
+	var text string
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		text = strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		text = strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		text = strconv.FormatFloat(value.Float(), 'g', -1, 64)
+	default:
+		text = fmt.Sprint(value.Complex())
+	}
+	dumper.dumpLiteral(value.Type(), text, numberColor)
+}
+
+// defaultTypes are the default types of untyped constants, whose literals
+// are written without a conversion.
+var defaultTypes = map[reflect.Type]bool{
+	reflect.TypeOf(false): true,
+	reflect.TypeOf(""):    true,
+	reflect.TypeOf(0):     true,
+	reflect.TypeOf(0.0):   true,
+	reflect.TypeOf(0i):    true,
+}
+
+// dumpLiteral writes a literal, converted to valueType unless it is the
+// default type of the literal.
+func (dumper *valueDumper) dumpLiteral(
+	valueType reflect.Type,
+	text string,
+	attribute color.Attribute,
+) {
+	if defaultTypes[valueType] {
+		dumper.write(text, attribute)
+		return
+	}
+	dumper.write(valueType.String(), typeColor)
+	dumper.write("(")
+	dumper.write(text, attribute)
+	dumper.write(")")
+}
+
+// dumpComposite writes a value of valueType with count elements, each
+// written by element on its own line.
+func (dumper *valueDumper) dumpComposite(
+	valueType reflect.Type,
+	count int,
+	depth int,
+	element func(i int),
+) {
+	dumper.write(valueType.String(), typeColor)
+	maxDepth := dumper.printer.maxDepth
+	switch {
+	case count == 0:
+		dumper.write("{}")
+		return
+	case maxDepth > 0 && depth >= maxDepth:
+		dumper.write("{...}")
+		return
+	}
+
+	indent := strings.Repeat(dumper.printer.indent, depth+1)
+	dumper.write("{\n")
+	for i := 0; i < count; i++ {
+		dumper.write(indent)
+		element(i)
+		dumper.write(",\n")
+	}
+	dumper.write(strings.Repeat(dumper.printer.indent, depth) + "}")
+}
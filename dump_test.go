@@ -0,0 +1,91 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+type dumpTestState string
+
+type dumpTestSnapshot struct {
+	Name    string
+	State   dumpTestState
+	Size    uint16
+	Tags    []string
+	Labels  map[string]int
+	Created time.Time
+	Parent  *dumpTestSnapshot
+	Extra   interface{}
+	private bool
+}
+
+func TestValuePrinter(t *testing.T) {
+	snapshot := &dumpTestSnapshot{
+		Name:    "daily",
+		State:   "done",
+		Size:    10,
+		Tags:    []string{"vm"},
+		Labels:  map[string]int{"b": 2, "a": 1},
+		Created: time.Date(2019, 3, 14, 15, 9, 26, 0, time.UTC),
+		Extra:   []interface{}{nil, 1.5},
+		private: true,
+	}
+	snapshot.Parent = snapshot
+
+	printer := NewValuePrinter()
+	printer.SetColor(false)
+	assert.EqualString(
+		t,
+		`&pretty.dumpTestSnapshot{
+  Name: "daily",
+  State: pretty.dumpTestState("done"),
+  Size: uint16(10),
+  Tags: []string{
+    "vm",
+  },
+  Labels: map[string]int{
+    "a": 1,
+    "b": 2,
+  },
+  Created: time.Time("2019-03-14T15:09:26Z"),
+  Parent: &pretty.dumpTestSnapshot{<cycle>},
+  Extra: []interface {}{
+    nil,
+    1.5,
+  },
+  private: true,
+}
+`,
+		printer.Format(snapshot))
+
+	printer.SetMaxDepth(1)
+	printer.SetIndent("\t")
+	assert.EqualString(
+		t,
+		"[]*pretty.dumpTestSnapshot{\n"+
+			"\t&pretty.dumpTestSnapshot{...},\n"+
+			"\t(*pretty.dumpTestSnapshot)(nil),\n"+
+			"}\n",
+		printer.Format([]*dumpTestSnapshot{snapshot, nil}))
+
+	assert.EqualString(t, "nil\n", printer.Format(nil))
+	assert.EqualString(t, "[]int(nil)\n", printer.Format([]int(nil)))
+	assert.EqualString(t, "struct {}{}\n", printer.Format(struct{}{}))
+
+	// Unexported times cannot be formatted as such.
+	printer.SetMaxDepth(2)
+	output := printer.Format(struct{ created time.Time }{})
+	assert.True(t, strings.HasPrefix(output, "struct { created time.Time }{\n"))
+}
+
+func TestValuePrinterColors(t *testing.T) {
+	printer := NewValuePrinter()
+	printer.SetColor(true)
+	assert.EqualString(
+		t,
+		"\x1b[33mint8\x1b[0m(\x1b[36m1\x1b[0m)\n",
+		printer.Format(int8(1)))
+}
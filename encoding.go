@@ -0,0 +1,123 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of a table exported as CSV or TSV.
+type Encoding uint
+
+const (
+	// EncodingUTF8 is UTF-8 without a byte order mark. It is the default.
+	EncodingUTF8 Encoding = iota
+	// EncodingUTF8BOM is UTF-8 starting with a byte order mark, which Excel
+	// needs to read UTF-8 rather than the legacy encoding of the system.
+	EncodingUTF8BOM Encoding = iota
+	// EncodingUTF16LE is little-endian UTF-16 starting with a byte order
+	// mark, as written by Excel's "Unicode Text".
+	EncodingUTF16LE Encoding = iota
+	// EncodingLatin1 is ISO 8859-1, for programs that read nothing else.
+	// Characters it lacks are transliterated, such as curly quotes to
+	// straight ones and € to EUR, or replaced with ?.
+	EncodingLatin1 Encoding = iota
+)
+
+// utf8BOM is the byte order mark in UTF-8.
+const utf8BOM = "\ufeff"
+
+// latin1Transliterations are the ASCII replacements for common characters
+// that Latin-1 lacks.
+var latin1Transliterations = map[rune]string{
+	'‘': "'",
+	'’': "'",
+	'‚': "'",
+	'′': "'",
+	'“': `"`,
+	'”': `"`,
+	'„': `"`,
+	'″': `"`,
+	'‐': "-",
+	'‑': "-",
+	'–': "-",
+	'—': "-",
+	'−': "-",
+	'…': "...",
+	'•': "*",
+	'€': "EUR",
+	'™': "(TM)",
+	'→': "->",
+	'←': "<-",
+	'≤': "<=",
+	'≥': ">=",
+}
+
+// SetExportEncoding sets the character encoding of the table when it is
+// written as CSV or TSV by WriteFormat, since spreadsheets often misread
+// plain UTF-8.
+func (table *Table) SetExportEncoding(encoding Encoding) {
+	table.exportEncoding = encoding
+}
+
+// WithExportEncoding sets the character encoding of CSV and TSV exports. See
+// Table.SetExportEncoding.
+func WithExportEncoding(encoding Encoding) Option {
+	return func(table *Table) error {
+		table.SetExportEncoding(encoding)
+		return nil
+	}
+}
+
+// writeEncoded calls write with a writer that converts the UTF-8 written to
+// it to the given encoding before it reaches w.
+func writeEncoded(
+	w io.Writer,
+	encoding Encoding,
+	write func(w io.Writer) error,
+) error {
+	if encoding == EncodingUTF8 {
+		return write(w)
+	}
+
+	var buffer bytes.Buffer
+	if err := write(&buffer); err != nil {
+		return err
+	}
+	_, err := w.Write(encode(buffer.Bytes(), encoding))
+	return err
+}
+
+// encode converts UTF-8 text to the given encoding.
+func encode(text []byte, encoding Encoding) []byte {
+	switch encoding {
+	case EncodingUTF8BOM:
+		return append([]byte(utf8BOM), text...)
+	case EncodingUTF16LE:
+		units := utf16.Encode(bytes.Runes(append([]byte(utf8BOM), text...)))
+		encoded := make([]byte, 2*len(units))
+		for i, unit := range units {
+			binary.LittleEndian.PutUint16(encoded[2*i:], unit)
+		}
+		return encoded
+	case EncodingLatin1:
+		encoded := make([]byte, 0, len(text))
+		for len(text) > 0 {
+			r, size := utf8.DecodeRune(text)
+			text = text[size:]
+			switch replacement, ok := latin1Transliterations[r]; {
+			case r <= 0xff:
+				encoded = append(encoded, byte(r))
+			case ok:
+				encoded = append(encoded, replacement...)
+			default:
+				encoded = append(encoded, '?')
+			}
+		}
+		return encoded
+	default:
+		return text
+	}
+}
@@ -0,0 +1,51 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestExportEncoding(t *testing.T) {
+	tests := []struct {
+		encoding Encoding
+		expected string
+	}{
+		{EncodingUTF8, "Name\n“Café” – 5€\n"},
+		{EncodingUTF8BOM, "\xef\xbb\xbfName\n“Café” – 5€\n"},
+		{EncodingLatin1, "Name\n\"Caf\xe9\" - 5EUR\n"},
+	}
+	for _, test := range tests {
+		table, err := NewTable(
+			[]ColumnDef{NewColumnDef("Name")},
+			WithExportEncoding(test.encoding),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, table.AddRow("“Café” – 5€"))
+
+		var buffer bytes.Buffer
+		assert.Nil(t, table.WriteFormat(&buffer, FormatTSV))
+		assert.EqualString(t, test.expected, buffer.String())
+	}
+
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("A")},
+		WithExportEncoding(EncodingUTF16LE),
+		WithColumnNames(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("é😀"))
+	var buffer bytes.Buffer
+	assert.Nil(t, table.WriteFormat(&buffer, FormatCSV))
+	assert.DeepEqual(
+		t,
+		[]byte{
+			0xff, 0xfe, // byte order mark
+			0xe9, 0x00, // é
+			0x3d, 0xd8, 0x00, 0xde, // 😀, as a surrogate pair
+			'\n', 0x00,
+		},
+		buffer.Bytes(),
+	)
+}
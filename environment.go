@@ -0,0 +1,55 @@
+package pretty
+
+import (
+	"os"
+	"strconv"
+)
+
+// Environment variables that end users can set to change the defaults of
+// every new table. Settings made by the program take precedence.
+const (
+	// EnvStyle names the default border style, as accepted by
+	// BorderStyleByName.
+	EnvStyle = "PRETTY_STYLE"
+	// EnvMaxWidth is the default maximum width of a table, in characters.
+	EnvMaxWidth = "PRETTY_MAX_WIDTH"
+	// EnvNoColor disables colors when set to any non-empty value.
+	EnvNoColor = "PRETTY_NO_COLOR"
+	// EnvAccessible turns on the linearized rendering for screen readers
+	// when set to any non-empty value. See Table.SetAccessible.
+	EnvAccessible = "PRETTY_ACCESSIBLE"
+)
+
+// applyEnvironment configures the table from the environment. Invalid values
+// are ignored, so that a bad setting never breaks the program.
+func (table *Table) applyEnvironment() {
+	if style, ok := environmentBorderStyle(); ok {
+		table.SetBorderStyle(style)
+		table.borderFromEnvironment = true
+	}
+	if value := os.Getenv(EnvMaxWidth); value != "" {
+		if maxWidth, err := strconv.Atoi(value); err == nil && maxWidth > 0 {
+			table.SetMaxWidth(maxWidth)
+			table.maxWidthFromEnvironment = true
+		}
+	}
+	if os.Getenv(EnvNoColor) != "" {
+		table.SetColor(false)
+		table.colorFromEnvironment = true
+	}
+	if os.Getenv(EnvAccessible) != "" {
+		table.SetAccessible(true)
+		table.accessibleFromEnvironment = true
+	}
+}
+
+// environmentBorderStyle returns the border style named by EnvStyle, if it is
+// set to a valid name.
+func environmentBorderStyle() (BorderStyle, bool) {
+	name := os.Getenv(EnvStyle)
+	if name == "" {
+		return BorderStyle{}, false
+	}
+	style, err := BorderStyleByName(name)
+	return style, err == nil
+}
@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTableWithEnvironmentDefaults(t *testing.T) {
+	t.Setenv(EnvStyle, "rounded")
+	t.Setenv(EnvMaxWidth, "50")
+	t.Setenv(EnvNoColor, "1")
+
+	table := createBasicTable(t)
+	table.SetHeader("Employees")
+	assert.False(t, *table.colorEnabled)
+	assertExpectedTable(t, table, "table_with_environment_defaults.txt")
+
+	// Settings made by the program take precedence.
+	table.SetBorderStyle(BorderASCII)
+	table.SetMaxWidth(0)
+	table.header = nil
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestTableWithInvalidEnvironment(t *testing.T) {
+	t.Setenv(EnvStyle, "fancy")
+	t.Setenv(EnvMaxWidth, "wide")
+
+	table := createBasicTable(t)
+	assert.Nil(t, table.colorEnabled)
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestTestStringIgnoresEnvironment(t *testing.T) {
+	t.Setenv(EnvStyle, "rounded")
+	t.Setenv(EnvMaxWidth, "50")
+	t.Setenv(EnvAccessible, "1")
+
+	table := createBasicTable(t)
+	table.SetColor(true)
+	output, err := table.TestString()
+	assert.Nil(t, err)
+	assert.EqualString(t, readFileAsString(t, "test/basic_table.txt"), output)
+	assert.True(t, *table.colorEnabled)
+	assert.Equal(t, BorderRounded, table.borderStyle())
+	assert.EqualInt(t, 50, table.maxWidth)
+	assert.True(t, table.accessible)
+
+	// Settings made by the program are kept.
+	table.SetAccessible(false)
+	table.SetMaxWidth(50)
+	output, err = table.TestString()
+	assert.Nil(t, err)
+	assert.True(t, output != readFileAsString(t, "test/basic_table.txt"))
+}
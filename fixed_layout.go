@@ -0,0 +1,51 @@
+package pretty
+
+import "fmt"
+
+// SetFixedLayout gives each column a fixed width, so that the table has the
+// same geometry every time it is rendered, whatever its values: longer
+// values are truncated, and the maximum widths of the table and its columns
+// are ignored. It suits periodic snapshots written to logs, which should diff
+// cleanly. Calling it without widths restores sizing by content.
+func (table *Table) SetFixedLayout(widths ...int) error {
+	if len(widths) == 0 {
+		table.fixedWidths = nil
+		return nil
+	}
+	if len(widths) != len(table.columnDefs) {
+		return fmt.Errorf(
+			"widths length %d must match columns %d",
+			len(widths),
+			len(table.columnDefs))
+	}
+	for i, width := range widths {
+		if width < 1 {
+			return fmt.Errorf(
+				"width %d of column %q must be positive",
+				width,
+				table.columnDefs[i].name)
+		}
+	}
+	table.fixedWidths = append([]int(nil), widths...)
+	return nil
+}
+
+// WithFixedLayout gives each column a fixed width. See Table.SetFixedLayout.
+func WithFixedLayout(widths ...int) Option {
+	return func(table *Table) error {
+		return table.SetFixedLayout(widths...)
+	}
+}
+
+// validateFixedLayout checks that the fixed widths still match the columns,
+// which may have changed since they were set.
+func (table *Table) validateFixedLayout() error {
+	if table.fixedWidths != nil &&
+		len(table.fixedWidths) != len(table.columnDefs) {
+		return fmt.Errorf(
+			"fixed widths length %d must match columns %d",
+			len(table.fixedWidths),
+			len(table.columnDefs))
+	}
+	return nil
+}
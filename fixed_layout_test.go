@@ -0,0 +1,55 @@
+package pretty
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFixedLayout(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Status")},
+		WithColor(false),
+		WithFixedLayout(6, 8),
+		WithMaxWidth(10),
+	)
+	assert.Nil(t, err)
+
+	assert.Nil(t, table.AddRow("a", "ok"))
+	first, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+--------+----------+\n"+
+			"| Name   | Status   |\n"+
+			"+--------+----------+\n"+
+			"|      a |       ok |\n"+
+			"+--------+----------+\n",
+		first,
+	)
+
+	assert.Nil(t, table.SetRows([][]string{{"much longer", "degraded"}}))
+	second, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+--------+----------+\n"+
+			"| Name   | Status   |\n"+
+			"+--------+----------+\n"+
+			"| muc... | degraded |\n"+
+			"+--------+----------+\n",
+		second,
+	)
+
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	var restored Table
+	assert.Nil(t, json.Unmarshal(data, &restored))
+	assert.DeepEqual(t, []int{6, 8}, restored.fixedWidths)
+
+	assert.NotNil(t, table.SetFixedLayout(6))
+	assert.NotNil(t, table.SetFixedLayout(6, 0))
+	assert.Nil(t, table.SetFixedLayout())
+	assert.True(t, table.fixedWidths == nil)
+}
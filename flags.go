@@ -0,0 +1,145 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// FlagSet is the subset of *pflag.FlagSet, as returned by the Flags method of
+// a cobra.Command, used to register output flags.
+type FlagSet interface {
+	StringVarP(
+		p *string,
+		name string,
+		shorthand string,
+		value string,
+		usage string)
+	BoolVarP(
+		p *bool,
+		name string,
+		shorthand string,
+		value bool,
+		usage string)
+}
+
+// OutputFlags holds the standard command line flags controlling how a table
+// is printed:
+//
+//	-o, --output     table, json, csv or markdown, or wide for a table
+//	                 whose values are never truncated
+//	    --wide       never truncate values
+//	    --no-header  omit the column names
+//	    --sort       column to sort by, prefixed with - for descending order
+//	    --columns    comma-separated list of columns to show
+//	    --tz         time zone to show times in, such as Local or
+//	                 America/New_York
+//
+// Register the flags on a command, then call Print once the command has
+// produced its table.
+type OutputFlags struct {
+	Output   string
+	Wide     bool
+	NoHeader bool
+	Sort     string
+	Columns  string
+	TimeZone string
+}
+
+// outputWide is the --output value that prints a table in wide mode.
+const outputWide = "wide"
+
+// Register adds the output flags to flagSet, e.g. cmd.Flags() of a cobra
+// command.
+func (flags *OutputFlags) Register(flagSet FlagSet) {
+	flagSet.StringVarP(
+		&flags.Output,
+		"output",
+		"o",
+		FormatTable.String(),
+		"output format, one of: "+
+			strings.Join(formatNames, ", ")+", "+outputWide)
+	flagSet.BoolVarP(
+		&flags.Wide,
+		"wide",
+		"",
+		false,
+		"do not truncate values to fit the terminal")
+	flagSet.BoolVarP(
+		&flags.NoHeader,
+		"no-header",
+		"",
+		false,
+		"do not print column names")
+	flagSet.StringVarP(
+		&flags.Sort,
+		"sort",
+		"",
+		"",
+		"column to sort by, prefix with - to sort in descending order")
+	flagSet.StringVarP(
+		&flags.Columns,
+		"columns",
+		"",
+		"",
+		"comma-separated list of columns to print")
+	flagSet.StringVarP(
+		&flags.TimeZone,
+		"tz",
+		"",
+		"",
+		"time zone to print times in, such as Local or America/New_York")
+}
+
+// Apply sorts the table, selects its columns, hides its column names, turns
+// on wide mode and sets the time zone as requested by the flags.
+func (flags *OutputFlags) Apply(table *Table) error {
+	if flags.Sort != "" {
+		column := strings.TrimPrefix(flags.Sort, "-")
+		descending := column != flags.Sort
+		if err := table.SortBy(column, descending); err != nil {
+			return fmt.Errorf("invalid --sort: %v", err)
+		}
+	}
+	if flags.Columns != "" {
+		columns := strings.Split(flags.Columns, ",")
+		for i := range columns {
+			columns[i] = strings.TrimSpace(columns[i])
+		}
+		if err := table.SelectColumns(columns...); err != nil {
+			return fmt.Errorf("invalid --columns: %v", err)
+		}
+	}
+	if flags.NoHeader {
+		table.ShowColumnNames(false)
+	}
+	if flags.Wide || flags.Output == outputWide {
+		table.SetWide(true)
+	}
+	if flags.TimeZone != "" {
+		location, err := time.LoadLocation(flags.TimeZone)
+		if err != nil {
+			return fmt.Errorf("invalid --tz: %v", err)
+		}
+		table.SetTimeZone(location)
+	}
+	return nil
+}
+
+// Print applies the flags to the table and writes it to w in the requested
+// output format.
+func (flags *OutputFlags) Print(w io.Writer, table *Table) error {
+	format := FormatTable
+	if flags.Output != "" && flags.Output != outputWide {
+		var err error
+		format, err = ParseFormat(flags.Output)
+		if err != nil {
+			return fmt.Errorf("invalid --output: %v", err)
+		}
+	}
+	if err := flags.Apply(table); err != nil {
+		return err
+	}
+	return table.WriteFormat(w, format)
+}
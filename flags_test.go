@@ -0,0 +1,110 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+// fakeFlagSet records registered flags like a pflag.FlagSet, and lets tests
+// set their values as if parsed from the command line.
+type fakeFlagSet struct {
+	strings map[string]*string
+	bools   map[string]*bool
+}
+
+func (flagSet *fakeFlagSet) StringVarP(
+	p *string,
+	name string,
+	shorthand string,
+	value string,
+	usage string,
+) {
+	*p = value
+	flagSet.strings[name] = p
+	if shorthand != "" {
+		flagSet.strings[shorthand] = p
+	}
+}
+
+func (flagSet *fakeFlagSet) BoolVarP(
+	p *bool,
+	name string,
+	shorthand string,
+	value bool,
+	usage string,
+) {
+	*p = value
+	flagSet.bools[name] = p
+}
+
+func TestOutputFlags(t *testing.T) {
+	flagSet := &fakeFlagSet{
+		strings: make(map[string]*string),
+		bools:   make(map[string]*bool),
+	}
+	var flags OutputFlags
+	flags.Register(flagSet)
+	assert.EqualString(t, "table", flags.Output)
+
+	*flagSet.strings["o"] = "csv"
+	*flagSet.strings["sort"] = "-Employee Number"
+	*flagSet.strings["columns"] = "Name, Employee Number"
+	*flagSet.bools["no-header"] = true
+
+	var buffer bytes.Buffer
+	err := flags.Print(&buffer, createBasicTable(t))
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"Postnava,1182\nDavid,83\nPranava,52\nNoel,23\n",
+		buffer.String())
+}
+
+func TestOutputFlagsTableFormat(t *testing.T) {
+	flags := OutputFlags{Sort: "Name"}
+
+	table := createBasicTable(t)
+	var buffer bytes.Buffer
+	err := flags.Print(&buffer, table)
+	assert.Nil(t, err)
+	expected := readFileAsString(t, "test/basic_table_sorted_by_name.txt")
+	assert.EqualString(t, expected+"\n", buffer.String())
+}
+
+func TestOutputFlagsErrors(t *testing.T) {
+	var buffer bytes.Buffer
+	flags := OutputFlags{Output: "xml"}
+	assert.NotNil(t, flags.Print(&buffer, createBasicTable(t)))
+
+	flags = OutputFlags{Sort: "Salary"}
+	assert.NotNil(t, flags.Print(&buffer, createBasicTable(t)))
+
+	flags = OutputFlags{Columns: "Name,Salary"}
+	assert.NotNil(t, flags.Print(&buffer, createBasicTable(t)))
+}
+
+func TestOutputFlagsWide(t *testing.T) {
+	for _, flags := range []OutputFlags{{Wide: true}, {Output: "wide"}} {
+		table, err := NewTable(
+			[]ColumnDef{NewColumnDefWithWidth("Name", 6)},
+			WithColor(false),
+			WithMaxWidth(5),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, table.AddRow("much longer"))
+
+		var buffer bytes.Buffer
+		assert.Nil(t, flags.Print(&buffer, table))
+		assert.EqualString(
+			t,
+			"+-------------+\n"+
+				"| Name        |\n"+
+				"+-------------+\n"+
+				"| much longer |\n"+
+				"+-------------+\n\n",
+			buffer.String())
+		assert.EqualInt(t, 0, len(table.Truncations()))
+	}
+}
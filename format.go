@@ -0,0 +1,277 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Format is an output format that a table can be written in.
+type Format uint
+
+const (
+	// FormatTable is the bordered table produced by PrettyString.
+	FormatTable Format = iota
+	// FormatJSON is a JSON array with one object per row, keyed by column
+	// name.
+	FormatJSON Format = iota
+	// FormatCSV is comma-separated values with a record of column names.
+	FormatCSV Format = iota
+	// FormatMarkdown is a GitHub-flavored Markdown table.
+	FormatMarkdown Format = iota
+	// FormatTSV is tab-separated values with a line of column names, suited
+	// to tools such as cut and awk. Tabs and newlines within values are
+	// replaced by spaces.
+	FormatTSV Format = iota
+	// FormatHTML is an HTML table, with the column names in its head.
+	// Newlines within values are replaced by line breaks.
+	FormatHTML Format = iota
+)
+
+var formatNames = []string{
+	FormatTable:    "table",
+	FormatJSON:     "json",
+	FormatCSV:      "csv",
+	FormatMarkdown: "markdown",
+	FormatTSV:      "tsv",
+	FormatHTML:     "html",
+}
+
+// String returns the name of the format, as accepted by ParseFormat.
+func (format Format) String() string {
+	if int(format) < len(formatNames) {
+		return formatNames[format]
+	}
+	return fmt.Sprintf("Format(%d)", uint(format))
+}
+
+// ParseFormat returns the Format with the given name.
+func ParseFormat(name string) (Format, error) {
+	for format, formatName := range formatNames {
+		if strings.EqualFold(name, formatName) {
+			return Format(format), nil
+		}
+	}
+	return 0, fmt.Errorf(
+		"unknown format %q, must be one of %s",
+		name,
+		strings.Join(formatNames, ", "))
+}
+
+// WriteFormat writes the table to w in the given format. Values are written
+// as they are displayed, without truncation or colors. Only FormatTable
+// includes the header and row count.
+func (table *Table) WriteFormat(w io.Writer, format Format) error {
+	if format == FormatTable {
+		return table.fprintTable(w)
+	}
+	if err := table.Err(); err != nil {
+		return err
+	}
+
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+	for _, row := range table.rows {
+		if err := table.validateStoredRow(row); err != nil {
+			return err
+		}
+	}
+	rendered := table.renderedTable()
+	rendered.rows = replaceRowHyperlinks(
+		rendered.rows,
+		table.hyperlinkFallback)
+
+	switch format {
+	case FormatJSON:
+		return rendered.writeJSON(w)
+	case FormatCSV:
+		return writeEncoded(w, table.exportEncoding, rendered.writeCSV)
+	case FormatMarkdown:
+		return rendered.writeMarkdown(w)
+	case FormatTSV:
+		return writeEncoded(w, table.exportEncoding, rendered.writeTSV)
+	case FormatHTML:
+		return rendered.writeHTML(w)
+	default:
+		return fmt.Errorf("unknown format %v", format)
+	}
+}
+
+// plainString renders the table as PrettyString does, but without colors, for
+// output that is not shown on a terminal even when stdout is one.
+func (table *Table) plainString() (string, error) {
+	colorEnabled := table.colorEnabled
+	disabled := false
+	table.colorEnabled = &disabled
+	defer func() {
+		table.colorEnabled = colorEnabled
+	}()
+	return table.PrettyString()
+}
+
+// jsonRow is a row encoded as a JSON object whose keys keep the column order.
+type jsonRow struct {
+	columns []string
+	values  []string
+}
+
+func (row jsonRow) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteString("{")
+	for i, column := range row.columns {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+		key, err := json.Marshal(column)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(row.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(key)
+		buffer.WriteString(":")
+		buffer.Write(value)
+	}
+	buffer.WriteString("}")
+	return buffer.Bytes(), nil
+}
+
+func (table *Table) writeJSON(w io.Writer) error {
+	columns := table.ColumnNames()
+	rows := make([]jsonRow, len(table.rows))
+	for i, row := range table.rows {
+		rows[i] = jsonRow{columns: columns, values: row}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rows)
+}
+
+func (table *Table) writeCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if !table.hideColumnNames {
+		if err := writer.Write(table.ColumnNames()); err != nil {
+			return err
+		}
+	}
+	if err := writer.WriteAll(table.rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func (table *Table) writeMarkdown(w io.Writer) error {
+	var buffer bytes.Buffer
+	writeMarkdownRow(&buffer, table.ColumnNames())
+
+	separators := make([]string, len(table.columnDefs))
+	for i, columnDef := range table.columnDefs {
+		alignment := RightJustify
+		if columnDef.alignment != nil {
+			alignment = *columnDef.alignment
+		}
+		switch alignment {
+		case LeftJustify:
+			separators[i] = ":---"
+		case CenterJustify:
+			separators[i] = ":---:"
+		default:
+			separators[i] = "---:"
+		}
+	}
+	buffer.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+
+	for _, row := range table.rows {
+		writeMarkdownRow(&buffer, row)
+	}
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+var markdownEscaper = strings.NewReplacer("|", "\\|", "\n", "<br>")
+
+func writeMarkdownRow(buffer *bytes.Buffer, values []string) {
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = markdownEscaper.Replace(value)
+	}
+	buffer.WriteString("| " + strings.Join(escaped, " | ") + " |\n")
+}
+
+func (table *Table) writeHTML(w io.Writer) error {
+	alignments := make([]string, len(table.columnDefs))
+	for i, columnDef := range table.columnDefs {
+		alignment := RightJustify
+		if columnDef.alignment != nil {
+			alignment = *columnDef.alignment
+		}
+		switch alignment {
+		case LeftJustify:
+			alignments[i] = "left"
+		case CenterJustify:
+			alignments[i] = "center"
+		default:
+			alignments[i] = "right"
+		}
+	}
+	writeHTMLRow := func(buffer *bytes.Buffer, tag string, values []string) {
+		buffer.WriteString("<tr>")
+		for i, value := range values {
+			fmt.Fprintf(
+				buffer,
+				"<%s style=\"text-align: %s\">%s</%s>",
+				tag,
+				alignments[i],
+				strings.Replace(html.EscapeString(value), "\n", "<br>", -1),
+				tag)
+		}
+		buffer.WriteString("</tr>\n")
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString("<table>\n")
+	if !table.hideColumnNames {
+		buffer.WriteString("<thead>\n")
+		writeHTMLRow(&buffer, "th", table.ColumnNames())
+		buffer.WriteString("</thead>\n")
+	}
+	buffer.WriteString("<tbody>\n")
+	for _, row := range table.rows {
+		writeHTMLRow(&buffer, "td", row)
+	}
+	buffer.WriteString("</tbody>\n</table>\n")
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
+
+var tsvEscaper = strings.NewReplacer("\t", " ", "\r\n", " ", "\n", " ")
+
+func (table *Table) writeTSV(w io.Writer) error {
+	var buffer bytes.Buffer
+	writeTSVRow := func(values []string) {
+		for i, value := range values {
+			if i > 0 {
+				buffer.WriteString("\t")
+			}
+			buffer.WriteString(tsvEscaper.Replace(value))
+		}
+		buffer.WriteString("\n")
+	}
+
+	if !table.hideColumnNames {
+		writeTSVRow(table.ColumnNames())
+	}
+	for _, row := range table.rows {
+		writeTSVRow(row)
+	}
+	_, err := w.Write(buffer.Bytes())
+	return err
+}
@@ -0,0 +1,57 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestWriteFormat(t *testing.T) {
+	for _, format := range []Format{
+		FormatJSON,
+		FormatCSV,
+		FormatMarkdown,
+		FormatHTML,
+	} {
+		table := createBasicTable(t)
+		table.columnDefs[1] = table.columnDefs[1].WithAlignment(LeftJustify)
+		err := table.AddRow("9", "Pipe | Person", "Human", "")
+		assert.Nil(t, err)
+
+		var buffer bytes.Buffer
+		err = table.WriteFormat(&buffer, format)
+		assert.Nil(t, err)
+		expected := readFileAsString(t, "test/basic_table."+format.String())
+		assert.EqualString(t, expected, buffer.String())
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := ParseFormat("Markdown")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatMarkdown, format)
+
+	_, err = ParseFormat("xml")
+	assert.NotNil(t, err)
+}
+
+func TestFprintWithPipedFormat(t *testing.T) {
+	table := createBasicTable(t)
+
+	var buffer bytes.Buffer
+	err := table.Fprint(&buffer)
+	assert.Nil(t, err)
+	expected := readFileAsString(t, "test/basic_table.txt")
+	assert.EqualString(t, expected+"\n", buffer.String())
+
+	// A buffer is not a terminal, so the piped format is used.
+	table.SetPipedFormat(FormatTSV)
+	err = table.AddRow("9", "Tab\tPerson", "Multi\nLine", "")
+	assert.Nil(t, err)
+	buffer.Reset()
+	err = table.Fprint(&buffer)
+	assert.Nil(t, err)
+	expected = readFileAsString(t, "test/basic_table.tsv")
+	assert.EqualString(t, expected, buffer.String())
+}
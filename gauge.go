@@ -0,0 +1,107 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Default thresholds of a Gauge, in percent.
+const (
+	defaultGaugeWarning  = 70
+	defaultGaugeCritical = 90
+	defaultGaugeWidth    = 10
+)
+
+// Gauge shows a value as a percentage of a maximum on a single line, for
+// at-a-glance health and quota displays:
+//
+//	Quota [██████----] 62%
+//
+// The filled part is green, turning yellow at the warning threshold and red
+// at the critical one.
+type Gauge struct {
+	value        float64
+	max          float64
+	label        string
+	width        int
+	warning      float64
+	critical     float64
+	colorEnabled *bool
+}
+
+// NewGauge creates a Gauge showing value as a percentage of max. A NaN or
+// infinite percentage is shown as 0%.
+func NewGauge(value float64, max float64) *Gauge {
+	return &Gauge{
+		value:    value,
+		max:      max,
+		width:    defaultGaugeWidth,
+		warning:  defaultGaugeWarning,
+		critical: defaultGaugeCritical,
+	}
+}
+
+// SetLabel sets the text shown before the gauge.
+func (gauge *Gauge) SetLabel(label string) {
+	gauge.label = label
+}
+
+// SetWidth sets the width of the gauge itself, excluding the label and
+// percentage. Negative widths are treated as 0.
+func (gauge *Gauge) SetWidth(width int) {
+	if width < 0 {
+		width = 0
+	}
+	gauge.width = width
+}
+
+// SetThresholds sets the percentages at which the gauge turns yellow and red,
+// which default to 70 and 90.
+func (gauge *Gauge) SetThresholds(warning float64, critical float64) {
+	gauge.warning = warning
+	gauge.critical = critical
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (gauge *Gauge) SetColor(enabled bool) {
+	gauge.colorEnabled = &enabled
+}
+
+// String renders the gauge.
+func (gauge *Gauge) String() string {
+	percent := 0.0
+	if gauge.max > 0 {
+		percent = gauge.value / gauge.max * 100
+	}
+	if !isFinite(percent) {
+		percent = 0
+	}
+	attribute := color.FgGreen
+	switch {
+	case percent >= gauge.critical:
+		attribute = color.FgRed
+	case percent >= gauge.warning:
+		attribute = color.FgYellow
+	}
+
+	filled := int(math.Round(
+		math.Max(0, math.Min(100, percent)) / 100 * float64(gauge.width)))
+	var builder strings.Builder
+	if gauge.label != "" {
+		builder.WriteString(gauge.label + " ")
+	}
+	builder.WriteString("[")
+	if filled > 0 {
+		builder.WriteString(colorize(
+			strings.Repeat("█", filled),
+			colorsEnabled(gauge.colorEnabled),
+			attribute))
+	}
+	builder.WriteString(strings.Repeat("-", gauge.width-filled))
+	fmt.Fprintf(&builder, "] %d%%", int(math.Round(percent)))
+	return builder.String()
+}
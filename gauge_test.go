@@ -0,0 +1,50 @@
+package pretty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestGauge(t *testing.T) {
+	gauge := NewGauge(62, 100)
+	gauge.SetColor(false)
+	assert.EqualString(t, "[██████----] 62%", gauge.String())
+
+	gauge = NewGauge(30, 20)
+	gauge.SetLabel("Quota")
+	gauge.SetWidth(4)
+	gauge.SetColor(false)
+	assert.EqualString(t, "Quota [████] 150%", gauge.String())
+
+	gauge = NewGauge(0, 0)
+	gauge.SetColor(false)
+	assert.EqualString(t, "[----------] 0%", gauge.String())
+}
+
+func TestGaugeThresholds(t *testing.T) {
+	gauge := NewGauge(1, 10)
+	gauge.SetWidth(1)
+	gauge.SetColor(true)
+	assert.EqualString(t, "[-] 10%", gauge.String())
+
+	gauge.value = 7
+	assert.EqualString(t, "[\x1b[33m█\x1b[0m] 70%", gauge.String())
+	gauge.SetThresholds(50, 60)
+	assert.EqualString(t, "[\x1b[31m█\x1b[0m] 70%", gauge.String())
+	gauge.value = 5.5
+	assert.EqualString(t, "[\x1b[33m█\x1b[0m] 55%", gauge.String())
+	gauge.value = 4.5
+	gauge.SetWidth(2)
+	assert.EqualString(t, "[\x1b[32m█\x1b[0m-] 45%", gauge.String())
+}
+
+func TestGaugeInvalidValues(t *testing.T) {
+	gauge := NewGauge(math.NaN(), 10)
+	gauge.SetColor(false)
+	assert.EqualString(t, "[----------] 0%", gauge.String())
+
+	gauge.SetWidth(-3)
+	assert.EqualString(t, "[] 0%", gauge.String())
+}
@@ -0,0 +1,72 @@
+package pretty
+
+import (
+	"os"
+	"strings"
+)
+
+// Heading returns title underlined with "=", for the title of a command's
+// output.
+func Heading(title string) string {
+	return underline(title, "=")
+}
+
+// Subheading returns title underlined with "-", for sections below a
+// Heading.
+func Subheading(title string) string {
+	return underline(title, "-")
+}
+
+// Banner returns title centered between two lines of "=", width characters
+// wide. A width of 0 or less uses the width of the terminal.
+//
+//	========================
+//	     Backup Summary
+//	========================
+func Banner(title string, width int) string {
+	width = lineWidth(width)
+	line := strings.Repeat("=", width)
+	padding := (width - strLengthWithEncoding(title)) / 2
+	if padding < 0 {
+		padding = 0
+	}
+	return line + "\n" + strings.Repeat(" ", padding) + title + "\n" + line +
+		"\n"
+}
+
+// Divider returns a horizontal line width characters wide, with title near
+// its start if it is not empty, for separating sections of output. A width of
+// 0 or less uses the width of the terminal.
+//
+//	── Snapshots ───────────
+func Divider(title string, width int) string {
+	width = lineWidth(width)
+	horizontal := BorderLight.Horizontal
+	if title == "" {
+		return strings.Repeat(horizontal, width) + "\n"
+	}
+
+	prefix := strings.Repeat(horizontal, 2) + " " + title + " "
+	remaining := width - strLengthWithEncoding(prefix)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return prefix + strings.Repeat(horizontal, remaining) + "\n"
+}
+
+func underline(title string, character string) string {
+	return title + "\n" +
+		strings.Repeat(character, strLengthWithEncoding(title)) + "\n"
+}
+
+// lineWidth returns width if it is positive, and otherwise the width of the
+// terminal stdout writes to, or defaultTerminalWidth if it is not one.
+func lineWidth(width int) int {
+	if width > 0 {
+		return width
+	}
+	if maxWidth, ok := terminalWidth(os.Stdout); ok {
+		return maxWidth
+	}
+	return defaultTerminalWidth
+}
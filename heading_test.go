@@ -0,0 +1,24 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestHeadings(t *testing.T) {
+	assert.EqualString(t, "Snapshots\n=========\n", Heading("Snapshots"))
+	assert.EqualString(t, "Résumé\n------\n", Subheading("Résumé"))
+	assert.EqualString(
+		t,
+		"==========\n   Done\n==========\n",
+		Banner("Done", 10))
+	assert.EqualString(t, "── VMs ───\n", Divider("VMs", 10))
+	assert.EqualString(t, "─────\n", Divider("", 5))
+	assert.EqualString(t, "── Too long \n", Divider("Too long", 5))
+
+	// Without a terminal, the default width is used.
+	lines := strings.Split(Banner("", 0), "\n")
+	assert.EqualInt(t, defaultTerminalWidth, len(lines[0]))
+}
@@ -0,0 +1,81 @@
+package pretty
+
+import (
+	"fmt"
+	"math"
+)
+
+// Histogram sorts values into bucketCount buckets of equal width between the
+// smallest and largest value, and draws the number of values in each as a
+// BarChart labeled with the bounds of the bucket, for distributions such as
+// latencies. Each bucket includes its lower bound, and the last one also
+// includes its upper bound. NaN and infinite values are ignored.
+//
+//	[0, 25)   │████████████████ 12
+//	[25, 50)  │█████▍ 4
+//	[50, 75)  │█▍ 1
+//	[75, 100] │██▋ 2
+func Histogram(values []float64, bucketCount int) (string, error) {
+	if bucketCount < 1 {
+		return "", fmt.Errorf("bucket count %d must be positive", bucketCount)
+	}
+	labels, counts := histogramBuckets(values, bucketCount)
+	chart := barChart{
+		labels:       labels,
+		values:       counts,
+		width:        lineWidth(0),
+		colorEnabled: colorsEnabled(nil),
+	}
+	return chart.render(), nil
+}
+
+// histogramBuckets returns the label and number of values of each bucket. It
+// returns no buckets if there are no values.
+func histogramBuckets(values []float64, bucketCount int) ([]string, []float64) {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if !isFinite(value) {
+			continue
+		}
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+	if low > high {
+		return nil, nil
+	}
+	if low == high {
+		high = low + 1
+	}
+
+	bucketWidth := (high - low) / float64(bucketCount)
+	counts := make([]float64, bucketCount)
+	for _, value := range values {
+		if !isFinite(value) {
+			continue
+		}
+		bucket := int((value - low) / bucketWidth)
+		if bucket >= bucketCount {
+			bucket = bucketCount - 1
+		}
+		counts[bucket]++
+	}
+
+	labels := make([]string, bucketCount)
+	for i := range labels {
+		closing := ")"
+		if i == bucketCount-1 {
+			closing = "]"
+		}
+		labels[i] = fmt.Sprintf(
+			"[%s, %s%s",
+			formatBound(low+float64(i)*bucketWidth),
+			formatBound(low+float64(i+1)*bucketWidth),
+			closing)
+	}
+	return labels, counts
+}
+
+// formatBound formats the bound of a bucket to at most two decimals.
+func formatBound(bound float64) string {
+	return formatNumeric(math.Round(bound*100) / 100)
+}
@@ -0,0 +1,45 @@
+package pretty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestHistogramBuckets(t *testing.T) {
+	labels, counts := histogramBuckets(
+		[]float64{
+			0, 10, 24.9, 25, 60, 100,
+			math.NaN(), math.Inf(1), math.Inf(-1),
+		},
+		4)
+	assert.DeepEqual(
+		t,
+		[]string{"[0, 25)", "[25, 50)", "[50, 75)", "[75, 100]"},
+		labels)
+	assert.DeepEqual(t, []float64{3, 1, 1, 1}, counts)
+
+	labels, counts = histogramBuckets([]float64{1, 1}, 3)
+	assert.DeepEqual(
+		t,
+		[]string{"[1, 1.33)", "[1.33, 1.67)", "[1.67, 2]"},
+		labels)
+	assert.DeepEqual(t, []float64{2, 0, 0}, counts)
+
+	labels, counts = histogramBuckets([]float64{math.Inf(1)}, 3)
+	assert.EqualInt(t, 0, len(labels))
+	assert.EqualInt(t, 0, len(counts))
+
+	labels, counts = histogramBuckets(nil, 3)
+	assert.EqualInt(t, 0, len(labels))
+	assert.EqualInt(t, 0, len(counts))
+}
+
+func TestHistogram(t *testing.T) {
+	_, err := Histogram([]float64{1}, 0)
+	assert.NotNil(t, err)
+	output, err := Histogram(nil, 3)
+	assert.Nil(t, err)
+	assert.EqualString(t, "", output)
+}
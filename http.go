@@ -0,0 +1,111 @@
+package pretty
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// formatMediaTypes maps each format to the media type it is served as.
+var formatMediaTypes = map[Format]string{
+	FormatTable:    "text/plain",
+	FormatJSON:     "application/json",
+	FormatCSV:      "text/csv",
+	FormatMarkdown: "text/markdown",
+	FormatTSV:      "text/tab-separated-values",
+	FormatHTML:     "text/html",
+}
+
+// ServeHTTP writes the table in the format that the request accepts, so that
+// debug and status endpoints can show the tables a command line prints: HTML
+// for browsers, JSON for scripts, and the table itself, without colors, for
+// curl and anything else. A format query parameter, such as ?format=csv,
+// overrides the Accept header.
+//
+// Like other methods of Table, ServeHTTP must not be called concurrently, as
+// servers do. Serve a SyncTable instead, or use Handler to build a table for
+// each request.
+func (table *Table) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	format, err := requestFormat(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var buffer bytes.Buffer
+	if format == FormatTable {
+		var output string
+		output, err = table.plainString()
+		buffer.WriteString(output + "\n")
+	} else {
+		err = table.WriteFormat(&buffer, format)
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", formatMediaTypes[format]+"; charset=utf-8")
+	w.Header().Add("Vary", "Accept")
+	w.Write(buffer.Bytes())
+}
+
+// ServeHTTP writes the table in the format that the request accepts. See
+// Table.ServeHTTP.
+func (syncTable *SyncTable) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	syncTable.table.ServeHTTP(w, r)
+}
+
+// Handler returns a handler that calls build for each request and serves the
+// table it returns, as Table.ServeHTTP does. If build fails, the error is
+// served with status 500.
+func Handler(build func() (*Table, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		table, err := build()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		table.ServeHTTP(w, r)
+	})
+}
+
+// requestFormat returns the format named by the format query parameter of r,
+// or else the one its Accept header prefers. It defaults to FormatTable.
+func requestFormat(r *http.Request) (Format, error) {
+	if name := r.URL.Query().Get("format"); name != "" {
+		return ParseFormat(name)
+	}
+
+	format := FormatTable
+	bestQuality := 0.0
+	for _, accepted := range strings.Split(r.Header.Get("Accept"), ",") {
+		parameters := strings.Split(accepted, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(parameters[0]))
+		quality := 1.0
+		for _, parameter := range parameters[1:] {
+			parameter = strings.TrimSpace(parameter)
+			if strings.HasPrefix(parameter, "q=") {
+				var err error
+				quality, err = strconv.ParseFloat(parameter[2:], 64)
+				if err != nil {
+					quality = 0
+				}
+			}
+		}
+		// The first of equally preferred types wins.
+		if quality <= bestQuality {
+			continue
+		}
+		for candidate, candidateType := range formatMediaTypes {
+			if mediaType == candidateType {
+				format = candidate
+				bestQuality = quality
+			}
+		}
+	}
+	return format, nil
+}
@@ -0,0 +1,81 @@
+package pretty
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func serveTable(
+	handler http.Handler,
+	target string,
+	accept string,
+) *httptest.ResponseRecorder {
+	request := httptest.NewRequest("GET", target, nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestServeHTTP(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetColor(true)
+
+	response := serveTable(table, "/", "")
+	assert.EqualInt(t, http.StatusOK, response.Code)
+	assert.Equal(
+		t,
+		"text/plain; charset=utf-8",
+		response.Header().Get("Content-Type"))
+	expected := readFileAsString(t, "test/basic_table.txt")
+	assert.EqualString(t, expected+"\n", response.Body.String())
+	assert.True(t, *table.colorEnabled)
+
+	response = serveTable(
+		table,
+		"/",
+		"text/html,application/xhtml+xml,*/*;q=0.8")
+	assert.Equal(
+		t,
+		"text/html; charset=utf-8",
+		response.Header().Get("Content-Type"))
+
+	response = serveTable(table, "/", "text/html;q=0.5, application/json")
+	assert.Equal(
+		t,
+		"application/json; charset=utf-8",
+		response.Header().Get("Content-Type"))
+	assert.True(t, strings.HasPrefix(response.Body.String(), "[\n"))
+
+	response = serveTable(table, "/?format=csv", "application/json")
+	assert.Equal(
+		t,
+		"text/csv; charset=utf-8",
+		response.Header().Get("Content-Type"))
+
+	response = serveTable(table, "/?format=xml", "")
+	assert.EqualInt(t, http.StatusBadRequest, response.Code)
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func() (*Table, error) {
+		return createBasicTable(t), nil
+	})
+	response := serveTable(handler, "/", "text/plain")
+	expected := readFileAsString(t, "test/basic_table.txt")
+	assert.EqualString(t, expected+"\n", response.Body.String())
+
+	handler = Handler(func() (*Table, error) {
+		return nil, errors.New("cluster unreachable")
+	})
+	response = serveTable(handler, "/", "")
+	assert.EqualInt(t, http.StatusInternalServerError, response.Code)
+	assert.Equal(t, "cluster unreachable\n", response.Body.String())
+}
@@ -0,0 +1,177 @@
+package pretty
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// HyperlinkFallback is how a hyperlink is shown where the terminal cannot
+// display it, or the table is not written to a terminal.
+type HyperlinkFallback uint
+
+const (
+	// HyperlinkShowText shows only the text of the link. It is the default.
+	HyperlinkShowText HyperlinkFallback = iota
+	// HyperlinkShowTextAndURL shows the text of the link followed by its
+	// URL in parentheses, as in "docs (https://example.com)", unless the
+	// text is the URL.
+	HyperlinkShowTextAndURL HyperlinkFallback = iota
+)
+
+// hyperlinkStart starts an OSC 8 hyperlink escape sequence.
+const hyperlinkStart = "\x1b]8;"
+
+// Hyperlink returns text linked to url with an OSC 8 escape sequence, which
+// terminals that support it display as a clickable link. Tables show it
+// according to their HyperlinkFallback where links are not supported.
+func Hyperlink(text string, url string) string {
+	return hyperlinkStart + ";" + url + "\x1b\\" + text +
+		hyperlinkStart + ";\x1b\\"
+}
+
+// DetectHyperlinks reports whether the terminal is known to display OSC 8
+// hyperlinks, judging by the environment variables it sets.
+func DetectHyperlinks() bool {
+	switch os.Getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "Hyper", "ghostty":
+		return true
+	}
+	switch os.Getenv("TERM") {
+	case "xterm-kitty", "alacritty", "foot", "xterm-ghostty":
+		return true
+	}
+	if os.Getenv("WT_SESSION") != "" || os.Getenv("KITTY_WINDOW_ID") != "" {
+		return true
+	}
+	version, err := strconv.Atoi(os.Getenv("VTE_VERSION"))
+	return err == nil && version >= 5000
+}
+
+// hyperlinksDetected caches DetectHyperlinks, which is consulted for every
+// row.
+var hyperlinksDetected = struct {
+	once     sync.Once
+	detected bool
+}{}
+
+// SetHyperlinks overrides whether hyperlinks within values are displayed as
+// links, which by default they are only if colors are enabled and the
+// terminal is known to support them. Otherwise they are shown according to
+// SetHyperlinkFallback. Exports never contain links.
+func (table *Table) SetHyperlinks(enabled bool) {
+	table.hyperlinks = &enabled
+}
+
+// SetHyperlinkFallback sets how hyperlinks are shown where they cannot be
+// displayed as links.
+func (table *Table) SetHyperlinkFallback(fallback HyperlinkFallback) {
+	table.hyperlinkFallback = fallback
+}
+
+// WithHyperlinks overrides whether hyperlinks are displayed. See
+// Table.SetHyperlinks.
+func WithHyperlinks(enabled bool) Option {
+	return func(table *Table) error {
+		table.SetHyperlinks(enabled)
+		return nil
+	}
+}
+
+// WithHyperlinkFallback sets how hyperlinks are shown where they cannot be
+// displayed. See Table.SetHyperlinkFallback.
+func WithHyperlinkFallback(fallback HyperlinkFallback) Option {
+	return func(table *Table) error {
+		table.SetHyperlinkFallback(fallback)
+		return nil
+	}
+}
+
+// showsHyperlinks reports whether hyperlinks are displayed as links.
+func (table *Table) showsHyperlinks() bool {
+	if table.hyperlinks != nil {
+		return *table.hyperlinks
+	}
+	if !colorsEnabled(table.colorEnabled) {
+		return false
+	}
+	hyperlinksDetected.once.Do(func() {
+		hyperlinksDetected.detected = DetectHyperlinks()
+	})
+	return hyperlinksDetected.detected
+}
+
+// expandsHyperlinks reports whether hyperlinks are replaced by text that is
+// wider than the link, and so must be replaced before values are measured.
+// Links shown as their text alone are replaced as they are written.
+func (table *Table) expandsHyperlinks() bool {
+	return table.hyperlinkFallback == HyperlinkShowTextAndURL &&
+		!table.showsHyperlinks()
+}
+
+// replaceHyperlinks replaces the OSC 8 hyperlinks in text as given by
+// fallback.
+func replaceHyperlinks(text string, fallback HyperlinkFallback) string {
+	if !strings.Contains(text, hyperlinkStart) {
+		return text
+	}
+
+	var builder strings.Builder
+	url := ""
+	linkStart := 0
+	for {
+		start := strings.Index(text, hyperlinkStart)
+		if start < 0 {
+			break
+		}
+		n := escapeSequenceLength(text[start:])
+		if n == 0 {
+			break
+		}
+		builder.WriteString(text[:start])
+
+		// The sequence holds parameters, then the URL, which is empty
+		// at the end of the link.
+		sequence := text[start+len(hyperlinkStart) : start+n]
+		sequence = strings.TrimSuffix(sequence, "\a")
+		sequence = strings.TrimSuffix(sequence, "\x1b\\")
+		text = text[start+n:]
+		if _, target, _ := strings.Cut(sequence, ";"); target != "" {
+			url = target
+			linkStart = builder.Len()
+			continue
+		}
+		if fallback == HyperlinkShowTextAndURL && url != "" &&
+			builder.String()[linkStart:] != url {
+			builder.WriteString(" (" + url + ")")
+		}
+		url = ""
+	}
+	builder.WriteString(text)
+	return builder.String()
+}
+
+// replaceRowHyperlinks returns rows with their hyperlinks replaced as given by
+// fallback, copying the rows that change rather than modifying them.
+func replaceRowHyperlinks(
+	rows [][]string,
+	fallback HyperlinkFallback,
+) [][]string {
+	replaced := make([][]string, len(rows))
+	for i, row := range rows {
+		replaced[i] = row
+		copied := false
+		for j, cell := range row {
+			if !strings.Contains(cell, hyperlinkStart) {
+				continue
+			}
+			if !copied {
+				replaced[i] = append([]string(nil), row...)
+				copied = true
+			}
+			replaced[i][j] = replaceHyperlinks(cell, fallback)
+		}
+	}
+	return replaced
+}
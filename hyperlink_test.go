@@ -0,0 +1,85 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestReplaceHyperlinks(t *testing.T) {
+	link := "see " + Hyperlink("docs", "https://example.com") + "."
+	assert.EqualString(
+		t,
+		"see docs.",
+		replaceHyperlinks(link, HyperlinkShowText),
+	)
+	assert.EqualString(
+		t,
+		"see docs (https://example.com).",
+		replaceHyperlinks(link, HyperlinkShowTextAndURL),
+	)
+
+	bare := "\x1b]8;id=1;https://example.com\ahttps://example.com\x1b]8;;\a"
+	assert.EqualString(
+		t,
+		"https://example.com",
+		replaceHyperlinks(bare, HyperlinkShowTextAndURL),
+	)
+}
+
+func TestTableHyperlinks(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Docs")},
+		WithColor(false),
+		WithHyperlinkFallback(HyperlinkShowTextAndURL),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("vm", Hyperlink("docs", "https://x.io")))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+---------------------+\n"+
+			"| Name | Docs                |\n"+
+			"+------+---------------------+\n"+
+			"|   vm | docs (https://x.io) |\n"+
+			"+------+---------------------+\n",
+		rendered,
+	)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, table.WriteFormat(&buffer, FormatCSV))
+	assert.EqualString(
+		t,
+		"Name,Docs\nvm,docs (https://x.io)\n",
+		buffer.String(),
+	)
+
+	table.SetHyperlinkFallback(HyperlinkShowText)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+------+\n"+
+			"| Name | Docs |\n"+
+			"+------+------+\n"+
+			"|   vm | docs |\n"+
+			"+------+------+\n",
+		rendered,
+	)
+
+	table.SetHyperlinks(true)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+------+\n"+
+			"| Name | Docs |\n"+
+			"+------+------+\n"+
+			"|   vm | "+Hyperlink("docs", "https://x.io")+" |\n"+
+			"+------+------+\n",
+		rendered,
+	)
+}
@@ -0,0 +1,43 @@
+package pretty
+
+// FormatShortID returns a Formatter that shortens IDs, such as UUIDs and
+// hexadecimal hashes, to their first length characters followed by "...",
+// like abbreviated git commit hashes. Values that are not IDs, and IDs too
+// short to gain from it, are formatted with fmt.Sprint. To show full IDs in
+// wide mode, use ColumnDef.WithShortIDs instead.
+func FormatShortID(length int) Formatter {
+	return func(value interface{}) string {
+		return shortenID(formatValue(value), length)
+	}
+}
+
+// WithShortIDs returns a copy of the ColumnDef whose values are shortened as
+// by FormatShortID after any formatter is applied, unless the table is in
+// wide mode.
+func (columnDef ColumnDef) WithShortIDs(length int) ColumnDef {
+	columnDef.shortIDLength = length
+	return columnDef
+}
+
+// shortenID returns the first length characters of id followed by "...", or
+// id unchanged if it is not an ID or is no longer than the result.
+func shortenID(id string, length int) string {
+	if length <= 0 || len(id) <= length+len(ellipsis) || !isID(id) {
+		return id
+	}
+	return id[:length] + ellipsis
+}
+
+// isID reports whether str consists of hexadecimal digits and dashes, as
+// UUIDs and hashes do.
+func isID(str string) bool {
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		isHex := '0' <= c && c <= '9' || 'a' <= c && c <= 'f' ||
+			'A' <= c && c <= 'F'
+		if !isHex && c != '-' {
+			return false
+		}
+	}
+	return str != ""
+}
@@ -0,0 +1,49 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFormatShortID(t *testing.T) {
+	format := FormatShortID(8)
+	assert.EqualString(
+		t,
+		"3f2a9c1e...",
+		format("3f2a9c1e-7b4d-4c1a-9e2f-0a1b2c3d4e5f"))
+	assert.EqualString(t, "9E2F0A1B...", format("9E2F0A1BC3D4E5F6"))
+	assert.EqualString(t, "3f2a9c1e7b4", format("3f2a9c1e7b4"))
+	assert.EqualString(t, "not-an-id-at-all", format("not-an-id-at-all"))
+	assert.EqualString(t, "", format(nil))
+}
+
+func TestColumnShortIDs(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("ID").WithShortIDs(7),
+			NewColumnDef("Name"),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	id := "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"
+	assert.Nil(t, table.AddRow(id, "x"))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------------+------+\n"+
+			"| ID         | Name |\n"+
+			"+------------+------+\n"+
+			"| a94a8fe... |    x |\n"+
+			"+------------+------+\n",
+		rendered)
+
+	table.SetWide(true)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(rendered, id))
+}
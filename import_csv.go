@@ -0,0 +1,51 @@
+package pretty
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// TableFromCSV creates a Table from CSV data. If hasHeader is true, the first
+// record holds the column names; otherwise the columns are named "Column 1",
+// "Column 2" and so on. Every record must have as many fields as there are
+// columns, unless the table has lenient rows.
+func TableFromCSV(r io.Reader, hasHeader bool, opts ...Option) (*Table, error) {
+	reader := csv.NewReader(r)
+	// The number of fields is checked by SetRows, which fits the records to
+	// the columns instead if the options make rows lenient.
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("csv input is empty")
+	}
+
+	var columns []string
+	if hasHeader {
+		columns, records = records[0], records[1:]
+	} else {
+		columns = make([]string, len(records[0]))
+		for i := range columns {
+			columns[i] = fmt.Sprintf("Column %d", i+1)
+		}
+	}
+
+	columnDefs := make([]ColumnDef, len(columns))
+	for i, column := range columns {
+		columnDefs[i] = NewColumnDef(column)
+	}
+	table, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+	if err := table.SetRows(records); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
@@ -0,0 +1,47 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+const employeesCSV = `Employee Number,Name,Type,Phone Number
+23,Noel,Human,(123) 456-7899
+83,David,Cyborg,987-654-3211
+52,Pranava,Crusher,1-800-123-4567
+1182,Postnava,Kitten,1 (800) 987-6543
+`
+
+func TestTableFromCSV(t *testing.T) {
+	table, err := TableFromCSV(strings.NewReader(employeesCSV), true)
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestTableFromCSVWithoutHeader(t *testing.T) {
+	table, err := TableFromCSV(
+		strings.NewReader("a,\"b, with comma\"\nc,d\n"),
+		false)
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_from_csv_without_header.txt")
+}
+
+func TestTableFromCSVErrors(t *testing.T) {
+	_, err := TableFromCSV(strings.NewReader(""), true)
+	assert.NotNil(t, err)
+
+	_, err = TableFromCSV(strings.NewReader("a,b\nc\n"), true)
+	assert.NotNil(t, err)
+}
+
+func TestTableFromCSVWithLenientRows(t *testing.T) {
+	table, err := TableFromCSV(
+		strings.NewReader("a,b\nc\nd,e,f\n"),
+		true,
+		WithLenientRows(true))
+	assert.Nil(t, err)
+	assert.DeepEqual(t, [][]string{{"c", ""}, {"d", "e"}}, table.rows)
+	assert.EqualInt(t, 1, len(table.Warnings()))
+}
@@ -0,0 +1,56 @@
+package pretty
+
+import "sort"
+
+// TableFromMaps creates a Table from a slice of maps, such as decoded JSON
+// objects, with one row per map. If columns is empty, the columns are the
+// union of all keys in sorted order; otherwise only the given keys are shown,
+// in the given order. Missing keys are shown as empty cells and values are
+// formatted with fmt.Sprint.
+func TableFromMaps(
+	records []map[string]interface{},
+	columns []string,
+	opts ...Option,
+) (*Table, error) {
+	if len(columns) == 0 {
+		columns = unionOfKeys(records)
+	}
+
+	columnDefs := make([]ColumnDef, len(columns))
+	for i, column := range columns {
+		columnDefs[i] = NewColumnDef(column)
+	}
+	table, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	for _, record := range records {
+		row := make([]string, len(columns))
+		for i, column := range columns {
+			row[i] = formatValue(record[column])
+		}
+		if err := table.AddRow(row...); err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+func unionOfKeys(records []map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, record := range records {
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
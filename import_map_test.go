@@ -0,0 +1,36 @@
+package pretty
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+const employeesJSON = `[
+	{"Name": "Noel", "Type": "Human", "Employee Number": 23},
+	{"Name": "David", "Type": "Cyborg", "Phone Number": "987-654-3211"},
+	{"Name": "Pranava", "Employee Number": 52, "Manager": null}
+]`
+
+func TestTableFromMaps(t *testing.T) {
+	var records []map[string]interface{}
+	err := json.Unmarshal([]byte(employeesJSON), &records)
+	assert.Nil(t, err)
+
+	table, err := TableFromMaps(records, nil)
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_from_maps.txt")
+
+	table, err = TableFromMaps(
+		records,
+		[]string{"Name", "Employee Number"},
+		WithRowCount(true))
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_from_maps_with_columns.txt")
+}
+
+func TestTableFromMapsWithNoColumns(t *testing.T) {
+	_, err := TableFromMaps(nil, nil)
+	assert.NotNil(t, err)
+}
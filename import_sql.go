@@ -0,0 +1,78 @@
+package pretty
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+)
+
+// NullPlaceholder is shown in place of SQL NULL values by TableFromSQLRows.
+const NullPlaceholder = "NULL"
+
+// TableFromSQLRows creates a Table from a query result set, with one column
+// per result column. All remaining rows are read; the caller is still
+// responsible for closing rows. NULL values are shown as NullPlaceholder,
+// byte slices as text and timestamps in RFC 3339 format.
+func TableFromSQLRows(rows *sql.Rows, opts ...Option) (*Table, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	columnDefs := make([]ColumnDef, len(columns))
+	for i, column := range columns {
+		columnDefs[i] = NewColumnDef(column)
+	}
+	table, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	values := make([]interface{}, len(columns))
+	scanArgs := make([]interface{}, len(columns))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+		row := make([]string, len(values))
+		for i, value := range values {
+			row[i] = formatSQLValue(value)
+		}
+		if err := table.AddRow(row...); err != nil {
+			return nil, err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// formatSQLValue formats a value scanned from a driver, which is one of the
+// types allowed by database/sql/driver.Value.
+func formatSQLValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return NullPlaceholder
+	case []byte:
+		return string(v)
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return formatValue(v)
+	}
+}
@@ -0,0 +1,105 @@
+package pretty
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+// fakeDriver serves a fixed result set for every query, so that
+// TableFromSQLRows can be tested without a real database.
+type fakeDriver struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+type fakeConn struct{ driver *fakeDriver }
+
+type fakeStmt struct{ driver *fakeDriver }
+
+type fakeRows struct {
+	driver *fakeDriver
+	next   int
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{driver: c.driver}, nil
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions are not supported")
+}
+
+func (s *fakeStmt) Close() error { return nil }
+
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, fmt.Errorf("exec is not supported")
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &fakeRows{driver: s.driver}, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.driver.columns }
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.driver.rows) {
+		return io.EOF
+	}
+	copy(dest, r.driver.rows[r.next])
+	r.next++
+	return nil
+}
+
+func init() {
+	sql.Register("pretty-fake", &fakeDriver{
+		columns: []string{"id", "name", "active", "score", "created", "notes"},
+		rows: [][]driver.Value{
+			{
+				int64(1),
+				[]byte("Noel"),
+				true,
+				float64(9.5),
+				time.Date(2018, 4, 1, 12, 30, 0, 0, time.UTC),
+				nil,
+			},
+			{
+				int64(2),
+				"David",
+				false,
+				float64(7),
+				time.Date(2018, 4, 2, 8, 0, 0, 0, time.UTC),
+				"cyborg",
+			},
+		},
+	})
+}
+
+func TestTableFromSQLRows(t *testing.T) {
+	db, err := sql.Open("pretty-fake", "")
+	assert.Nil(t, err)
+	defer db.Close()
+
+	rows, err := db.Query("SELECT * FROM employees")
+	assert.Nil(t, err)
+	defer rows.Close()
+
+	table, err := TableFromSQLRows(rows, WithRowCount(true))
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_from_sql_rows.txt")
+}
@@ -0,0 +1,176 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// structTag is the struct tag key read by TableFromStructs.
+const structTag = "pretty"
+
+// TableFromStructs creates a Table from a slice of structs, or of pointers to
+// structs, with one column per exported field and one row per element.
+// Fields of embedded structs are promoted as in Go. Columns are configured
+// with a struct tag of the form:
+//
+//	Name string `pretty:"Full Name,width=20,align=left"`
+//
+// The first tag value is the column name, defaulting to the field name.
+// width sets the maximum width and align is one of left, right or center. A
+// tag of "-" skips the field. The field values are added with AddValues, so
+// they are formatted with fmt.Sprint unless the column is given a formatter.
+func TableFromStructs(slice interface{}, opts ...Option) (*Table, error) {
+	sliceValue := reflect.ValueOf(slice)
+	if sliceValue.Kind() != reflect.Slice && sliceValue.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	elemType := sliceValue.Type().Elem()
+	if elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+	if elemType.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("expected a slice of structs, got %T", slice)
+	}
+
+	var columnDefs []ColumnDef
+	var fields [][]int
+	for _, field := range reflect.VisibleFields(elemType) {
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && indirectType(field.Type).Kind() == reflect.Struct {
+			// The promoted fields are listed separately.
+			continue
+		}
+
+		columnDef, ok, err := columnDefFromField(field)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		columnDefs = append(columnDefs, columnDef)
+		fields = append(fields, field.Index)
+	}
+
+	table, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	for i := 0; i < sliceValue.Len(); i++ {
+		elem := sliceValue.Index(i)
+		row := make([]interface{}, len(fields))
+		if elem.Kind() == reflect.Ptr {
+			if elem.IsNil() {
+				if err := table.AddValues(row...); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			elem = elem.Elem()
+		}
+
+		for j, index := range fields {
+			// Embedded structs reached through a nil pointer have no value.
+			fieldValue, err := elem.FieldByIndexErr(index)
+			if err != nil {
+				continue
+			}
+			row[j] = fieldValue.Interface()
+		}
+		if err := table.AddValues(row...); err != nil {
+			return nil, err
+		}
+	}
+
+	return table, nil
+}
+
+// columnDefFromField builds the ColumnDef for a struct field from its tag. It
+// returns false if the field should be skipped.
+func columnDefFromField(field reflect.StructField) (ColumnDef, bool, error) {
+	tag, ok := field.Tag.Lookup(structTag)
+	if ok && tag == "-" {
+		return ColumnDef{}, false, nil
+	}
+
+	parts := strings.Split(tag, ",")
+	name := strings.TrimSpace(parts[0])
+	if name == "" {
+		name = field.Name
+	}
+	columnDef := NewColumnDef(name)
+
+	for _, part := range parts[1:] {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "width":
+			width, err := strconv.Atoi(value)
+			if err != nil {
+				return ColumnDef{}, false, fmt.Errorf(
+					"field %s has invalid width %q",
+					field.Name,
+					value)
+			}
+			columnDef.maxWidth = &width
+		case "align":
+			alignment, err := parseAlignment(value)
+			if err != nil {
+				return ColumnDef{}, false, fmt.Errorf(
+					"field %s: %v",
+					field.Name,
+					err)
+			}
+			columnDef = columnDef.WithAlignment(alignment)
+		default:
+			return ColumnDef{}, false, fmt.Errorf(
+				"field %s has unknown tag option %q",
+				field.Name,
+				part)
+		}
+	}
+
+	return columnDef, true, nil
+}
+
+// String returns the name of the alignment, as used in struct tags.
+func (alignment Alignment) String() string {
+	switch alignment {
+	case LeftJustify:
+		return "left"
+	case RightJustify:
+		return "right"
+	case CenterJustify:
+		return "center"
+	default:
+		return fmt.Sprintf("Alignment(%d)", uint(alignment))
+	}
+}
+
+func parseAlignment(value string) (Alignment, error) {
+	switch value {
+	case "left":
+		return LeftJustify, nil
+	case "right":
+		return RightJustify, nil
+	case "center":
+		return CenterJustify, nil
+	default:
+		return 0, fmt.Errorf("unknown alignment %q", value)
+	}
+}
+
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,55 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+type contact struct {
+	Phone string `pretty:"Phone Number"`
+}
+
+type employee struct {
+	Number int    `pretty:"Employee Number"`
+	Name   string `pretty:",align=left"`
+	Type   string `pretty:"Type,width=6,align=center"`
+	*contact
+	Salary   int `pretty:"-"`
+	internal string
+}
+
+func TestTableFromStructs(t *testing.T) {
+	employees := []*employee{
+		{Number: 23, Name: "Noel", Type: "Human",
+			contact: &contact{Phone: "(123) 456-7899"}},
+		{Number: 83, Name: "David", Type: "Cyborg",
+			contact: &contact{Phone: "987-654-3211"}},
+		{Number: 52, Name: "Pranava", Type: "Crusher"},
+		nil,
+	}
+
+	table, err := TableFromStructs(employees, WithHeader("Employees"))
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_from_structs.txt")
+}
+
+func TestTableFromStructsErrors(t *testing.T) {
+	_, err := TableFromStructs("not a slice")
+	assert.NotNil(t, err)
+
+	_, err = TableFromStructs([]int{1, 2, 3})
+	assert.NotNil(t, err)
+
+	type badWidth struct {
+		Name string `pretty:"Name,width=wide"`
+	}
+	_, err = TableFromStructs([]badWidth{{Name: "Noel"}})
+	assert.NotNil(t, err)
+
+	type badAlignment struct {
+		Name string `pretty:"Name,align=top"`
+	}
+	_, err = TableFromStructs([]badAlignment{{Name: "Noel"}})
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,387 @@
+package pretty
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// Keys read from a terminal in raw mode.
+const (
+	keyUp       = "\x1b[A"
+	keyDown     = "\x1b[B"
+	keyRight    = "\x1b[C"
+	keyLeft     = "\x1b[D"
+	keyHome     = "\x1b[H"
+	keyEnd      = "\x1b[F"
+	keyPageUp   = "\x1b[5~"
+	keyPageDown = "\x1b[6~"
+	keyEscape   = "\x1b"
+	keyEnter    = "\r"
+	keyCtrlC    = "\x03"
+)
+
+// Escape sequences controlling the screen of a terminal.
+const (
+	enterAlternateScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAlternateScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen          = "\x1b[H\x1b[2J"
+)
+
+// Interactive shows the table in the terminal for exploring, until q is
+// pressed:
+//
+//   - Up, down, page up, page down, home and end scroll the rows.
+//   - Left and right select a column, and s sorts by it, first ascending,
+//     then descending, then not at all.
+//   - / starts a search, which shows only the rows containing the text typed
+//     so far, ignoring case. Enter ends the search, and escape clears it.
+//
+// The column names stay at the top of the screen while scrolling. Both stdin
+// and stdout must be terminals.
+func (table *Table) Interactive() error {
+	if !isTerminal(os.Stdin) || !isTerminal(os.Stdout) {
+		return fmt.Errorf("interactive mode requires a terminal")
+	}
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+	for _, row := range table.rows {
+		if err := table.validateStoredRow(row); err != nil {
+			return err
+		}
+	}
+
+	viewer := newTableViewer(table)
+	restore, err := makeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return err
+	}
+	defer restore()
+	screen := consoleWriter(os.Stdout)
+	if _, err := io.WriteString(screen, enterAlternateScreen); err != nil {
+		return err
+	}
+	defer io.WriteString(screen, leaveAlternateScreen)
+
+	input := make([]byte, 64)
+	for {
+		width, height, ok := fdSize(os.Stdout.Fd())
+		if !ok {
+			width, height = defaultTerminalWidth, defaultTerminalHeight
+		}
+		frame, err := viewer.frame(width, height)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(screen, clearScreen+frame)
+		if err != nil {
+			return err
+		}
+
+		n, err := os.Stdin.Read(input)
+		if err != nil {
+			return err
+		}
+		for _, key := range splitKeys(string(input[:n])) {
+			if viewer.handleKey(key) {
+				return nil
+			}
+		}
+	}
+}
+
+// defaultTerminalHeight is assumed for terminals whose height is unknown.
+const defaultTerminalHeight = 24
+
+// tableViewerChrome is the number of lines of a frame other than rows: the
+// top border, column names and border below them, the bottom border and the
+// status line. Tables without column names have two fewer.
+const tableViewerChrome = 5
+
+// tableViewer holds the state of Table.Interactive.
+type tableViewer struct {
+	// table holds the rows as they are displayed.
+	table *Table
+	// rows are the indices of the rows that are shown, in order.
+	rows []int
+
+	top            int
+	pageSize       int
+	column         int
+	sortColumn     int
+	sortDescending bool
+	query          string
+	searching      bool
+}
+
+func newTableViewer(table *Table) *tableViewer {
+	rendered := table.renderedTable()
+	rendered.border = table.border
+	rendered.colorEnabled = table.colorEnabled
+	for index, annotation := range table.rowAnnotations {
+		if rendered.rowAnnotations == nil {
+			rendered.rowAnnotations = make(map[int]string)
+		}
+		rendered.rowAnnotations[index] = Sanitize(
+			annotation,
+			table.sanitization)
+	}
+	viewer := &tableViewer{
+		table:      rendered,
+		sortColumn: -1,
+		pageSize:   1,
+	}
+	viewer.update()
+	return viewer
+}
+
+// update recomputes the rows that are shown after the search or sort
+// changes, and scrolls back to the first.
+func (viewer *tableViewer) update() {
+	query := strings.ToLower(viewer.query)
+	viewer.rows = viewer.rows[:0]
+	for i, row := range viewer.table.rows {
+		if query == "" ||
+			strings.Contains(strings.ToLower(strings.Join(row, "\x00")), query) {
+			viewer.rows = append(viewer.rows, i)
+		}
+	}
+
+	if viewer.sortColumn >= 0 {
+		rows := viewer.table.rows
+		column := viewer.sortColumn
+		sort.SliceStable(viewer.rows, func(i, j int) bool {
+			comparison := compareValues(
+				rows[viewer.rows[i]][column],
+				rows[viewer.rows[j]][column])
+			if viewer.sortDescending {
+				return comparison > 0
+			}
+			return comparison < 0
+		})
+	}
+	viewer.top = 0
+}
+
+// handleKey applies a key press, and reports whether to quit.
+func (viewer *tableViewer) handleKey(key string) bool {
+	if viewer.searching {
+		switch key {
+		case keyEnter:
+			viewer.searching = false
+		case keyEscape:
+			viewer.searching = false
+			viewer.query = ""
+			viewer.update()
+		case "\x7f", "\b":
+			if viewer.query != "" {
+				_, size := utf8.DecodeLastRuneInString(viewer.query)
+				viewer.query = viewer.query[:len(viewer.query)-size]
+				viewer.update()
+			}
+		case keyCtrlC:
+			return true
+		default:
+			if key >= " " && !strings.HasPrefix(key, keyEscape) {
+				viewer.query += key
+				viewer.update()
+			}
+		}
+		return false
+	}
+
+	columnCount := len(viewer.table.columnDefs)
+	switch key {
+	case "q", keyCtrlC:
+		return true
+	case keyDown, "j":
+		viewer.scroll(1)
+	case keyUp, "k":
+		viewer.scroll(-1)
+	case keyPageDown, " ":
+		viewer.scroll(viewer.pageSize)
+	case keyPageUp, "b":
+		viewer.scroll(-viewer.pageSize)
+	case keyHome, "g":
+		viewer.top = 0
+	case keyEnd, "G":
+		viewer.scroll(len(viewer.rows))
+	case keyRight, "l":
+		viewer.column = (viewer.column + 1) % columnCount
+	case keyLeft, "h":
+		viewer.column = (viewer.column + columnCount - 1) % columnCount
+	case "s":
+		switch {
+		case viewer.sortColumn != viewer.column:
+			viewer.sortColumn = viewer.column
+			viewer.sortDescending = false
+		case !viewer.sortDescending:
+			viewer.sortDescending = true
+		default:
+			viewer.sortColumn = -1
+		}
+		viewer.update()
+	case "/":
+		viewer.searching = true
+		viewer.query = ""
+		viewer.update()
+	case keyEscape:
+		viewer.query = ""
+		viewer.update()
+	}
+	return false
+}
+
+// scroll moves the first row shown by delta, keeping a full page shown where
+// possible.
+func (viewer *tableViewer) scroll(delta int) {
+	viewer.top += delta
+	if last := len(viewer.rows) - viewer.pageSize; viewer.top > last {
+		viewer.top = last
+	}
+	if viewer.top < 0 {
+		viewer.top = 0
+	}
+}
+
+// frame renders the screen for a terminal of the given size.
+func (viewer *tableViewer) frame(width int, height int) (string, error) {
+	viewer.pageSize = height - tableViewerChrome
+	if viewer.table.hideColumnNames {
+		viewer.pageSize += 2
+	}
+	if viewer.pageSize < 1 {
+		viewer.pageSize = 1
+	}
+	top := viewer.top
+	viewer.scroll(0)
+	atEnd := viewer.top < top
+
+	end := viewer.top + viewer.pageSize
+	if end > len(viewer.rows) {
+		end = len(viewer.rows)
+	}
+	columnDefs := make([]ColumnDef, len(viewer.table.columnDefs))
+	ascii := isASCII(viewer.table.borderStyle().Vertical)
+	for i, columnDef := range viewer.table.columnDefs {
+		switch {
+		case i != viewer.sortColumn:
+		case viewer.sortDescending && ascii:
+			columnDef.name += " v"
+		case viewer.sortDescending:
+			columnDef.name += " ▼"
+		case ascii:
+			columnDef.name += " ^"
+		default:
+			columnDef.name += " ▲"
+		}
+		columnDefs[i] = columnDef
+	}
+	// The columns are sized for all the rows, not only those of the page, so
+	// that they do not change width while scrolling. The rows of the viewer
+	// are already sanitized by renderedTable.
+	widths := make([]int, len(columnDefs))
+	for _, index := range viewer.rows {
+		for i, value := range viewer.table.rows[index] {
+			if valueWidth := strLengthWithEncoding(value); valueWidth > widths[i] {
+				widths[i] = valueWidth
+			}
+		}
+	}
+
+	// Rows take several lines when wrapped or annotated, so the page drops
+	// rows until it fits on the screen: the first ones when scrolled to the
+	// end, and the last ones otherwise.
+	var builder strings.Builder
+	for {
+		page := viewer.page(columnDefs, widths, width, end)
+		builder.Reset()
+		err := page.RenderContext(context.Background(), &builder)
+		if err != nil {
+			return "", err
+		}
+		if strings.Count(builder.String(), "\n") < height ||
+			end-viewer.top <= 1 {
+			break
+		}
+		if atEnd {
+			viewer.top++
+		} else {
+			end--
+		}
+	}
+	if end-viewer.top > 1 {
+		viewer.pageSize = end - viewer.top
+	} else {
+		viewer.pageSize = 1
+	}
+	builder.WriteString(Truncate(viewer.status(end), width))
+	return builder.String(), nil
+}
+
+// page returns a table of the rows shown from the top row to end, with
+// their annotations.
+func (viewer *tableViewer) page(
+	columnDefs []ColumnDef,
+	widths []int,
+	width int,
+	end int,
+) *Table {
+	page := &Table{
+		columnDefs:   columnDefs,
+		border:       viewer.table.border,
+		maxWidth:     width,
+		colorEnabled: viewer.table.colorEnabled,
+	}
+	for i, index := range viewer.rows[viewer.top:end] {
+		page.rows = append(page.rows, viewer.table.rows[index])
+		annotation, ok := viewer.table.rowAnnotations[index]
+		if !ok {
+			continue
+		}
+		if page.rowAnnotations == nil {
+			page.rowAnnotations = make(map[int]string)
+		}
+		page.rowAnnotations[i] = annotation
+	}
+	page.columnWidths = append([]int(nil), widths...)
+	page.measuredRows = len(page.rows)
+	return page
+}
+
+// status describes the rows shown and the search, sort and selected column.
+func (viewer *tableViewer) status(end int) string {
+	status := translate("%d-%d of %d", viewer.top+1, end, len(viewer.rows))
+	if len(viewer.rows) == 0 {
+		status = translate("%d of %d", 0, 0)
+	}
+	status += "  " +
+		translate("column: %s", viewer.table.columnDefs[viewer.column].name)
+	if viewer.searching {
+		return status + "  /" + viewer.query
+	}
+	if viewer.query != "" {
+		status += "  " + translate("search: %s", viewer.query)
+	}
+	return status + "  " + translate("(s sort, / search, q quit)")
+}
+
+// splitKeys splits input read from a terminal in raw mode into keys: escape
+// sequences, such as those of arrow keys, and single characters.
+func splitKeys(input string) []string {
+	var keys []string
+	for input != "" {
+		size := escapeSequenceLength(input)
+		if size == 0 {
+			_, size = utf8.DecodeRuneInString(input)
+		}
+		keys = append(keys, input[:size])
+		input = input[size:]
+	}
+	return keys
+}
@@ -0,0 +1,150 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func newTestTableViewer(t *testing.T) *tableViewer {
+	table, err := NewPrettyTable(
+		NewColumnDef("Name").WithAlignment(LeftJustify),
+		NewColumnDef("Size"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	for _, row := range [][]string{
+		{"vm-1", "30"},
+		{"db-1", "100"},
+		{"vm-2", "5"},
+		{"db-2", "7"},
+	} {
+		assert.Nil(t, table.AddRow(row...))
+	}
+	return newTableViewer(table)
+}
+
+func TestTableViewerScroll(t *testing.T) {
+	viewer := newTestTableViewer(t)
+	frame, err := viewer.frame(80, 7)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+------+\n"+
+			"| Name | Size |\n"+
+			"+------+------+\n"+
+			"| vm-1 |   30 |\n"+
+			"| db-1 |  100 |\n"+
+			"+------+------+\n"+
+			"1-2 of 4  column: Name  (s sort, / search, q quit)",
+		frame)
+
+	for _, key := range splitKeys(keyDown + keyDown + keyDown + "k") {
+		assert.True(t, !viewer.handleKey(key))
+	}
+	frame, err = viewer.frame(80, 7)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(frame, "| vm-2 |    5 |\n"))
+	assert.True(t, strings.HasSuffix(frame, "\n2-3 of 4  column: Name"+
+		"  (s sort, / search, q quit)"))
+	assert.True(t, viewer.handleKey("q"))
+}
+
+func TestTableViewerSortAndSearch(t *testing.T) {
+	viewer := newTestTableViewer(t)
+	for _, key := range []string{keyRight, "s", "s"} {
+		viewer.handleKey(key)
+	}
+	frame, err := viewer.frame(80, 10)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+--------+\n"+
+			"| Name | Size v |\n"+
+			"+------+--------+\n"+
+			"| db-1 |    100 |\n"+
+			"| vm-1 |     30 |\n"+
+			"| db-2 |      7 |\n"+
+			"| vm-2 |      5 |\n"+
+			"+------+--------+\n"+
+			"1-4 of 4  column: Size  (s sort, / search, q quit)",
+		frame)
+
+	for _, key := range splitKeys("/VX\x7fM" + keyEnter) {
+		viewer.handleKey(key)
+	}
+	frame, err = viewer.frame(80, 10)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(frame, "| vm-1 |     30 |\n"+
+		"| vm-2 |      5 |\n+"))
+	assert.True(t, strings.HasSuffix(frame, "1-2 of 2"+
+		"  column: Size  search: VM  (s sort, / search, q quit)"))
+
+	viewer.handleKey(keyEscape)
+	viewer.handleKey("s")
+	assert.EqualInt(t, -1, viewer.sortColumn)
+	assert.EqualInt(t, 4, len(viewer.rows))
+}
+
+func TestSplitKeys(t *testing.T) {
+	assert.DeepEqual(
+		t,
+		[]string{keyUp, "q", "é", keyPageDown},
+		splitKeys(keyUp+"qé"+keyPageDown))
+}
+
+func TestTableViewerSanitizes(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	table.SetSanitization(SanitizeEscape)
+	assert.Nil(t, table.AddRow("a\x1b[2Jb"))
+
+	frame, err := newTableViewer(table).frame(80, 6)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+-----------+\n"+
+			"| Name      |\n"+
+			"+-----------+\n"+
+			"| a\\x1b[2Jb |\n"+
+			"+-----------+\n"+
+			"1-1 of 1  column: Name  (s sort, / search, q quit)",
+		frame)
+}
+
+func TestTableViewerAnnotations(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	table.SetBorderStyle(BorderLight)
+	for _, name := range []string{"vm-1", "vm-2", "vm-3"} {
+		assert.Nil(t, table.AddRow(name))
+	}
+	assert.Nil(t, table.SetRowAnnotation(0, "disk full"))
+	viewer := newTableViewer(table)
+	viewer.handleKey("s")
+
+	// The annotation, wrapped to the width of the table, takes the lines of
+	// the other rows.
+	frame, err := viewer.frame(80, 8)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"┌────────┐\n"+
+			"│ Name ▲ │\n"+
+			"├────────┤\n"+
+			"│   vm-1 │\n"+
+			"│   disk │\n"+
+			"│   full │\n"+
+			"└────────┘\n"+
+			"1-1 of 3  column: Name  (s sort, / search, q quit)",
+		frame)
+
+	viewer.handleKey(keyEnd)
+	frame, err = viewer.frame(80, 8)
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(frame, "│   vm-2 │\n│   vm-3 │\n"))
+	assert.True(t, strings.HasSuffix(frame, "\n2-3 of 3  column: Name"+
+		"  (s sort, / search, q quit)"))
+}
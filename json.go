@@ -0,0 +1,267 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Colors of the parts of a pretty-printed document.
+var (
+	keyColor     = color.FgBlue
+	stringColor  = color.FgGreen
+	numberColor  = color.FgCyan
+	literalColor = color.FgMagenta
+)
+
+// JSON returns v encoded as indented JSON, with syntax colors if stdout is a
+// terminal. See JSONPrinter for other settings.
+func JSON(v interface{}) (string, error) {
+	return NewJSONPrinter().Format(v)
+}
+
+// JSONPrinter encodes values as indented, syntax-colored JSON, for showing
+// JSON output to people. Values are encoded as by encoding/json, so struct
+// tags and Marshaler implementations are respected.
+type JSONPrinter struct {
+	indent       string
+	sortKeys     bool
+	maxDepth     int
+	colorEnabled *bool
+}
+
+// NewJSONPrinter creates a JSONPrinter indenting by two spaces, with keys in
+// the order they are encoded and no depth limit.
+func NewJSONPrinter() *JSONPrinter {
+	return &JSONPrinter{indent: "  "}
+}
+
+// SetIndent sets the string that each level is indented by.
+func (printer *JSONPrinter) SetIndent(indent string) {
+	printer.indent = indent
+}
+
+// SetSortKeys sets whether the keys of objects are sorted. By default they
+// are in the order they are encoded, which is the field order for structs
+// and sorted for maps.
+func (printer *JSONPrinter) SetSortKeys(sortKeys bool) {
+	printer.sortKeys = sortKeys
+}
+
+// SetMaxDepth sets the number of levels of nested objects and arrays that are
+// shown. Deeper ones are elided as {...} or [...]. A depth of 0, the default,
+// shows all levels.
+func (printer *JSONPrinter) SetMaxDepth(maxDepth int) {
+	printer.maxDepth = maxDepth
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (printer *JSONPrinter) SetColor(enabled bool) {
+	printer.colorEnabled = &enabled
+}
+
+// Format returns v encoded as JSON, followed by a newline.
+func (printer *JSONPrinter) Format(v interface{}) (string, error) {
+	node, err := parseDocument(v)
+	if err != nil {
+		return "", err
+	}
+	if printer.sortKeys {
+		node.sortKeys()
+	}
+
+	var builder strings.Builder
+	printer.write(&builder, node, 0, colorsEnabled(printer.colorEnabled))
+	builder.WriteString("\n")
+	return builder.String(), nil
+}
+
+// Print prints v encoded as JSON to stdout.
+func (printer *JSONPrinter) Print(v interface{}) error {
+	return printer.Fprint(os.Stdout, v)
+}
+
+// Fprint prints v encoded as JSON to w.
+func (printer *JSONPrinter) Fprint(w io.Writer, v interface{}) error {
+	output, err := printer.Format(v)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(consoleWriter(w), output)
+	return err
+}
+
+func (printer *JSONPrinter) write(
+	builder *strings.Builder,
+	node *documentNode,
+	depth int,
+	colorEnabled bool,
+) {
+	open, close := "{", "}"
+	if node.kind == documentArray {
+		open, close = "[", "]"
+	}
+	switch {
+	case node.kind == documentScalar:
+		builder.WriteString(
+			colorize(node.scalar, colorEnabled, node.scalarColor()))
+		return
+	case len(node.children) == 0:
+		builder.WriteString(open + close)
+		return
+	case printer.maxDepth > 0 && depth >= printer.maxDepth:
+		builder.WriteString(open + "..." + close)
+		return
+	}
+
+	indent := strings.Repeat(printer.indent, depth+1)
+	builder.WriteString(open + "\n")
+	for i, child := range node.children {
+		builder.WriteString(indent)
+		if node.kind == documentObject {
+			builder.WriteString(
+				colorize(quoteJSON(node.keys[i]), colorEnabled, keyColor))
+			builder.WriteString(": ")
+		}
+		printer.write(builder, child, depth+1, colorEnabled)
+		if i < len(node.children)-1 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("\n")
+	}
+	builder.WriteString(strings.Repeat(printer.indent, depth) + close)
+}
+
+// documentKind is the kind of a node of a parsed document.
+type documentKind uint
+
+const (
+	documentScalar documentKind = iota
+	documentObject
+	documentArray
+)
+
+// documentNode is a value parsed from JSON, keeping the order of the keys of
+// objects.
+type documentNode struct {
+	kind documentKind
+	// scalar is the JSON encoding of a scalar value.
+	scalar   string
+	keys     []string
+	children []*documentNode
+}
+
+// parseDocument encodes v as JSON and parses it into a documentNode.
+func parseDocument(v interface{}) (*documentNode, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return parseDocumentNode(decoder)
+}
+
+func parseDocumentNode(decoder *json.Decoder) (*documentNode, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+	delimiter, ok := token.(json.Delim)
+	if !ok {
+		return &documentNode{scalar: encodeJSONScalar(token)}, nil
+	}
+
+	node := &documentNode{kind: documentArray}
+	if delimiter == '{' {
+		node.kind = documentObject
+	}
+	for decoder.More() {
+		if node.kind == documentObject {
+			key, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			node.keys = append(node.keys, key.(string))
+		}
+		child, err := parseDocumentNode(decoder)
+		if err != nil {
+			return nil, err
+		}
+		node.children = append(node.children, child)
+	}
+	// Consume the closing delimiter.
+	if _, err := decoder.Token(); err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+// sortKeys sorts the keys of this and all nested objects.
+func (node *documentNode) sortKeys() {
+	if node.kind == documentObject {
+		order := make([]int, len(node.keys))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return node.keys[order[i]] < node.keys[order[j]]
+		})
+		keys := make([]string, len(order))
+		children := make([]*documentNode, len(order))
+		for i, index := range order {
+			keys[i] = node.keys[index]
+			children[i] = node.children[index]
+		}
+		node.keys, node.children = keys, children
+	}
+	for _, child := range node.children {
+		child.sortKeys()
+	}
+}
+
+// scalarColor returns the color of a scalar value by its type.
+func (node *documentNode) scalarColor() color.Attribute {
+	switch {
+	case strings.HasPrefix(node.scalar, `"`):
+		return stringColor
+	case node.scalar == "true" || node.scalar == "false" ||
+		node.scalar == "null":
+		return literalColor
+	}
+	return numberColor
+}
+
+// encodeJSONScalar encodes a scalar token from a json.Decoder.
+func encodeJSONScalar(token json.Token) string {
+	switch value := token.(type) {
+	case nil:
+		return "null"
+	case bool:
+		if value {
+			return "true"
+		}
+		return "false"
+	case json.Number:
+		return value.String()
+	case string:
+		return quoteJSON(value)
+	}
+	return ""
+}
+
+// quoteJSON encodes str as a JSON string, without escaping HTML characters.
+func quoteJSON(str string) string {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	encoder.SetEscapeHTML(false)
+	// Strings always encode.
+	_ = encoder.Encode(str)
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
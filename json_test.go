@@ -0,0 +1,90 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+type jsonTestSnapshot struct {
+	Name   string            `json:"name"`
+	Size   float64           `json:"size"`
+	Tags   []string          `json:"tags"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Parent *jsonTestSnapshot `json:"parent"`
+}
+
+func TestJSONPrinter(t *testing.T) {
+	snapshot := jsonTestSnapshot{
+		Name:   "<daily>",
+		Size:   1.5,
+		Tags:   []string{},
+		Labels: map[string]string{"b": "2", "a": "1"},
+		Parent: &jsonTestSnapshot{Name: "base", Tags: []string{"x"}},
+	}
+	printer := NewJSONPrinter()
+	printer.SetColor(false)
+	output, err := printer.Format(snapshot)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		`{
+  "name": "<daily>",
+  "size": 1.5,
+  "tags": [],
+  "labels": {
+    "a": "1",
+    "b": "2"
+  },
+  "parent": {
+    "name": "base",
+    "size": 0,
+    "tags": [
+      "x"
+    ],
+    "parent": null
+  }
+}
+`,
+		output)
+
+	printer.SetSortKeys(true)
+	printer.SetMaxDepth(1)
+	printer.SetIndent("\t")
+	output, err = printer.Format(snapshot)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"{\n"+
+			"\t\"labels\": {...},\n"+
+			"\t\"name\": \"<daily>\",\n"+
+			"\t\"parent\": {...},\n"+
+			"\t\"size\": 1.5,\n"+
+			"\t\"tags\": []\n"+
+			"}\n",
+		output)
+
+	_, err = printer.Format(func() {})
+	assert.NotNil(t, err)
+}
+
+func TestJSONPrinterColors(t *testing.T) {
+	printer := NewJSONPrinter()
+	printer.SetColor(true)
+	output, err := printer.Format(map[string]interface{}{
+		"a": true,
+		"b": 1,
+	})
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"{\n"+
+			"  \x1b[34m\"a\"\x1b[0m: \x1b[35mtrue\x1b[0m,\n"+
+			"  \x1b[34m\"b\"\x1b[0m: \x1b[36m1\x1b[0m\n"+
+			"}\n",
+		output)
+
+	output, err = printer.Format("text")
+	assert.Nil(t, err)
+	assert.EqualString(t, "\x1b[32m\"text\"\x1b[0m\n", output)
+}
@@ -0,0 +1,159 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// KV renders key-value pairs as aligned "Key: Value" lines, the usual layout
+// for describing a single object:
+//
+//	Name:   vm-1
+//	Status: Running
+//
+//	Resources:
+//	  CPU:    4
+//	  Memory: 16 GiB
+//
+// Pairs may be divided into titled groups, each of which is aligned on its
+// own. Long values are wrapped to the width of the terminal, with the
+// following lines aligned to the first.
+type KV struct {
+	groups []kvGroup
+
+	attributes   []color.Attribute
+	width        int
+	colorEnabled *bool
+}
+
+type kvGroup struct {
+	title string
+	pairs []kvPair
+}
+
+type kvPair struct {
+	key   string
+	value string
+}
+
+// kvGroupIndent is the indentation of the pairs in a titled group.
+const kvGroupIndent = "  "
+
+// NewKV creates an empty KV.
+func NewKV() *KV {
+	return &KV{}
+}
+
+// Add adds a pair to the most recently added group, or before all groups if
+// there are none, and returns the KV.
+func (kv *KV) Add(key string, value string) *KV {
+	if len(kv.groups) == 0 {
+		kv.groups = append(kv.groups, kvGroup{})
+	}
+	group := &kv.groups[len(kv.groups)-1]
+	group.pairs = append(group.pairs, kvPair{key: key, value: value})
+	return kv
+}
+
+// AddGroup starts a group with the given title, to which the following pairs
+// are added, and returns the KV.
+func (kv *KV) AddGroup(title string) *KV {
+	kv.groups = append(kv.groups, kvGroup{title: title})
+	return kv
+}
+
+// SetKeyColor sets the color attributes of the keys and group titles.
+func (kv *KV) SetKeyColor(attributes ...color.Attribute) {
+	kv.attributes = attributes
+}
+
+// SetWidth sets the width that values are wrapped to. A width of 0, the
+// default, wraps to the width of the terminal, and does not wrap when not
+// writing to a terminal.
+func (kv *KV) SetWidth(width int) {
+	kv.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (kv *KV) SetColor(enabled bool) {
+	kv.colorEnabled = &enabled
+}
+
+// PrettyString renders the pairs, wrapped as if written to stdout.
+func (kv *KV) PrettyString() string {
+	return kv.render(os.Stdout)
+}
+
+// Print prints the pairs to stdout.
+func (kv *KV) Print() error {
+	return kv.Fprint(os.Stdout)
+}
+
+// Fprint prints the pairs to w.
+func (kv *KV) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), kv.render(w))
+	return err
+}
+
+// render renders the pairs, wrapped to the width of w if it is a terminal.
+func (kv *KV) render(w io.Writer) string {
+	width := kv.width
+	if width == 0 {
+		width, _ = terminalWidth(w)
+	}
+	colorEnabled := colorsEnabled(kv.colorEnabled)
+
+	var builder strings.Builder
+	for i, group := range kv.groups {
+		indent := ""
+		if group.title != "" {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(
+				colorize(group.title+":", colorEnabled, kv.attributes...))
+			builder.WriteString("\n")
+			indent = kvGroupIndent
+		}
+
+		keyWidth := 0
+		for _, pair := range group.pairs {
+			if strLengthWithEncoding(pair.key) > keyWidth {
+				keyWidth = strLengthWithEncoding(pair.key)
+			}
+		}
+
+		// Values start after the longest key, its colon and a space.
+		valueIndent := indent + strings.Repeat(" ", keyWidth+2)
+		wrapWidth := 0
+		if width > 0 {
+			wrapWidth = width - strLengthWithEncoding(valueIndent)
+			if wrapWidth < minWrapWidth {
+				wrapWidth = minWrapWidth
+			}
+		}
+
+		for _, pair := range group.pairs {
+			padding := strings.Repeat(
+				" ",
+				keyWidth-strLengthWithEncoding(pair.key)+1)
+			for j, line := range Wrap(pair.value, wrapWidth) {
+				if j == 0 {
+					builder.WriteString(indent)
+					builder.WriteString(
+						colorize(pair.key+":", colorEnabled, kv.attributes...))
+					builder.WriteString(padding)
+				} else {
+					builder.WriteString(valueIndent)
+				}
+				builder.WriteString(line)
+				builder.WriteString("\n")
+			}
+		}
+	}
+	return builder.String()
+}
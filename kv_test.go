@@ -0,0 +1,42 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestKV(t *testing.T) {
+	kv := NewKV().
+		Add("Name", "vm-1").
+		Add("Description", "Primary database server for the finance "+
+			"team, replicated nightly").
+		AddGroup("Resources").
+		Add("CPU", "4").
+		Add("Memory", "16 GiB").
+		AddGroup("Labels").
+		Add("Tier", "Gold\nPinned")
+	kv.SetWidth(50)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, kv.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/kv.txt"),
+		buffer.String())
+}
+
+func TestKVKeyColor(t *testing.T) {
+	kv := NewKV().Add("A", "1").Add("Long", "2")
+	kv.SetKeyColor(color.Bold)
+	kv.SetColor(true)
+
+	printer := color.New(color.Bold)
+	printer.EnableColor()
+	assert.EqualString(
+		t,
+		printer.Sprint("A:")+"    1\n"+printer.Sprint("Long:")+" 2\n",
+		kv.PrettyString())
+}
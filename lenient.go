@@ -0,0 +1,60 @@
+package pretty
+
+import "fmt"
+
+// SetLenientRows toggles a mode in which rows of the wrong length are fitted
+// to the columns instead of rejected, for data whose field count varies, such
+// as responses from different API versions. Short rows are padded with empty
+// cells, and long rows are truncated, with a warning returned by Warnings.
+func (table *Table) SetLenientRows(lenient bool) {
+	table.lenientRows = lenient
+}
+
+// WithLenientRows toggles the mode in which rows of the wrong length are
+// fitted to the columns. See Table.SetLenientRows.
+func WithLenientRows(lenient bool) Option {
+	return func(table *Table) error {
+		table.SetLenientRows(lenient)
+		return nil
+	}
+}
+
+// Warnings returns the problems that were worked around while adding rows,
+// such as values dropped from rows that were too long.
+func (table *Table) Warnings() []error {
+	return table.warnings
+}
+
+// fitRows fits rows to the columns if rows are lenient. Rows are numbered
+// from offset+1 in warnings.
+func (table *Table) fitRows(rows [][]string, offset int) [][]string {
+	if !table.lenientRows {
+		return rows
+	}
+	fitted := make([][]string, len(rows))
+	for i, row := range rows {
+		fitted[i] = fitRow(table, row, offset+i+1)
+	}
+	return fitted
+}
+
+// fitRow pads or truncates row to the number of input columns, warning about
+// any values dropped.
+func fitRow[T any](table *Table, row []T, number int) []T {
+	inputColumnCount := table.inputColumnCount()
+	switch {
+	case len(row) > inputColumnCount:
+		table.warnings = append(table.warnings, fmt.Errorf(
+			"row %d: dropped %d of %d values to fit %d columns",
+			number,
+			len(row)-inputColumnCount,
+			len(row),
+			inputColumnCount))
+		return row[:inputColumnCount]
+	case len(row) < inputColumnCount:
+		padded := make([]T, inputColumnCount)
+		copy(padded, row)
+		return padded
+	}
+	return row
+}
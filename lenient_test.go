@@ -0,0 +1,45 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestLenientRows(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Count")},
+		WithLenientRows(true),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("a"))
+	assert.Nil(t, table.AddRows([][]string{{"b", "2"}, {"c", "3", "x", "y"}}))
+	assert.Nil(t, table.AddValues("d", 4, true))
+
+	rows, err := table.Rows()
+	assert.Nil(t, err)
+	assert.DeepEqual(
+		t,
+		[][]string{{"a", ""}, {"b", "2"}, {"c", "3"}, {"d", "4"}},
+		rows,
+	)
+
+	warnings := table.Warnings()
+	assert.EqualInt(t, 2, len(warnings))
+	assert.EqualString(
+		t,
+		"row 3: dropped 2 of 4 values to fit 2 columns",
+		warnings[0].Error(),
+	)
+	assert.EqualString(
+		t,
+		"row 4: dropped 1 of 3 values to fit 2 columns",
+		warnings[1].Error(),
+	)
+
+	assert.Nil(t, table.SetRows([][]string{{}, {"e", "5", "z"}}))
+	rows, err = table.Rows()
+	assert.Nil(t, err)
+	assert.DeepEqual(t, [][]string{{"", ""}, {"e", "5"}}, rows)
+	assert.EqualInt(t, 3, len(table.Warnings()))
+}
@@ -0,0 +1,187 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// defaultBullets are the bullets of each level of nested bulleted lists.
+var defaultBullets = []string{"•", "◦", "▪"}
+
+// minWrapWidth is the narrowest that text is wrapped to, however deeply it is
+// nested.
+const minWrapWidth = 10
+
+// List renders a bulleted or numbered list, whose items may contain nested
+// lists. Long items are wrapped to the width of the terminal, with the
+// following lines aligned to the text of the first.
+type List struct {
+	items    []listItem
+	numbered bool
+
+	bullets      []string
+	attributes   []color.Attribute
+	width        int
+	colorEnabled *bool
+}
+
+type listItem struct {
+	text    string
+	sublist *List
+}
+
+// NewList creates a bulleted list of the given items.
+func NewList(items ...string) *List {
+	return (&List{}).Add(items...)
+}
+
+// NewNumberedList creates a numbered list of the given items.
+func NewNumberedList(items ...string) *List {
+	return (&List{numbered: true}).Add(items...)
+}
+
+// Add adds items to the end of the list, and returns the list.
+func (list *List) Add(items ...string) *List {
+	for _, item := range items {
+		list.items = append(list.items, listItem{text: item})
+	}
+	return list
+}
+
+// AddWithSublist adds an item followed by a nested list, and returns the
+// list.
+func (list *List) AddWithSublist(item string, sublist *List) *List {
+	list.items = append(list.items, listItem{text: item, sublist: sublist})
+	return list
+}
+
+// SetBullets sets the bullets of bulleted lists, one for each level of
+// nesting, starting over once all have been used. The default bullets are
+// "•", "◦" and "▪".
+func (list *List) SetBullets(bullets ...string) {
+	list.bullets = bullets
+}
+
+// SetMarkerColor sets the color attributes of the bullets and numbers.
+func (list *List) SetMarkerColor(attributes ...color.Attribute) {
+	list.attributes = attributes
+}
+
+// SetWidth sets the width that items are wrapped to. A width of 0, the
+// default, wraps to the width of the terminal, and does not wrap when not
+// writing to a terminal.
+func (list *List) SetWidth(width int) {
+	list.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (list *List) SetColor(enabled bool) {
+	list.colorEnabled = &enabled
+}
+
+// PrettyString renders the list, wrapped as if written to stdout.
+func (list *List) PrettyString() string {
+	return list.render(os.Stdout)
+}
+
+// Print prints the list to stdout.
+func (list *List) Print() error {
+	return list.Fprint(os.Stdout)
+}
+
+// Fprint prints the list to w.
+func (list *List) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), list.render(w))
+	return err
+}
+
+// render renders the list, wrapped to the width of w if it is a terminal.
+func (list *List) render(w io.Writer) string {
+	width := list.width
+	if width == 0 {
+		width, _ = terminalWidth(w)
+	}
+	bullets := list.bullets
+	if len(bullets) == 0 {
+		bullets = defaultBullets
+	}
+	renderer := &listRenderer{
+		bullets:      bullets,
+		attributes:   list.attributes,
+		width:        width,
+		colorEnabled: colorsEnabled(list.colorEnabled),
+	}
+
+	var builder strings.Builder
+	renderer.renderList(&builder, list, "", 0)
+	return builder.String()
+}
+
+// listRenderer holds the settings of the outermost list, which apply to all
+// nested lists.
+type listRenderer struct {
+	bullets      []string
+	attributes   []color.Attribute
+	width        int
+	colorEnabled bool
+}
+
+// renderList writes a list whose lines start with indent.
+func (renderer *listRenderer) renderList(
+	builder *strings.Builder,
+	list *List,
+	indent string,
+	depth int,
+) {
+	markers := make([]string, len(list.items))
+	markerWidth := 0
+	for i := range list.items {
+		if list.numbered {
+			markers[i] = strconv.Itoa(i+1) + "."
+		} else {
+			markers[i] = renderer.bullets[depth%len(renderer.bullets)]
+		}
+		if strLengthWithEncoding(markers[i]) > markerWidth {
+			markerWidth = strLengthWithEncoding(markers[i])
+		}
+	}
+
+	// Numbers are right-aligned, and the text of every item starts in the
+	// same column.
+	textIndent := indent + strings.Repeat(" ", markerWidth+1)
+	wrapWidth := 0
+	if renderer.width > 0 {
+		wrapWidth = renderer.width - strLengthWithEncoding(textIndent)
+		if wrapWidth < minWrapWidth {
+			wrapWidth = minWrapWidth
+		}
+	}
+
+	for i, item := range list.items {
+		padding := strings.Repeat(
+			" ",
+			markerWidth-strLengthWithEncoding(markers[i]))
+		for j, line := range Wrap(item.text, wrapWidth) {
+			if j == 0 {
+				builder.WriteString(indent + padding)
+				builder.WriteString(colorize(
+					markers[i],
+					renderer.colorEnabled,
+					renderer.attributes...))
+				builder.WriteString(" ")
+			} else {
+				builder.WriteString(textIndent)
+			}
+			builder.WriteString(line)
+			builder.WriteString("\n")
+		}
+		if item.sublist != nil {
+			renderer.renderList(builder, item.sublist, textIndent, depth+1)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestList(t *testing.T) {
+	steps := NewNumberedList(
+		"Take a snapshot of every virtual machine in the SLA domain before "+
+			"the maintenance window starts",
+		"Upgrade")
+	for i := 3; i <= 10; i++ {
+		steps.Add("Verify")
+	}
+	list := NewList("Prepare").
+		AddWithSublist("Steps", steps).
+		AddWithSublist("Cleanup", NewList("Expire snapshots", "Notify"))
+	list.SetWidth(40)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, list.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/list.txt"),
+		buffer.String())
+}
+
+func TestListMarkerColor(t *testing.T) {
+	list := NewList("one")
+	list.SetBullets("-")
+	list.SetMarkerColor(color.FgCyan)
+	list.SetColor(true)
+
+	printer := color.New(color.FgCyan)
+	printer.EnableColor()
+	assert.EqualString(t, printer.Sprint("-")+" one\n", list.PrettyString())
+}
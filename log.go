@@ -0,0 +1,30 @@
+package pretty
+
+import "strings"
+
+// LogTo renders the table and passes each line to logf, such as log.Printf or
+// testing.T.Logf, after prefix. Logging line by line keeps the columns
+// aligned under the prefixes that loggers add. Colors are off unless the
+// table enables them with SetColor, in which case every line ends with a
+// reset so that none is left open in the log.
+func (table *Table) LogTo(
+	logf func(format string, args ...interface{}),
+	prefix string,
+) error {
+	if table.colorEnabled == nil {
+		disabled := false
+		table.colorEnabled = &disabled
+		defer func() {
+			table.colorEnabled = nil
+		}()
+	}
+
+	output, err := table.PrettyString()
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		logf("%s%s", prefix, line)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package pretty
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestLogTo(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("vm-1"))
+
+	var lines []string
+	logf := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	noColor := color.NoColor
+	color.NoColor = false
+	defer func() {
+		color.NoColor = noColor
+	}()
+	assert.Nil(t, table.LogTo(logf, "snapshot: "))
+	assert.DeepEqual(
+		t,
+		[]string{
+			"snapshot: +------+",
+			"snapshot: | Name |",
+			"snapshot: +------+",
+			"snapshot: | vm-1 |",
+			"snapshot: +------+",
+		},
+		lines)
+	assert.True(t, table.colorEnabled == nil)
+
+	// Each line of a colored table leaves no color open.
+	lines = nil
+	table.SetColor(true)
+	assert.Nil(t, table.LogTo(logf, ""))
+	for _, line := range lines {
+		if strings.Contains(line, "\x1b[") {
+			assert.True(t, strings.HasSuffix(line, "\x1b[0m|"))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package pretty
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// SetMaxRows limits the number of rows displayed to maxRows. Longer tables
+// show their first and last rows, half each, around a divider such as
+// "⋯ (1,234 rows omitted) ⋯". The row count still counts every row. A
+// maxRows of 0 removes the limit.
+func (table *Table) SetMaxRows(maxRows int) {
+	table.SetMaxRowsSplit(maxRows-maxRows/2, maxRows/2)
+}
+
+// SetMaxRowsSplit limits the number of rows displayed like SetMaxRows, but
+// chooses how many of the first and last rows are shown, as in
+// SetMaxRowsSplit(10, 0) to show only the first 10. Negative counts are
+// treated as 0.
+func (table *Table) SetMaxRowsSplit(head int, tail int) {
+	if head < 0 {
+		head = 0
+	}
+	if tail < 0 {
+		tail = 0
+	}
+	table.maxHeadRows = head
+	table.maxTailRows = tail
+}
+
+// WithMaxRows limits the number of rows displayed. See Table.SetMaxRows.
+func WithMaxRows(maxRows int) Option {
+	return func(table *Table) error {
+		table.SetMaxRows(maxRows)
+		return nil
+	}
+}
+
+// WithMaxRowsSplit limits the number of first and last rows displayed. See
+// Table.SetMaxRowsSplit.
+func WithMaxRowsSplit(head int, tail int) Option {
+	return func(table *Table) error {
+		table.SetMaxRowsSplit(head, tail)
+		return nil
+	}
+}
+
+// omittedRowCount returns the number of rows left out by SetMaxRows.
+func (table *Table) omittedRowCount() int {
+	if table.maxHeadRows+table.maxTailRows <= 0 {
+		return 0
+	}
+	omitted := table.rowCount() - table.maxHeadRows - table.maxTailRows
+	if omitted < 0 {
+		return 0
+	}
+	return omitted
+}
+
+// displayedRows returns the first and last rows shown when omitted rows are
+// left out, as they are displayed. Spilled rows must have been loaded.
+func (table *Table) displayedRows(
+	columnDefs []ColumnDef,
+	omitted int,
+) [][]string {
+	rows := make([][]string, 0, len(table.rows)-omitted)
+	for i := 0; i < table.maxHeadRows; i++ {
+		rows = append(rows, table.renderedRow(columnDefs, i))
+	}
+	for i := table.maxHeadRows + omitted; i < len(table.rows); i++ {
+		rows = append(rows, table.renderedRow(columnDefs, i))
+	}
+	return rows
+}
+
+// renderOmittingRows writes the displayed rows around a divider that counts
+// the omitted rows.
+func (table *Table) renderOmittingRows(
+	ctx context.Context,
+	w renderWriter,
+	renderer *rowRenderer,
+	columnDefs []ColumnDef,
+	rows [][]string,
+	omitted int,
+	justifications []Alignment,
+) error {
+	head, tail := rows[:table.maxHeadRows], rows[table.maxHeadRows:]
+	tailOffset := table.maxHeadRows + omitted
+	table.recordTruncations(columnDefs, head, 0, renderer)
+	table.recordTruncations(columnDefs, tail, tailOffset, renderer)
+
+	err := table.renderRows(ctx, w, renderer, head, 0, justifications)
+	if err != nil {
+		return err
+	}
+	w.WriteString(omittedRowsLine(renderer, omitted))
+	return table.renderRows(
+		ctx,
+		w,
+		renderer,
+		tail,
+		tailOffset,
+		justifications)
+}
+
+// omittedRowsLine returns the divider row that counts the omitted rows,
+// centered across the table, with its line break.
+func omittedRowsLine(renderer *rowRenderer, omitted int) string {
+	style := renderer.border
+	ellipsis := "⋯"
+	if isASCII(style.Vertical) {
+		ellipsis = "..."
+	}
+	label := translate("(%s rows omitted)", groupThousands(omitted))
+	if omitted == 1 {
+		label = translate("(1 row omitted)")
+	}
+	content := ellipsis + " " + label + " " + ellipsis
+
+	// The divider spans the cells and the borders between them, less a
+	// space of padding on each side.
+	width := -2 - strLengthWithEncoding(style.Vertical)
+	for _, columnSize := range renderer.columnSizes {
+		width += columnSize + 2 + strLengthWithEncoding(style.Vertical)
+	}
+	content = Truncate(content, width)
+	padding := width - strLengthWithEncoding(content)
+	return style.Vertical + " " +
+		strings.Repeat(" ", padding/2) +
+		content +
+		strings.Repeat(" ", padding-padding/2) +
+		" " + style.Vertical + "\n"
+}
+
+// groupThousands formats n with commas between groups of three digits, as in
+// 1,234.
+func groupThousands(n int) string {
+	digits := strconv.Itoa(n)
+	sign := ""
+	if n < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	var builder strings.Builder
+	builder.WriteString(sign)
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteByte(',')
+		}
+		builder.WriteRune(digit)
+	}
+	return builder.String()
+}
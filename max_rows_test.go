@@ -0,0 +1,88 @@
+package pretty
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestMaxRows(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Index")},
+		WithColor(false),
+		WithMaxRows(3),
+		WithRowCount(true),
+	)
+	assert.Nil(t, err)
+	for i := 1; i <= 1236; i++ {
+		assert.Nil(t, table.AddRow(fmt.Sprintf("vm-%d", i), fmt.Sprint(i)))
+	}
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---------+-------+\n"+
+			"| Name    | Index |\n"+
+			"+---------+-------+\n"+
+			"|    vm-1 |     1 |\n"+
+			"|    vm-2 |     2 |\n"+
+			"| ... (1,233 r... |\n"+
+			"| vm-1236 |  1236 |\n"+
+			"+---------+-------+\n"+
+			"Count: 1236\n",
+		rendered,
+	)
+
+	table.SetMaxRowsSplit(0, 1)
+	table.SetBorderStyle(BorderLight)
+	table.ShowRowCount(false)
+	assert.Nil(t, table.SetRows([][]string{
+		{"first virtual machine", "1"},
+		{"second virtual machine", "2"},
+	}))
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"┌────────────────────────┬───────┐\n"+
+			"│ Name                   │ Index │\n"+
+			"├────────────────────────┼───────┤\n"+
+			"│      ⋯ (1 row omitted) ⋯       │\n"+
+			"│ second virtual machine │     2 │\n"+
+			"└────────────────────────┴───────┘\n",
+		rendered,
+	)
+
+	assert.EqualString(t, "1,234,567", groupThousands(1234567))
+	assert.EqualString(t, "-999", groupThousands(-999))
+}
+
+func TestMaxRowsSplitNegative(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("N")},
+		WithColor(false),
+		WithMaxRowsSplit(4, -2),
+	)
+	assert.Nil(t, err)
+	for _, n := range []string{"1", "2", "3", "4", "5", "6"} {
+		assert.Nil(t, table.AddRow(n))
+	}
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---+\n"+
+			"| N |\n"+
+			"+---+\n"+
+			"| 1 |\n"+
+			"| 2 |\n"+
+			"| 3 |\n"+
+			"| 4 |\n"+
+			"| . |\n"+
+			"+---+\n",
+		rendered,
+	)
+}
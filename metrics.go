@@ -0,0 +1,178 @@
+package pretty
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// metricDurationUnits are the name suffixes of metrics measured in units of
+// time, which are formatted as durations.
+var metricDurationUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	{"seconds", time.Second},
+	{"ms", time.Millisecond},
+	{"us", time.Microsecond},
+	{"ns", time.Nanosecond},
+}
+
+// MetricsTable creates a table of metrics and their values, sorted by name,
+// for debug commands that show the state of a process. Nested maps are
+// flattened into metrics named "parent.key". Values are formatted according
+// to the unit that ends the name of their metric, after "_", "." or "-", or
+// capitalized as in camel case:
+//
+//	heap_bytes, HeapBytes       1.5 GiB
+//	latency_ms, uptime_seconds  250ms, 3h12m5s
+//
+// Values of type time.Duration are formatted as durations, and slices as
+// their elements separated by spaces, or as a count of numbers.
+func MetricsTable(
+	metrics map[string]interface{},
+	opts ...Option,
+) (*Table, error) {
+	flattened := make(map[string]interface{}, len(metrics))
+	for name, value := range metrics {
+		flattenMetric(flattened, name, value)
+	}
+	names := make([]string, 0, len(flattened))
+	for name := range flattened {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table, err := NewPrettyTable(
+		NewColumnDef("Metric").WithAlignment(LeftJustify),
+		NewColumnDef("Value"))
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		err := table.AddRow(name, formatMetric(name, flattened[name]))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return table, nil
+}
+
+// ExpvarTable creates a MetricsTable of the variables published with the
+// expvar package, including the memstats and cmdline variables it publishes
+// itself.
+func ExpvarTable(opts ...Option) (*Table, error) {
+	metrics := make(map[string]interface{})
+	var err error
+	expvar.Do(func(variable expvar.KeyValue) {
+		decoder := json.NewDecoder(strings.NewReader(variable.Value.String()))
+		decoder.UseNumber()
+		var value interface{}
+		if decodeErr := decoder.Decode(&value); decodeErr != nil && err == nil {
+			err = fmt.Errorf("invalid expvar %s: %v", variable.Key, decodeErr)
+		}
+		metrics[variable.Key] = value
+	})
+	if err != nil {
+		return nil, err
+	}
+	return MetricsTable(metrics, opts...)
+}
+
+// flattenMetric adds the metric to flattened, or the metrics it holds if it
+// is a map.
+func flattenMetric(
+	flattened map[string]interface{},
+	name string,
+	value interface{},
+) {
+	reflected := reflect.ValueOf(value)
+	if reflected.Kind() != reflect.Map {
+		flattened[name] = value
+		return
+	}
+	iter := reflected.MapRange()
+	for iter.Next() {
+		key := formatValue(iter.Key().Interface())
+		flattenMetric(flattened, name+"."+key, iter.Value().Interface())
+	}
+}
+
+func formatMetric(name string, value interface{}) string {
+	if duration, ok := value.(time.Duration); ok {
+		return duration.String()
+	}
+	if number, ok := value.(json.Number); ok {
+		value = number.String()
+	}
+
+	reflected := reflect.ValueOf(value)
+	if kind := reflected.Kind(); kind == reflect.Slice || kind == reflect.Array {
+		if _, ok := numericSeries(value); ok {
+			return fmt.Sprintf("(%d values)", reflected.Len())
+		}
+		elements := make([]string, reflected.Len())
+		for i := range elements {
+			elements[i] = formatValue(reflected.Index(i).Interface())
+		}
+		return strings.Join(elements, " ")
+	}
+
+	number, ok := numberValue(value)
+	if !ok {
+		return formatValue(value)
+	}
+	if hasMetricUnit(name, "bytes") {
+		return formatBytes(number)
+	}
+	for _, durationUnit := range metricDurationUnits {
+		if hasMetricUnit(name, durationUnit.suffix) {
+			return roundDuration(
+				time.Duration(number * float64(durationUnit.unit))).String()
+		}
+	}
+	switch reflected.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return formatNumeric(number)
+	}
+	return formatValue(value)
+}
+
+// hasMetricUnit returns whether the name of a metric ends with unit, after a
+// separator or capitalized.
+func hasMetricUnit(name string, unit string) bool {
+	lower := strings.ToLower(name)
+	for _, separator := range []string{"_", ".", "-"} {
+		if strings.HasSuffix(lower, separator+unit) {
+			return true
+		}
+	}
+	capitalized := strings.ToUpper(unit[:1]) + unit[1:]
+	return len(name) > len(unit) && strings.HasSuffix(name, capitalized)
+}
+
+// roundDuration rounds a duration to a thousandth of its largest unit, as in
+// 1.235s, or to whole seconds if it is a minute or more.
+func roundDuration(duration time.Duration) time.Duration {
+	for _, unit := range []time.Duration{
+		time.Minute,
+		time.Second,
+		time.Millisecond,
+		time.Microsecond,
+	} {
+		if duration >= unit || -duration >= unit {
+			if unit == time.Minute {
+				return duration.Round(time.Second)
+			}
+			return duration.Round(unit / 1000)
+		}
+	}
+	return duration
+}
@@ -0,0 +1,62 @@
+package pretty
+
+import (
+	"expvar"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestMetricsTable(t *testing.T) {
+	table, err := MetricsTable(
+		map[string]interface{}{
+			"requests":       1234,
+			"heap_bytes":     uint64(1610612736),
+			"latency_ms":     12.3456,
+			"uptime_seconds": 11525,
+			"GCPauseNs":      []uint64{100, 200},
+			"cmdline":        []string{"server", "-v"},
+			"timeout":        1500 * time.Millisecond,
+			"ratio":          0.25,
+			"cache": map[string]interface{}{
+				"hits":   "98",
+				"misses": 2,
+			},
+		},
+		WithColor(false))
+	assert.Nil(t, err)
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+----------------+------------+\n"+
+			"| Metric         | Value      |\n"+
+			"+----------------+------------+\n"+
+			"| GCPauseNs      | (2 values) |\n"+
+			"| cache.hits     |         98 |\n"+
+			"| cache.misses   |          2 |\n"+
+			"| cmdline        |  server -v |\n"+
+			"| heap_bytes     |    1.5 GiB |\n"+
+			"| latency_ms     |   12.346ms |\n"+
+			"| ratio          |       0.25 |\n"+
+			"| requests       |       1234 |\n"+
+			"| timeout        |       1.5s |\n"+
+			"| uptime_seconds |    3h12m5s |\n"+
+			"+----------------+------------+\n",
+		output)
+}
+
+func TestExpvarTable(t *testing.T) {
+	expvar.NewInt("test_expvar_table_bytes").Set(2048)
+
+	table, err := ExpvarTable(WithColor(false))
+	assert.Nil(t, err)
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(output, "| test_expvar_table_bytes |"))
+	assert.True(t, strings.Contains(output, "2 KiB |"))
+	assert.True(t, strings.Contains(output, "| memstats.HeapAlloc "))
+}
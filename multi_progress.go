@@ -0,0 +1,104 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// MultiProgress shows several progress bars at once, one per line, for
+// operations running in parallel. The bars are redrawn in place whenever any
+// of them is updated, from any goroutine. When not writing to a terminal,
+// the bars are only written once, by Stop.
+type MultiProgress struct {
+	mu sync.Mutex
+
+	w          io.Writer
+	isTerminal bool
+	bars       []*ProgressBar
+	// drawnLines is the number of lines drawn by the previous redraw, which
+	// the cursor must move up to draw over them.
+	drawnLines int
+	stopped    bool
+}
+
+// NewMultiProgress creates a MultiProgress writing to w.
+func NewMultiProgress(w io.Writer) *MultiProgress {
+	return &MultiProgress{
+		w:          w,
+		isTerminal: isTerminal(w),
+	}
+}
+
+// AddBar adds a bar with the given label below the existing ones, which is
+// complete once total units of work are done. The bar is updated like any
+// other ProgressBar, but Finish leaves it in place instead of ending a line.
+func (multi *MultiProgress) AddBar(label string, total int64) *ProgressBar {
+	bar := NewProgressBar(multi.w, total)
+	bar.label = label
+	bar.manager = multi
+
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+	multi.bars = append(multi.bars, bar)
+	return bar
+}
+
+// Stop draws the bars a final time. Later updates are not drawn.
+func (multi *MultiProgress) Stop() error {
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+	if multi.stopped {
+		return nil
+	}
+	multi.stopped = true
+	return multi.draw()
+}
+
+// redraw draws the bars in place, if writing to a terminal.
+func (multi *MultiProgress) redraw() error {
+	multi.mu.Lock()
+	defer multi.mu.Unlock()
+	if multi.stopped || !multi.isTerminal {
+		return nil
+	}
+	return multi.draw()
+}
+
+func (multi *MultiProgress) draw() error {
+	// Labels are padded so that the bars line up.
+	labels := make([]string, len(multi.bars))
+	labelWidth := 0
+	for i, bar := range multi.bars {
+		bar.mu.Lock()
+		labels[i] = bar.label
+		bar.mu.Unlock()
+		if strLengthWithEncoding(labels[i]) > labelWidth {
+			labelWidth = strLengthWithEncoding(labels[i])
+		}
+	}
+
+	var builder strings.Builder
+	if multi.isTerminal && multi.drawnLines > 0 {
+		fmt.Fprintf(&builder, "\x1b[%dA", multi.drawnLines)
+	}
+	for i, bar := range multi.bars {
+		bar.mu.Lock()
+		bar.label = labels[i] +
+			strings.Repeat(" ", labelWidth-strLengthWithEncoding(labels[i]))
+		line := bar.line()
+		bar.label = labels[i]
+		bar.mu.Unlock()
+
+		if multi.isTerminal {
+			builder.WriteString("\r" + line + "\x1b[K\n")
+		} else {
+			builder.WriteString(line + "\n")
+		}
+	}
+	multi.drawnLines = len(multi.bars)
+
+	_, err := io.WriteString(consoleWriter(multi.w), builder.String())
+	return err
+}
@@ -0,0 +1,78 @@
+package pretty
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestMultiProgress(t *testing.T) {
+	var buffer bytes.Buffer
+	multi := NewMultiProgress(&buffer)
+	bars := []*ProgressBar{
+		multi.AddBar("vm-1", 100),
+		multi.AddBar("database", 100),
+	}
+	for _, bar := range bars {
+		bar.SetWidth(10)
+	}
+
+	var wg sync.WaitGroup
+	for _, bar := range bars {
+		wg.Add(1)
+		go func(bar *ProgressBar) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				assert.Nil(t, bar.Add(1))
+			}
+			assert.Nil(t, bar.Finish())
+		}(bar)
+	}
+	wg.Wait()
+
+	// Nothing is drawn in place when not writing to a terminal.
+	assert.EqualString(t, "", buffer.String())
+	for _, bar := range bars {
+		start := bar.start
+		bar.now = func() time.Time { return start.Add(10 * time.Second) }
+	}
+	assert.Nil(t, multi.Stop())
+	assert.Nil(t, multi.Stop())
+	assert.EqualString(
+		t,
+		"vm-1     [##########] 100%  10/s  ETA 0s\n"+
+			"database [##########] 100%  10/s  ETA 0s\n",
+		buffer.String())
+}
+
+func TestMultiProgressInPlace(t *testing.T) {
+	var buffer bytes.Buffer
+	multi := NewMultiProgress(&buffer)
+	multi.isTerminal = true
+	first := multi.AddBar("a", 4)
+	second := multi.AddBar("bb", 4)
+	for _, bar := range []*ProgressBar{first, second} {
+		start := bar.start
+		bar.now = func() time.Time { return start.Add(time.Second) }
+		bar.SetWidth(4)
+	}
+
+	assert.Nil(t, first.Add(2))
+	assert.Nil(t, second.Add(1))
+	assert.Nil(t, multi.Stop())
+	assert.Nil(t, first.Add(1))
+	assert.EqualString(
+		t,
+		"\ra  [##--]  50%  2/s  ETA 1s\x1b[K\n"+
+			"\rbb [----]   0%  --/s  ETA --\x1b[K\n"+
+			"\x1b[2A"+
+			"\ra  [##--]  50%  2/s  ETA 1s\x1b[K\n"+
+			"\rbb [#---]  25%  1/s  ETA 3s\x1b[K\n"+
+			"\x1b[2A"+
+			"\ra  [##--]  50%  2/s  ETA 1s\x1b[K\n"+
+			"\rbb [#---]  25%  1/s  ETA 3s\x1b[K\n",
+		buffer.String())
+}
@@ -0,0 +1,68 @@
+package pretty
+
+import (
+	"math"
+	"strconv"
+)
+
+// FormatSignificant returns a Formatter that rounds numbers to the given
+// number of significant digits, such as "0.000123" or "123000" for three,
+// without trailing zeros. Values may be of any numeric type, or numeric
+// strings as stored by AddRow. Other values are formatted with fmt.Sprint.
+func FormatSignificant(digits int) Formatter {
+	return func(value interface{}) string {
+		number, ok := numberValue(value)
+		if !ok {
+			return formatValue(value)
+		}
+		return formatSignificant(number, digits)
+	}
+}
+
+// FormatFixed returns a Formatter that rounds numbers to the given number of
+// decimals, always showing them all, such as "3.10" for two. Values are
+// handled as by FormatSignificant.
+func FormatFixed(decimals int) Formatter {
+	return func(value interface{}) string {
+		number, ok := numberValue(value)
+		if !ok {
+			return formatValue(value)
+		}
+		return strconv.FormatFloat(number, 'f', decimals, 64)
+	}
+}
+
+// FormatScientific returns a Formatter like FormatSignificant that switches
+// to scientific notation, such as "1.23e+09", for numbers other than zero
+// whose magnitude is below small or at least large.
+func FormatScientific(digits int, small, large float64) Formatter {
+	return func(value interface{}) string {
+		number, ok := numberValue(value)
+		if !ok {
+			return formatValue(value)
+		}
+		magnitude := math.Abs(number)
+		if number != 0 && (magnitude < small || magnitude >= large) {
+			if digits < 1 {
+				digits = 1
+			}
+			return strconv.FormatFloat(number, 'e', digits-1, 64)
+		}
+		return formatSignificant(number, digits)
+	}
+}
+
+// formatSignificant rounds number to the given number of significant digits
+// and formats it without an exponent.
+func formatSignificant(number float64, digits int) string {
+	if digits < 1 {
+		digits = 1
+	}
+	// FormatFloat rounds to significant digits, but only in a form that may
+	// have an exponent, so the result is parsed back. This always succeeds,
+	// even for infinities and NaN.
+	rounded, _ := strconv.ParseFloat(
+		strconv.FormatFloat(number, 'g', digits, 64),
+		64)
+	return formatNumeric(rounded)
+}
@@ -0,0 +1,35 @@
+package pretty
+
+import (
+	"math"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFormatSignificant(t *testing.T) {
+	format := FormatSignificant(3)
+	assert.EqualString(t, "0.333", format(1.0/3))
+	assert.EqualString(t, "123000", format(123456))
+	assert.EqualString(t, "0.0000123", format(0.0000123456))
+	assert.EqualString(t, "2.5", format("2.50"))
+	assert.EqualString(t, "-1.01", format(float32(-1.0123)))
+	assert.EqualString(t, "+Inf", format(math.Inf(1)))
+	assert.EqualString(t, "n/a", format("n/a"))
+}
+
+func TestFormatFixed(t *testing.T) {
+	format := FormatFixed(2)
+	assert.EqualString(t, "3.10", format(3.1))
+	assert.EqualString(t, "0.33", format(1.0/3))
+	assert.EqualString(t, "12.00", format(12))
+	assert.EqualString(t, "", format(nil))
+}
+
+func TestFormatScientific(t *testing.T) {
+	format := FormatScientific(3, 0.001, 1e6)
+	assert.EqualString(t, "1.23e+09", format(1234567890))
+	assert.EqualString(t, "1.23e-05", format(0.0000123456))
+	assert.EqualString(t, "123000", format(123456))
+	assert.EqualString(t, "0", format(0))
+}
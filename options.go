@@ -0,0 +1,98 @@
+package pretty
+
+import "fmt"
+
+// Option configures a Table when it is created.
+type Option func(table *Table) error
+
+// NewTable creates a new Table with the given columns, configured by opts.
+// Options are applied in order, after any defaults from the environment.
+func NewTable(columnDefs []ColumnDef, opts ...Option) (*Table, error) {
+	table, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.applyOptions(opts); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
+
+// WithHeader sets the header of the table. See Table.SetHeader.
+func WithHeader(header string) Option {
+	return func(table *Table) error {
+		table.SetHeader(header)
+		return nil
+	}
+}
+
+// WithRowCount toggles printing of the row count. See Table.ShowRowCount.
+func WithRowCount(showRowCount bool) Option {
+	return func(table *Table) error {
+		table.ShowRowCount(showRowCount)
+		return nil
+	}
+}
+
+// WithColumnNames toggles printing of the column names. See
+// Table.ShowColumnNames.
+func WithColumnNames(showColumnNames bool) Option {
+	return func(table *Table) error {
+		table.ShowColumnNames(showColumnNames)
+		return nil
+	}
+}
+
+// WithBorderStyle sets the border style. See Table.SetBorderStyle.
+func WithBorderStyle(style BorderStyle) Option {
+	return func(table *Table) error {
+		table.SetBorderStyle(style)
+		return nil
+	}
+}
+
+// WithMaxWidth limits the width of the table. See Table.SetMaxWidth.
+func WithMaxWidth(maxWidth int) Option {
+	return func(table *Table) error {
+		if maxWidth < 0 {
+			return fmt.Errorf("max width %d must not be negative", maxWidth)
+		}
+		table.SetMaxWidth(maxWidth)
+		return nil
+	}
+}
+
+// WithWide toggles wide mode, which ignores maximum widths. See
+// Table.SetWide.
+func WithWide(wide bool) Option {
+	return func(table *Table) error {
+		table.SetWide(wide)
+		return nil
+	}
+}
+
+// WithColor enables or disables colors. See Table.SetColor.
+func WithColor(enabled bool) Option {
+	return func(table *Table) error {
+		table.SetColor(enabled)
+		return nil
+	}
+}
+
+// WithPipedFormat sets the format used when output is not a terminal. See
+// Table.SetPipedFormat.
+func WithPipedFormat(format Format) Option {
+	return func(table *Table) error {
+		table.SetPipedFormat(format)
+		return nil
+	}
+}
+
+func (table *Table) applyOptions(opts []Option) error {
+	for _, opt := range opts {
+		if err := opt(table); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestNewTableWithOptions(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Employee Number"),
+			NewColumnDef("Name"),
+			NewColumnDef("Type"),
+			NewColumnDef("Phone Number"),
+		},
+		WithHeader("Employees"),
+		WithBorderStyle(BorderLight),
+		WithColor(false),
+		WithRowCount(true),
+		WithMaxWidth(100))
+	assert.Nil(t, err)
+
+	err = table.SetRows([][]string{
+		{"23", "Noel", "Human", "(123) 456-7899"},
+		{"83", "David", "Cyborg", "987-654-3211"},
+		{"52", "Pranava", "Crusher", "1-800-123-4567"},
+		{"1182", "Postnava", "Kitten", "1 (800) 987-6543"},
+	})
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_with_options.txt")
+}
+
+func TestNewTableWithInvalidOptions(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithMaxWidth(-1))
+	assert.NotNil(t, err)
+	assert.Nil(t, table)
+
+	table, err = NewTable(nil, WithHeader("Employees"))
+	assert.NotNil(t, err)
+	assert.Nil(t, table)
+}
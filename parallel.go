@@ -0,0 +1,108 @@
+package pretty
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// renderChunkSize is the number of rows rendered by a worker at a time.
+// Tables with fewer rows are always rendered serially.
+const renderChunkSize = 1024
+
+// SetRenderWorkers sets the number of goroutines rendering the rows of large
+// tables. Rows are rendered in chunks into separate buffers, which are then
+// written in order, so the output is the same as rendering serially. A value
+// of 0 or 1 renders serially, which is the default.
+func (table *Table) SetRenderWorkers(workers int) {
+	table.renderWorkers = workers
+}
+
+// WithRenderWorkers sets the number of goroutines rendering rows. See
+// Table.SetRenderWorkers.
+func WithRenderWorkers(workers int) Option {
+	return func(table *Table) error {
+		if workers < 0 {
+			return fmt.Errorf("render workers %d must not be negative", workers)
+		}
+		table.SetRenderWorkers(workers)
+		return nil
+	}
+}
+
+// renderedChunk is the output of rendering a chunk of rows. done is closed
+// once output and err are set.
+type renderedChunk struct {
+	output strings.Builder
+	err    error
+	done   chan struct{}
+}
+
+// renderRowsParallel writes the given rendered rows like renderRows, but
+// renders chunks of them concurrently.
+func (table *Table) renderRowsParallel(
+	ctx context.Context,
+	w renderWriter,
+	renderer *rowRenderer,
+	rows [][]string,
+	offset int,
+	justifications []Alignment,
+) error {
+	// Stop the workers early if writing fails or ctx is done.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	chunkCount := (len(rows) + renderChunkSize - 1) / renderChunkSize
+	chunks := make([]renderedChunk, chunkCount)
+	for i := range chunks {
+		chunks[i].done = make(chan struct{})
+	}
+
+	indexes := make(chan int)
+	go func() {
+		defer close(indexes)
+		for i := range chunks {
+			select {
+			case indexes <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for worker := 0; worker < table.renderWorkers; worker++ {
+		go func() {
+			// The renderer caches values, so each worker needs its own.
+			renderer := renderer.clone()
+			for i := range indexes {
+				start := i * renderChunkSize
+				end := start + renderChunkSize
+				if end > len(rows) {
+					end = len(rows)
+				}
+				chunk := &chunks[i]
+				chunk.err = table.renderRows(
+					ctx,
+					&chunk.output,
+					renderer,
+					rows[start:end],
+					offset+start,
+					justifications)
+				close(chunk.done)
+			}
+		}()
+	}
+
+	for i := range chunks {
+		chunk := &chunks[i]
+		<-chunk.done
+		if chunk.err != nil {
+			return chunk.err
+		}
+		if _, err := w.WriteString(chunk.output.String()); err != nil {
+			return err
+		}
+		chunk.output.Reset()
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package pretty
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func createLargeTable(t testing.TB, rowCount int) *Table {
+	table, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Phone Number"))
+	assert.Nil(t, err)
+	for i := 0; i < rowCount; i++ {
+		err = table.AddRow(strconv.Itoa(i), "Noel", "Human", "(123) 456-7899")
+		assert.Nil(t, err)
+	}
+	return table
+}
+
+func TestParallelRenderMatchesSerial(t *testing.T) {
+	table := createLargeTable(t, 5*renderChunkSize+17)
+	table.SetColor(true)
+	table.rowColorOverrides = map[int]color.Attribute{
+		renderChunkSize - 1: color.FgRed,
+		renderChunkSize:     color.FgGreen,
+		5 * renderChunkSize: color.FgYellow,
+	}
+	serial, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	for _, workers := range []int{2, 3, 16} {
+		table.SetRenderWorkers(workers)
+		parallel, err := table.PrettyString()
+		assert.Nil(t, err)
+		assert.Equal(t, serial, parallel)
+	}
+}
+
+// failingWriter fails every write.
+type failingWriter struct{}
+
+var errWriteFailed = errors.New("write failed")
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errWriteFailed
+}
+
+func TestParallelRenderWriteError(t *testing.T) {
+	table := createLargeTable(t, 4*renderChunkSize)
+	table.SetRenderWorkers(4)
+
+	err := table.RenderContext(context.Background(), failingWriter{})
+	assert.Equal(t, errWriteFailed, err)
+}
+
+func TestWithRenderWorkers(t *testing.T) {
+	_, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithRenderWorkers(-1))
+	assert.NotNil(t, err)
+
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithRenderWorkers(4))
+	assert.Nil(t, err)
+	assert.EqualInt(t, 4, table.renderWorkers)
+}
+
+func BenchmarkPrettyStringParallel(b *testing.B) {
+	table := createLargeTable(b, 100000)
+	table.SetRenderWorkers(8)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.PrettyString(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package pretty
+
+import (
+	"os"
+	"strings"
+)
+
+// FormatPath returns a Formatter for file paths that abbreviates the home
+// directory of the user to "~" and shortens paths wider than maxWidth by
+// replacing directories in their middle with "...", so that the start of the
+// path and its base name stay visible. A maxWidth of 0 or less only
+// abbreviates the home directory. Values that are not strings are formatted
+// with fmt.Sprint first.
+func FormatPath(maxWidth int) Formatter {
+	home, _ := os.UserHomeDir()
+	return func(value interface{}) string {
+		return shortenPath(formatValue(value), home, maxWidth)
+	}
+}
+
+// shortenPath abbreviates home in path to "~", then removes directories
+// from the middle of the path until it is at most maxWidth columns wide.
+// Paths are separated by slashes, or by backslashes if they have no slashes.
+func shortenPath(path string, home string, maxWidth int) string {
+	// Windows paths may be shown on any platform.
+	separator := "/"
+	if strings.Contains(path, `\`) && !strings.Contains(path, "/") {
+		separator = `\`
+	}
+	home = strings.TrimSuffix(home, separator)
+	if home != "" && (path == home ||
+		strings.HasPrefix(path, home+separator)) {
+		path = "~" + path[len(home):]
+	}
+	if maxWidth <= 0 || strLengthWithEncoding(path) <= maxWidth {
+		return path
+	}
+
+	parts := strings.Split(path, separator)
+	base := parts[len(parts)-1]
+	if len(parts) < 3 ||
+		strLengthWithEncoding(ellipsis+separator+base) > maxWidth {
+		return TruncateMiddle(base, maxWidth)
+	}
+
+	// Keep the first directory if it fits, then as many directories as fit
+	// next to the base name and at the start of the path, with the "..."
+	// between them. The first part of an absolute path is empty.
+	head, tail := 0, len(parts)-1
+	shortened := func(head int, tail int) string {
+		kept := append([]string(nil), parts[:head]...)
+		kept = append(kept, ellipsis)
+		kept = append(kept, parts[tail:]...)
+		return strings.Join(kept, separator)
+	}
+	width := func(head int, tail int) int {
+		return strLengthWithEncoding(shortened(head, tail))
+	}
+	first := 1
+	if parts[0] == "" {
+		first = 2
+	}
+	if first < tail && width(first, tail) <= maxWidth {
+		head = first
+	}
+	for tail-1 > head && width(head, tail-1) <= maxWidth {
+		tail--
+	}
+	for head+1 < tail && width(head+1, tail) <= maxWidth {
+		head++
+	}
+	return shortened(head, tail)
+}
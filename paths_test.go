@@ -0,0 +1,44 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestShortenPath(t *testing.T) {
+	home := "/home/ada"
+	assert.EqualString(
+		t,
+		"~/src/pretty/paths.go",
+		shortenPath("/home/ada/src/pretty/paths.go", home, 0))
+	assert.EqualString(t, "~", shortenPath("/home/ada", home, 0))
+	assert.EqualString(
+		t,
+		"/home/adam/notes",
+		shortenPath("/home/adam/notes", home, 0))
+	assert.EqualString(
+		t,
+		"/usr/.../pkg/README.md",
+		shortenPath("/usr/local/share/doc/pkg/README.md", home, 22))
+	assert.EqualString(
+		t,
+		"/usr/.../README.md",
+		shortenPath("/usr/local/share/doc/pkg/README.md", home, 20))
+	assert.EqualString(
+		t,
+		"/.../README.md",
+		shortenPath("/usr/local/share/doc/pkg/README.md", home, 14))
+	assert.EqualString(
+		t,
+		"~/.../paths.go",
+		shortenPath("/home/ada/src/pretty/paths.go", home, 15))
+	assert.EqualString(
+		t,
+		"a-very...me.txt",
+		shortenPath("/tmp/a-very-long-file-name.txt", home, 15))
+	assert.EqualString(
+		t,
+		`C:\...\report.txt`,
+		shortenPath(`C:\Users\ada\Documents\report.txt`, "", 17))
+}
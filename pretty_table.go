@@ -22,11 +22,16 @@
 package pretty
 
 import (
-	"bytes"
+	"bufio"
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
+	"sync"
+	"time"
 	"unicode"
+	"unicode/utf8"
 
 	"github.com/fatih/color"
 )
@@ -37,21 +42,123 @@ type Table struct {
 	columnDefs          []ColumnDef
 	rows                [][]string
 	shouldPrintRowCount bool
+	hideColumnNames     bool
+	sniffTypes          bool
+	sanitization        Sanitization
+	accessible          bool
+	pipedFormat         Format
+	border              BorderStyle
+	maxWidth            int
+	// colorEnabled overrides the global color setting of the color package
+	// when set.
+	colorEnabled *bool
+	// maxWidthFromEnvironment, accessibleFromEnvironment,
+	// colorFromEnvironment and borderFromEnvironment are whether maxWidth,
+	// accessible, colorEnabled and border were set by environment variables
+	// rather than the program.
+	maxWidthFromEnvironment   bool
+	accessibleFromEnvironment bool
+	colorFromEnvironment      bool
+	borderFromEnvironment     bool
+	// columnWidths is the widest stored value of each column among the first
+	// measuredRows rows, so that rendering only measures new rows.
+	columnWidths []int
+	measuredRows int
+	// rowColorOverrides replaces the column colors of individual rows, keyed
+	// by row index.
+	rowColorOverrides map[int]color.Attribute
+	// values holds the raw values of rows added with AddValues, indexed like
+	// rows. Rows added as strings, including any beyond the end of values,
+	// have nil entries.
+	values        [][]interface{}
+	renderWorkers int
+	// spill holds rows written to disk, which precede the rows in memory, if
+	// a spill threshold is set.
+	spill *rowSpill
+	// rowCountFormat and rowCountPluralFormat replace the default format of
+	// the row count when set.
+	rowCountFormat       string
+	rowCountPluralFormat string
+	rowCountPosition     RowCountPosition
+	// deferredErrors holds the problems found while adding rows, if
+	// deferErrors is set.
+	deferErrors    bool
+	deferredErrors []error
+	// warnings holds the problems worked around while adding rows, if
+	// lenientRows is set.
+	lenientRows bool
+	warnings    []error
+	// truncations holds the cells truncated by the last rendering.
+	truncations    []Truncation
+	truncationNote string
+	wide           bool
+	shrinkStrategy ShrinkStrategy
+	fixedWidths    []int
+	maxHeadRows    int
+	maxTailRows    int
+	// sortColumn is the column the rows were last sorted by, if any.
+	sortColumn        string
+	sortDescending    bool
+	showSortIndicator bool
+	stacked           bool
+	stackKeyColumns   []string
+	exportEncoding    Encoding
+	colorDepth        ColorDepth
+	hyperlinks        *bool
+	hyperlinkFallback HyperlinkFallback
+	style             Style
+	unitPlacement     UnitPlacement
+	timeZone          *time.Location
+	rowChanges        map[int]RowChange
+	rowMarkers        bool
+	rowAnnotations    map[int]string
 }
 
 // ColumnDef is a representation of a column definition with a name and a
 // maximum width. The max width must be > 3, and the name must be shorter than
 // the max width. Errors will happen on instantiation of the table.
 type ColumnDef struct {
-	name     string
-	maxWidth *int
+	name         string
+	maxWidth     *int
+	alignment    *Alignment
+	formatter    Formatter
+	derive       func(row map[string]string) string
+	validators   []Validator
+	shrinkWeight *int
+	style        *Style
+	prefix       string
+	suffix       string
+	unit         string
+	timeZone     *time.Location
+	// shortIDLength is the length IDs are shortened to, or 0 to show them
+	// in full.
+	shortIDLength int
+	valueColor    func(value string) color.Attribute
+	statusSymbols bool
 }
 
+// Formatter converts the value of a cell into the text that is displayed.
+type Formatter func(value interface{}) string
+
 // NewColumnDef creates a ColumnDef with a name and no maximum width.
 func NewColumnDef(name string) ColumnDef {
 	return ColumnDef{name: name}
 }
 
+// NewDerivedColumnDef creates a ColumnDef whose value is computed from each
+// row by derive when the table is rendered. derive is given the values of all
+// non-derived columns, keyed by column name. Derived columns are skipped when
+// passing values to AddRow and SetRows.
+func NewDerivedColumnDef(
+	name string,
+	derive func(row map[string]string) string,
+) ColumnDef {
+	return ColumnDef{
+		name:   name,
+		derive: derive,
+	}
+}
+
 // NewColumnDefWithWidth creates a ColumnDef with a name and maximum width.
 func NewColumnDefWithWidth(name string, maxWidth int) ColumnDef {
 	return ColumnDef{
@@ -60,11 +167,53 @@ func NewColumnDefWithWidth(name string, maxWidth int) ColumnDef {
 	}
 }
 
-type alignment uint
+// WithAlignment returns a copy of the ColumnDef whose values are aligned as
+// given. Values are right justified by default; column names are always left
+// justified.
+func (columnDef ColumnDef) WithAlignment(alignment Alignment) ColumnDef {
+	columnDef.alignment = &alignment
+	return columnDef
+}
+
+// WithFormatter returns a copy of the ColumnDef whose values are passed
+// through formatter when the table is rendered.
+func (columnDef ColumnDef) WithFormatter(formatter Formatter) ColumnDef {
+	columnDef.formatter = formatter
+	return columnDef
+}
+
+// WithPrefix returns a copy of the ColumnDef whose non-empty values are
+// displayed after prefix, such as "$" for prices.
+func (columnDef ColumnDef) WithPrefix(prefix string) ColumnDef {
+	columnDef.prefix = prefix
+	return columnDef
+}
+
+// WithSuffix returns a copy of the ColumnDef whose non-empty values are
+// displayed before suffix, such as " GiB" for sizes.
+func (columnDef ColumnDef) WithSuffix(suffix string) ColumnDef {
+	columnDef.suffix = suffix
+	return columnDef
+}
+
+// isPlain reports whether the column displays its stored values unchanged.
+func (columnDef ColumnDef) isPlain() bool {
+	return columnDef.derive == nil && columnDef.formatter == nil &&
+		columnDef.prefix == "" && columnDef.suffix == "" &&
+		columnDef.timeZone == nil && columnDef.shortIDLength == 0 &&
+		!columnDef.statusSymbols
+}
+
+// Alignment is the horizontal justification of values within a column.
+type Alignment uint
 
 const (
-	leftJustify  alignment = iota
-	rightJustify alignment = iota
+	// LeftJustify aligns values to the left edge of the cell.
+	LeftJustify Alignment = iota
+	// RightJustify aligns values to the right edge of the cell.
+	RightJustify Alignment = iota
+	// CenterJustify centers values within the cell.
+	CenterJustify Alignment = iota
 )
 
 var (
@@ -106,10 +255,12 @@ func NewPrettyTable(columnDefs ...ColumnDef) (*Table, error) {
 		}
 	}
 
-	return &Table{
+	table := &Table{
 		columnDefs: columnDefs,
 		rows:       make([][]string, 0),
-	}, nil
+	}
+	table.applyEnvironment()
+	return table, nil
 }
 
 // SetHeader creates a header for the table.
@@ -123,61 +274,445 @@ func (table *Table) ShowRowCount(showRowCount bool) {
 	table.shouldPrintRowCount = showRowCount
 }
 
+// SetMaxWidth limits the total width of the rendered table, including
+// borders. If the table is wider, the widest columns are shrunk and their
+// values truncated until it fits, although no column is shrunk below 4
+// characters. SetShrinkStrategy chooses other columns to shrink. A maxWidth
+// of 0 removes the limit.
+func (table *Table) SetMaxWidth(maxWidth int) {
+	table.maxWidth = maxWidth
+	table.maxWidthFromEnvironment = false
+}
+
+// SetWide toggles wide mode, in which the maximum widths of the table and its
+// columns are ignored and no value is truncated. It backs the conventional
+// --wide flag.
+func (table *Table) SetWide(wide bool) {
+	table.wide = wide
+}
+
+// SetColor enables or disables colors for this table, overriding the global
+// default, which is to use colors only when stdout is a terminal.
+func (table *Table) SetColor(enabled bool) {
+	table.colorEnabled = &enabled
+	table.colorFromEnvironment = false
+}
+
+// ShowColumnNames is a configuration, defaulted to true, that can be toggled
+// off to omit the row of column names when rendering.
+func (table *Table) ShowColumnNames(showColumnNames bool) {
+	table.hideColumnNames = !showColumnNames
+}
+
 // SetRows sets the rows of the table, overriding any that might
 // currently be there.
 func (table *Table) SetRows(rows [][]string) error {
-	for _, row := range rows {
-		if len(row) != len(table.columnDefs) {
-			return fmt.Errorf(
-				"row length %d must match columns %d",
-				len(row),
-				len(table.columnDefs))
-		}
+	rows = table.fitRows(rows, 0)
+	if table.deferErrors {
+		rows = table.validRows(rows, 0)
+	}
+	if err := table.validateRows(rows, 0); err != nil {
+		return err
 	}
 
-	table.rows = rows
-	return nil
+	expandedRows := make([][]string, len(rows))
+	for i, row := range rows {
+		expandedRows[i] = table.expandRow(row)
+	}
+	table.rows = expandedRows
+	table.values = nil
+	table.sortColumn = ""
+	table.rowChanges = nil
+	table.rowAnnotations = nil
+	table.resetColumnWidths()
+	if table.spill != nil {
+		if err := table.spill.discard(); err != nil {
+			return table.deferError(err)
+		}
+	}
+	return table.deferError(table.spillRows())
 }
 
 // AddRow adds a row to the table.
 func (table *Table) AddRow(row ...string) error {
-	if err := table.validateRowSize(row); err != nil {
+	if table.lenientRows {
+		row = fitRow(table, row, table.rowCount()+1)
+	}
+	if table.deferErrors &&
+		len(table.validRows([][]string{row}, table.rowCount())) == 0 {
+		return nil
+	}
+	if err := table.validateRow(row, table.rowCount()+1); err != nil {
 		return err
 	}
-	table.rows = append(table.rows, row)
-	return nil
+	table.rows = append(table.rows, table.expandRow(row))
+	return table.deferError(table.spillRows())
+}
+
+// AddRows adds rows to the end of the table. It is faster than calling AddRow
+// for each row. If any row has the wrong length, none are added, unless errors
+// are deferred, in which case only that row is left out.
+func (table *Table) AddRows(rows [][]string) error {
+	rows = table.fitRows(rows, table.rowCount())
+	if table.deferErrors {
+		rows = table.validRows(rows, table.rowCount())
+	}
+	inputColumnCount := table.inputColumnCount()
+	validatesValues := table.validatesValues()
+	for _, row := range rows {
+		if len(row) != inputColumnCount || validatesValues {
+			err := table.validateRows(rows, table.rowCount())
+			if err != nil {
+				return err
+			}
+			break
+		}
+	}
+
+	table.Grow(len(rows))
+	for _, row := range rows {
+		table.rows = append(table.rows, table.expandRow(row))
+	}
+	return table.deferError(table.spillRows())
+}
+
+// Grow preallocates space for n more rows, so that adding a known number of
+// rows does not repeatedly resize the table.
+func (table *Table) Grow(n int) {
+	if n <= cap(table.rows)-len(table.rows) {
+		return
+	}
+	rows := make([][]string, len(table.rows), len(table.rows)+n)
+	copy(rows, table.rows)
+	table.rows = rows
 }
 
 // PrettyString creates the pretty string representing this table.
 func (table *Table) PrettyString() (string, error) {
+	// The builder is grown to the size of the output once it is known.
+	var builder strings.Builder
+	if err := table.render(context.Background(), &builder); err != nil {
+		return "", err
+	}
+
+	// Pretty print!
+	return builder.String(), nil
+}
+
+// TestString renders the table like PrettyString, but in the same way in
+// every environment, for snapshot tests: without colors, with ASCII borders,
+// and without the settings of EnvMaxWidth and EnvAccessible, whatever the
+// terminal and environment variables. Values are formatted the same way in
+// every locale, as they always are.
+func (table *Table) TestString() (string, error) {
+	border := table.border
+	maxWidth := table.maxWidth
+	accessible := table.accessible
+	table.border = BorderASCII
+	if table.maxWidthFromEnvironment {
+		table.maxWidth = 0
+	}
+	if table.accessibleFromEnvironment {
+		table.accessible = false
+	}
+	defer func() {
+		table.border = border
+		table.maxWidth = maxWidth
+		table.accessible = accessible
+	}()
+	return table.plainString()
+}
+
+// RenderContext writes the pretty string representing this table to w,
+// checking ctx between rows so that rendering a huge table can be abandoned.
+// If ctx is done, the rows rendered so far are flushed to w and ctx.Err() is
+// returned.
+func (table *Table) RenderContext(ctx context.Context, w io.Writer) error {
+	writer := bufio.NewWriter(w)
+	err := table.render(ctx, writer)
+	if flushErr := writer.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// renderWriter is the destination of a rendered table, such as a
+// strings.Builder or bufio.Writer.
+type renderWriter interface {
+	io.Writer
+	io.StringWriter
+}
+
+// growableWriter is a renderWriter that can preallocate space for the output,
+// such as a strings.Builder or bytes.Buffer.
+type growableWriter interface {
+	Grow(n int)
+}
+
+func (table *Table) render(ctx context.Context, w renderWriter) error {
+	if err := table.Err(); err != nil {
+		return err
+	}
 	for _, row := range table.rows {
-		err := table.validateRowSize(row)
+		err := table.validateStoredRow(row)
 		if err != nil {
-			return "", err
+			return err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if err := table.validateFixedLayout(); err != nil {
+		return err
+	}
+	if table.rowMarkers {
+		return table.renderMarked(ctx, w)
+	}
+	if table.accessible {
+		return table.renderAccessible(ctx, w)
+	}
+	if table.stacked && table.maxWidth > 0 && !table.wide {
+		stacked, err := table.renderStacked(ctx, w)
+		if stacked || err != nil {
+			return err
 		}
 	}
+	columnDefs := table.resolvedColumnDefs()
+	omitted := table.omittedRowCount()
+	var rows [][]string
+	if omitted > 0 {
+		if err := table.loadSpilledRows(); err != nil {
+			return err
+		}
+		rows = table.displayedRows(columnDefs, omitted)
+	} else {
+		rows = table.renderedRows(columnDefs)
+	}
 
-	columnSizes := make([]int, len(table.columnDefs))
-	for i, columnDef := range table.columnDefs {
-		columnSize := strLengthWithEncoding(columnDef.name)
-		for _, row := range table.rows {
-			if strLengthWithEncoding(row[i]) > columnSize {
-				columnSize = strLengthWithEncoding(row[i])
-			}
+	var spilledWidths []int
+	spilledRowCount := table.spilledRowCount()
+	if spilledRowCount > 0 && table.fixedWidths == nil {
+		var err error
+		spilledWidths, err = table.measureSpilledRows(columnDefs)
+		if err != nil {
+			return err
+		}
+	}
+
+	renderer := table.newRowRenderer(
+		columnDefs,
+		table.columnSizes(columnDefs, rows, spilledWidths))
+	table.truncations = nil
+	if grower, ok := w.(growableWriter); ok {
+		grower.Grow(table.estimateSize(renderer, table.rowCount()))
+	}
+
+	if table.shouldPrintRowCount && table.rowCountPosition == RowCountAbove {
+		w.WriteString(table.rowCountLine(table.rowCount()))
+	}
+	err := table.renderTop(w, renderer)
+	if err != nil {
+		return err
+	}
+
+	// Write the content rows
+	justifications := columnJustifications(columnDefs)
+	if spilledRowCount > 0 {
+		err = table.renderSpilledRows(
+			ctx,
+			w,
+			renderer,
+			columnDefs,
+			justifications)
+		if err != nil {
+			return err
 		}
+	}
+	if omitted == 0 {
+		table.recordTruncations(
+			columnDefs,
+			rows,
+			spilledRowCount,
+			renderer)
+	}
+	if omitted > 0 {
+		err = table.renderOmittingRows(
+			ctx,
+			w,
+			renderer,
+			columnDefs,
+			rows,
+			omitted,
+			justifications)
+	} else if table.renderWorkers > 1 && len(rows) > renderChunkSize {
+		err = table.renderRowsParallel(
+			ctx,
+			w,
+			renderer,
+			rows,
+			spilledRowCount,
+			justifications)
+	} else {
+		err = table.renderRows(
+			ctx,
+			w,
+			renderer,
+			rows,
+			spilledRowCount,
+			justifications)
+	}
+	if err != nil {
+		return err
+	}
+
+	table.renderBottom(w, renderer, table.rowCount())
+	if len(table.truncations) > 0 && table.truncationNote != "" {
+		w.WriteString(table.truncationNote + "\n")
+	}
+	return nil
+}
 
-		if columnDef.maxWidth != nil && columnSize > *columnDef.maxWidth {
-			columnSizes[i] = *columnDef.maxWidth
+// renderRows writes the given rendered rows, the first of which is the row at
+// index offset in the table.
+func (table *Table) renderRows(
+	ctx context.Context,
+	w renderWriter,
+	renderer *rowRenderer,
+	rows [][]string,
+	offset int,
+	justifications []Alignment,
+) error {
+	for i, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		colors := renderer.dataColors
+		if override, ok := table.rowColorOverrides[offset+i]; ok {
+			colors = []color.Attribute{override}
+		} else if renderer.valueColors != nil {
+			colors = renderer.cellColors(row, colors)
+		}
+		err := renderer.renderRow(w, row, colors, justifications)
+		if err != nil {
+			return err
+		}
+		w.WriteString("\n")
+		if annotation, ok := table.rowAnnotations[offset+i]; ok {
+			renderer.renderAnnotation(
+				w,
+				Sanitize(annotation, table.sanitization))
+		}
+	}
+	return nil
+}
+
+// columnSizes returns the width of each column needed to fit the given
+// rendered rows, as well as any widths measured elsewhere, limited by the
+// maximum widths of the columns and table.
+func (table *Table) columnSizes(
+	columnDefs []ColumnDef,
+	rows [][]string,
+	otherWidths []int,
+) []int {
+	paddings := table.columnPaddings(columnDefs)
+	if table.fixedWidths != nil {
+		columnSizes := append([]int(nil), table.fixedWidths...)
+		for i := range paddings {
+			columnSizes[i] += 2 * paddings[i]
+		}
+		return columnSizes
+	}
+	valueWidths := table.measureRows()
+	columnSizes := make([]int, len(columnDefs))
+	for i, columnDef := range columnDefs {
+		columnSize := 0
+		if !table.hideColumnNames {
+			columnSize = strLengthWithEncoding(
+				table.displayedColumnName(columnDef))
+			unitSize := strLengthWithEncoding(columnDef.unit)
+			if table.unitPlacement == UnitBelowName &&
+				unitSize > columnSize {
+				columnSize = unitSize
+			}
+		}
+		if columnDef.isPlain() && table.values == nil &&
+			table.sanitization == SanitizeNone &&
+			!table.expandsHyperlinks() &&
+			len(rows) == len(table.rows) {
+			// The displayed values are the stored ones, which have already
+			// been measured.
+			if valueWidths[i] > columnSize {
+				columnSize = valueWidths[i]
+			}
 		} else {
-			columnSizes[i] = columnSize
+			for _, row := range rows {
+				if strLengthWithEncoding(row[i]) > columnSize {
+					columnSize = strLengthWithEncoding(row[i])
+				}
+			}
+		}
+		if otherWidths != nil && otherWidths[i] > columnSize {
+			columnSize = otherWidths[i]
+		}
+
+		if columnDef.maxWidth != nil && !table.wide &&
+			columnSize > *columnDef.maxWidth {
+			columnSize = *columnDef.maxWidth
+		}
+		columnSizes[i] = columnSize
+		if paddings != nil {
+			columnSizes[i] += 2 * paddings[i]
 		}
 	}
+	if table.maxWidth > 0 && !table.wide {
+		table.shrink(columnDefs, columnSizes)
+	}
+	return columnSizes
+}
 
-	var buffer bytes.Buffer
+func (table *Table) newRowRenderer(
+	columnDefs []ColumnDef,
+	columnSizes []int,
+) *rowRenderer {
+	styles := table.columnStyles(columnDefs)
+	return &rowRenderer{
+		columnSizes:  columnSizes,
+		border:       table.borderStyle(),
+		colorEnabled: table.colorEnabled,
+		colorDepth:   table.resolvedColorDepth(),
+		hyperlinks:   table.showsHyperlinks(),
+		dataColors:   styleColors(styles, rowColors, Style.valueColor),
+		headerColors: styleColors(styles, columnColors, Style.headerColor),
+		paddings:     table.columnPaddings(columnDefs),
+		overflows:    columnOverflows(styles),
+		valueColors:  columnValueColors(columnDefs),
+	}
+}
+
+// columnJustifications returns the alignment of the data cells in each
+// column.
+func columnJustifications(columnDefs []ColumnDef) []Alignment {
+	justifications := make([]Alignment, len(columnDefs))
+	for i, columnDef := range columnDefs {
+		justifications[i] = RightJustify
+		if columnDef.alignment != nil {
+			justifications[i] = *columnDef.alignment
+		}
+	}
+	return justifications
+}
+
+// renderTop writes everything above the data rows: the header, the upper
+// border and the column names.
+func (table *Table) renderTop(w renderWriter, renderer *rowRenderer) error {
+	style := renderer.border
+	columnSizes := renderer.columnSizes
 
 	var columnNames []string
 	for _, columnDef := range table.columnDefs {
-		columnNames = append(columnNames, columnDef.name)
+		columnNames = append(
+			columnNames,
+			table.displayedColumnName(columnDef))
 	}
 
 	// Write the header. Keep track of the length of the materialized header,
@@ -186,71 +721,211 @@ func (table *Table) PrettyString() (string, error) {
 	headerLength := 0
 	if table.header != nil {
 		var headerStr string
-		headerStr, headerLength = renderHeader(*table.header)
-		buffer.WriteString(headerStr)
+		headerStr, headerLength = renderHeader(*table.header, style)
+		w.WriteString(headerStr)
 	}
 
 	// Write and create table borders
-	headerLineStrings := make([]string, len(columnSizes))
-	for i := range columnSizes {
-		// Add 2 for the single space at beginning and end of cell
-		headerLineStrings[i] = strings.Repeat("-", columnSizes[i]+2)
-	}
-	border := "+" + strings.Join(headerLineStrings, "+") + "+"
+	upperBorder := style.line(
+		columnSizes,
+		style.TopLeft,
+		style.TopJunction,
+		style.TopRight)
+	border := style.line(
+		columnSizes,
+		style.MiddleLeft,
+		style.MiddleJunction,
+		style.MiddleRight) + "\n"
 
 	// Extend upper border if the header is longer than the width of table.
-	upperBorder := border
-	if headerLength > len(upperBorder) {
+	upperBorderLength := strLengthWithEncoding(upperBorder)
+	if headerLength > upperBorderLength {
 		upperBorder = upperBorder +
-			strings.Repeat("-", headerLength-len(upperBorder))
+			strings.Repeat(style.Horizontal, headerLength-upperBorderLength)
 	}
-	buffer.WriteString(upperBorder + "\n")
-	border += "\n"
+	w.WriteString(upperBorder + "\n")
 
 	// Write the column headers
-	err := renderRow(&buffer, columnSizes, columnNames, columnColors, leftJustify)
-	if err != nil {
-		return "", err
-	}
-	buffer.WriteString("\n")
-
-	// Write another border between columns and data rows.
-	buffer.WriteString(border)
-
-	// Write the content rows
-	for _, row := range table.rows {
-		err = renderRow(&buffer, columnSizes, row, rowColors, rightJustify)
+	if !table.hideColumnNames {
+		headerJustifications := make([]Alignment, len(columnNames))
+		for i := range headerJustifications {
+			headerJustifications[i] = LeftJustify
+		}
+		err := renderer.renderRow(
+			w,
+			columnNames,
+			renderer.headerColors,
+			headerJustifications)
 		if err != nil {
-			return "", err
+			return err
 		}
-		buffer.WriteString("\n")
+		w.WriteString("\n")
+		if units := table.unitLine(table.columnDefs); units != nil {
+			err := renderer.renderRow(
+				w,
+				units,
+				renderer.headerColors,
+				headerJustifications)
+			if err != nil {
+				return err
+			}
+			w.WriteString("\n")
+		}
+
+		// Write another border between columns and data rows.
+		w.WriteString(border)
 	}
+	return nil
+}
 
-	// Write the last border.
-	buffer.WriteString(border)
+// renderBottom writes everything below the data rows: the lower border and
+// the row count.
+func (table *Table) renderBottom(
+	w renderWriter,
+	renderer *rowRenderer,
+	rowCount int,
+) {
+	style := renderer.border
+	w.WriteString(style.line(
+		renderer.columnSizes,
+		style.BottomLeft,
+		style.BottomJunction,
+		style.BottomRight) + "\n")
 
 	// Write row count, if needed.
-	if table.shouldPrintRowCount {
-		buffer.WriteString(
-			fmt.Sprintf("Count: %d\n", len(table.rows)))
+	if table.shouldPrintRowCount && table.rowCountPosition == RowCountBelow {
+		w.WriteString(table.rowCountLine(rowCount))
 	}
-
-	// Pretty print!
-	return buffer.String(), nil
 }
 
 // Print prints the table to stdout.
 func (table *Table) Print() error {
-	strOutput, err := table.PrettyString()
-	if err != nil {
-		return err
+	return table.Fprint(os.Stdout)
+}
+
+// Fprint prints the table to w. If w is not a terminal, the table is written
+// in the format set by SetPipedFormat.
+func (table *Table) Fprint(w io.Writer) error {
+	if table.pipedFormat != FormatTable && !isTerminal(w) {
+		return table.WriteFormat(w, table.pipedFormat)
+	}
+	return table.fprintTable(w)
+}
+
+// SetPipedFormat sets the format used by Print and Fprint when the output is
+// not a terminal, such as when it is piped to another program or redirected
+// to a file. It defaults to FormatTable, which is used everywhere. Setting it
+// to FormatTSV matches the behavior of tools such as ls.
+func (table *Table) SetPipedFormat(format Format) {
+	table.pipedFormat = format
+}
+
+func (table *Table) fprintTable(w io.Writer) error {
+	if table.stacked && table.maxWidth == 0 {
+		if width, ok := terminalWidth(w); ok {
+			table.maxWidth = width
+			defer func() {
+				table.maxWidth = 0
+			}()
+		}
+	}
+
+	if colorsEnabled(table.colorEnabled) {
+		w = consoleWriter(w)
 	}
 
-	_, err = fmt.Fprintln(os.Stdout, strOutput)
+	// Render straight to w, so that large tables are never held in memory.
+	if err := table.RenderContext(context.Background(), w); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
 	return err
 }
 
-func (table *Table) validateRowSize(row []string) error {
+// ColumnNames returns the names of the columns of the table, in order.
+func (table *Table) ColumnNames() []string {
+	names := make([]string, len(table.columnDefs))
+	for i, columnDef := range table.columnDefs {
+		names[i] = columnDef.name
+	}
+	return names
+}
+
+// Rows returns the rows of the table as they are displayed, with derived
+// columns computed and formatters applied, but without truncation or colors.
+func (table *Table) Rows() ([][]string, error) {
+	if err := table.Err(); err != nil {
+		return nil, err
+	}
+	if err := table.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+	for _, row := range table.rows {
+		if err := table.validateStoredRow(row); err != nil {
+			return nil, err
+		}
+	}
+
+	renderedRows := table.renderedTable().rows
+	rows := make([][]string, len(renderedRows))
+	for i, row := range renderedRows {
+		rows[i] = append([]string(nil), row...)
+	}
+	return rows, nil
+}
+
+// columnIndex returns the index of the column with the given name.
+func (table *Table) columnIndex(name string) (int, error) {
+	for i, columnDef := range table.columnDefs {
+		if columnDef.name == name {
+			return i, nil
+		}
+	}
+	return -1, fmt.Errorf("column %s does not exist", name)
+}
+
+// measureRows updates the widths of the stored values with any rows added
+// since the last call, and returns them.
+func (table *Table) measureRows() []int {
+	if len(table.columnWidths) != len(table.columnDefs) ||
+		table.measuredRows > len(table.rows) {
+		table.resetColumnWidths()
+		table.columnWidths = make([]int, len(table.columnDefs))
+	}
+
+	for _, row := range table.rows[table.measuredRows:] {
+		for i, value := range row {
+			if width := strLengthWithEncoding(value); width > table.columnWidths[i] {
+				table.columnWidths[i] = width
+			}
+		}
+	}
+	table.measuredRows = len(table.rows)
+	return table.columnWidths
+}
+
+// resetColumnWidths discards the measured widths, which must be done whenever
+// rows are replaced or modified rather than added.
+func (table *Table) resetColumnWidths() {
+	table.columnWidths = nil
+	table.measuredRows = 0
+}
+
+// inputColumnCount returns the number of values expected by AddRow, which
+// excludes derived columns.
+func (table *Table) inputColumnCount() int {
+	count := 0
+	for _, columnDef := range table.columnDefs {
+		if columnDef.derive == nil {
+			count++
+		}
+	}
+	return count
+}
+
+// validateStoredRow checks a row as stored in the table, which holds a
+// placeholder for every derived column.
+func (table *Table) validateStoredRow(row []string) error {
 	if len(row) != len(table.columnDefs) {
 		return fmt.Errorf(
 			"row length %d must match columns %d",
@@ -260,103 +935,616 @@ func (table *Table) validateRowSize(row []string) error {
 	return nil
 }
 
-func renderRow(
-	buffer *bytes.Buffer,
-	columnSizes []int,
+// expandRow inserts empty placeholders for derived columns into a row of
+// input values, so that stored rows line up with the column definitions. The
+// row is always copied, since callers may reuse or modify their slice after
+// adding it, which would leave the measured widths stale.
+func (table *Table) expandRow(row []string) []string {
+	if len(row) == len(table.columnDefs) {
+		return append([]string(nil), row...)
+	}
+
+	expanded := make([]string, 0, len(table.columnDefs))
+	for _, columnDef := range table.columnDefs {
+		if columnDef.derive != nil {
+			expanded = append(expanded, "")
+			continue
+		}
+		expanded = append(expanded, row[0])
+		row = row[1:]
+	}
+	return expanded
+}
+
+// cellValue returns the value of the row at index in the given column as a
+// string, formatting raw values and computing derived columns.
+func (table *Table) cellValue(index int, column int) string {
+	return table.storedCellValue(
+		table.rows[index],
+		table.rowValues(index),
+		column)
+}
+
+// storedCellValue is like cellValue for a row given by its stored strings and
+// raw values, which are nil if it was added as strings.
+func (table *Table) storedCellValue(
+	row []string,
+	values []interface{},
+	column int,
+) string {
+	derive := table.columnDefs[column].derive
+	if derive == nil {
+		if values != nil {
+			return Sanitize(formatValue(values[column]), table.sanitization)
+		}
+		return Sanitize(row[column], table.sanitization)
+	}
+
+	namedValues := make(map[string]string, len(table.columnDefs))
+	for i, columnDef := range table.columnDefs {
+		if columnDef.derive == nil {
+			namedValues[columnDef.name] = table.storedCellValue(row, values, i)
+		}
+	}
+	return derive(namedValues)
+}
+
+// rowValues returns the raw values of the row at index, or nil if it was
+// added as strings.
+func (table *Table) rowValues(index int) []interface{} {
+	if index < len(table.values) {
+		return table.values[index]
+	}
+	return nil
+}
+
+// resolvedColumnDefs returns the column definitions used for rendering, with
+// settings inferred from the data filled in.
+func (table *Table) resolvedColumnDefs() []ColumnDef {
+	styledColumnDefs := table.zonedColumnDefs(table.styledColumnDefs())
+	if !table.sniffTypes {
+		return styledColumnDefs
+	}
+
+	columnDefs := make([]ColumnDef, len(styledColumnDefs))
+	for i, columnDef := range styledColumnDefs {
+		if columnDef.alignment == nil || columnDef.formatter == nil {
+			values := make([]string, len(table.rows))
+			for j := range table.rows {
+				values[j] = table.cellValue(j, i)
+			}
+			columnDef = SniffColumnType(values).applyTo(columnDef)
+		}
+		columnDefs[i] = columnDef
+	}
+	return columnDefs
+}
+
+// renderedRows returns the rows of the table as they are displayed, with
+// derived columns computed and formatters applied.
+func (table *Table) renderedRows(columnDefs []ColumnDef) [][]string {
+	isPlain := true
+	for _, columnDef := range columnDefs {
+		isPlain = isPlain && columnDef.isPlain()
+	}
+	if isPlain && table.values == nil &&
+		table.sanitization == SanitizeNone && !table.expandsHyperlinks() {
+		return table.rows
+	}
+
+	rows := make([][]string, len(table.rows))
+	for i := range table.rows {
+		rows[i] = table.renderedRow(columnDefs, i)
+	}
+	return rows
+}
+
+// renderedRow returns the row at index as it is displayed. Formatters are
+// given the raw value of the cell if the row was added with AddValues.
+func (table *Table) renderedRow(columnDefs []ColumnDef, index int) []string {
+	return table.renderStoredRow(
+		columnDefs,
+		table.rows[index],
+		table.rowValues(index))
+}
+
+// renderStoredRow is like renderedRow for a row given by its stored strings
+// and raw values.
+func (table *Table) renderStoredRow(
+	columnDefs []ColumnDef,
+	row []string,
+	values []interface{},
+) []string {
+	rendered := make([]string, len(columnDefs))
+	for j, columnDef := range columnDefs {
+		if columnDef.formatter == nil && columnDef.timeZone == nil {
+			rendered[j] = table.storedCellValue(row, values, j)
+		} else {
+			rendered[j] = table.formatStoredCell(
+				columnDef,
+				row,
+				values,
+				j)
+		}
+		if length := columnDef.shortIDLength; length > 0 && !table.wide {
+			rendered[j] = shortenID(rendered[j], length)
+		}
+		if columnDef.statusSymbols {
+			rendered[j] = statusLabel(
+				rendered[j],
+				isASCII(table.borderStyle().Vertical))
+		}
+		if rendered[j] != "" {
+			rendered[j] = columnDef.prefix + rendered[j] +
+				columnDef.suffix
+		}
+	}
+	if table.expandsHyperlinks() {
+		for j, cell := range rendered {
+			rendered[j] = replaceHyperlinks(
+				cell,
+				HyperlinkShowTextAndURL)
+		}
+	}
+	return rendered
+}
+
+// formatStoredCell formats the cell at column of a stored row with the
+// formatter and time zone of its column. Formatters are given the raw value
+// of the cell if there is one.
+func (table *Table) formatStoredCell(
+	columnDef ColumnDef,
+	row []string,
+	values []interface{},
+	column int,
+) string {
+	var value interface{}
+	if values != nil && columnDef.derive == nil {
+		value = values[column]
+		if str, ok := value.(string); ok {
+			value = Sanitize(str, table.sanitization)
+		}
+	} else {
+		value = table.storedCellValue(row, values, column)
+	}
+	value = columnDef.inTimeZone(value)
+	if columnDef.formatter != nil {
+		return columnDef.formatter(value)
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+	return table.storedCellValue(row, values, column)
+}
+
+// estimateSize returns the approximate number of bytes in the rendered table,
+// assuming single-byte values.
+func (table *Table) estimateSize(renderer *rowRenderer, rowCount int) int {
+	lineLength := 1 + len(renderer.border.Vertical)
+	for _, columnSize := range renderer.columnSizes {
+		lineLength += columnSize + 2 + len(renderer.border.Vertical)
+	}
+	borderLength := 1 + 2*len(renderer.border.TopLeft)
+	for _, columnSize := range renderer.columnSizes {
+		borderLength += (columnSize+2)*len(renderer.border.Horizontal) +
+			len(renderer.border.TopJunction)
+	}
+	if renderer.useColor() {
+		// Each cell is wrapped in escape sequences such as "\x1b[31;1m" and
+		// "\x1b[0m".
+		lineLength += 11 * len(renderer.columnSizes)
+	}
+
+	size := (rowCount+1)*lineLength + 3*borderLength
+	if table.header != nil {
+		size += 2*len(*table.header) + 8 + borderLength
+	}
+	if table.shouldPrintRowCount {
+		size += 32
+	}
+	return size
+}
+
+// rowRenderer holds the settings shared by all rows of a rendered table.
+type rowRenderer struct {
+	columnSizes  []int
+	border       BorderStyle
+	colorEnabled *bool
+	colorDepth   ColorDepth
+	hyperlinks   bool
+	dataColors   []color.Attribute
+	headerColors []color.Attribute
+	// paddings holds the spaces on each side of the values of each column
+	// beyond the first, or is nil if there are none.
+	paddings []int
+	// overflows holds the overflow of each column, or is nil if all values
+	// are truncated.
+	overflows []Overflow
+	// valueColors holds the functions coloring the values of each column,
+	// or is nil if no column has one.
+	valueColors []func(value string) color.Attribute
+
+	colorPrefixes map[color.Attribute]string
+	spaces        string
+}
+
+// clone returns a copy of the renderer with its own caches, for use by
+// another goroutine.
+func (renderer *rowRenderer) clone() *rowRenderer {
+	return &rowRenderer{
+		columnSizes:  renderer.columnSizes,
+		border:       renderer.border,
+		colorEnabled: renderer.colorEnabled,
+		colorDepth:   renderer.colorDepth,
+		hyperlinks:   renderer.hyperlinks,
+		dataColors:   renderer.dataColors,
+		headerColors: renderer.headerColors,
+		paddings:     renderer.paddings,
+		overflows:    renderer.overflows,
+		valueColors:  renderer.valueColors,
+	}
+}
+
+func (renderer *rowRenderer) useColor() bool {
+	return colorsEnabled(renderer.colorEnabled)
+}
+
+// contentWidth returns the width of the values of the column at index, within
+// its padding.
+func (renderer *rowRenderer) contentWidth(index int) int {
+	if renderer.paddings == nil {
+		return renderer.columnSizes[index]
+	}
+	return renderer.columnSizes[index] - 2*renderer.paddings[index]
+}
+
+// overflow returns the overflow of the column at index.
+func (renderer *rowRenderer) overflow(index int) Overflow {
+	if renderer.overflows == nil {
+		return OverflowTruncate
+	}
+	return renderer.overflows[index]
+}
+
+// renderRow writes a row, spanning several lines if values in wrapping
+// columns are too wide for them.
+func (renderer *rowRenderer) renderRow(
+	w renderWriter,
 	contents []string,
 	colors []color.Attribute,
-	justification alignment,
+	justifications []Alignment,
 ) error {
-	contentStrings := make([]string, len(contents))
+	lines := renderer.wrapRow(contents)
+	if lines == nil {
+		return renderer.renderLine(w, contents, colors, justifications)
+	}
+	for i, line := range lines {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		err := renderer.renderLine(w, line, colors, justifications)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// wrapRow returns the lines of a row whose values are wrapped to fit their
+// columns, or nil if the row fits on a single line.
+func (renderer *rowRenderer) wrapRow(contents []string) [][]string {
+	if renderer.overflows == nil {
+		return nil
+	}
+	var wrapped [][]string
+	lineCount := 1
+	for i, content := range contents {
+		if !renderer.overflows[i].wraps() {
+			continue
+		}
+		width := renderer.contentWidth(i)
+		if !strings.Contains(content, "\n") && (len(content) <= width ||
+			strLengthWithEncoding(content) <= width) {
+			continue
+		}
+		if wrapped == nil {
+			wrapped = make([][]string, len(contents))
+		}
+		wrapped[i] = wrap(
+			content,
+			width,
+			renderer.overflows[i] == OverflowHyphenate)
+		if len(wrapped[i]) > lineCount {
+			lineCount = len(wrapped[i])
+		}
+	}
+	if wrapped == nil {
+		return nil
+	}
+
+	lines := make([][]string, lineCount)
+	for i := range lines {
+		lines[i] = make([]string, len(contents))
+	}
+	for column, content := range contents {
+		if wrapped[column] == nil {
+			lines[0][column] = content
+			continue
+		}
+		for i, line := range wrapped[column] {
+			lines[i][column] = line
+		}
+	}
+	return lines
+}
+
+// renderLine writes a single line of a row.
+func (renderer *rowRenderer) renderLine(
+	w renderWriter,
+	contents []string,
+	colors []color.Attribute,
+	justifications []Alignment,
+) error {
+	vertical := renderer.border.Vertical
+	w.WriteString(vertical)
 	for i := range contents {
-		cell, err := renderCell(
-			contents[i],
-			columnSizes[i],
-			justification,
+		padding := 1
+		if renderer.paddings != nil {
+			padding += renderer.paddings[i]
+		}
+		content := contents[i]
+		width := renderer.contentWidth(i)
+		if renderer.overflows != nil && len(content) > width {
+			content = fitOverflow(content, width, renderer.overflows[i])
+		}
+		err := renderer.renderCell(
+			w,
+			content,
+			width,
+			padding,
+			justifications[i],
 			colors[i%len(colors)])
 		if err != nil {
 			return err
 		}
-		contentStrings[i] = cell
+		if _, err := w.WriteString(vertical); err != nil {
+			return err
+		}
 	}
-	_, err := buffer.WriteString(
-		"|" + strings.Join(contentStrings, "|") + "|")
-	return err
+	return nil
 }
 
-func renderCell(
+// colorReset is the escape sequence ending a colored cell.
+const colorReset = "\x1b[0m"
+
+// colorPrefix returns the escape sequence starting a cell of the given color,
+// or an empty string if colors are disabled. The sequences match those of
+// color.New(attribute, color.Bold), and are computed once per attribute.
+func (renderer *rowRenderer) colorPrefix(attribute color.Attribute) string {
+	if !renderer.useColor() {
+		return ""
+	}
+	prefix, ok := renderer.colorPrefixes[attribute]
+	if !ok {
+		prefix = fmt.Sprintf("\x1b[%d;%dm", attribute, color.Bold)
+		if renderer.colorPrefixes == nil {
+			renderer.colorPrefixes = make(map[color.Attribute]string)
+		}
+		renderer.colorPrefixes[attribute] = prefix
+	}
+	return prefix
+}
+
+// padding returns a string of length spaces, sliced from a shared string to
+// avoid allocating for every cell.
+func (renderer *rowRenderer) padding(length int) string {
+	if length > len(renderer.spaces) {
+		renderer.spaces = strings.Repeat(" ", length)
+	}
+	return renderer.spaces[:length]
+}
+
+func (renderer *rowRenderer) renderCell(
+	w renderWriter,
 	content string,
 	cellLength int,
-	justification alignment,
+	padding int,
+	justification Alignment,
 	textAttribute color.Attribute,
-) (string, error) {
+) error {
+	if renderer.colorDepth < ColorDepthTrueColor && renderer.useColor() {
+		content = DowngradeColors(content, renderer.colorDepth)
+	}
+	if !renderer.hyperlinks {
+		content = replaceHyperlinks(content, HyperlinkShowText)
+	}
 	truncatedContent := content
-	if strLengthWithEncoding(content) > cellLength {
-		truncatedContent = fmt.Sprintf(
-			"%s...",
-			truncateStringWithEncoding(content, cellLength-3))
+	contentLength := strLengthWithEncoding(content)
+	if contentLength > cellLength {
+		truncatedContent = Truncate(content, cellLength)
+		contentLength = strLengthWithEncoding(truncatedContent)
 	}
 
-	paddingLength := cellLength - strLengthWithEncoding(truncatedContent)
-	padding := strings.Repeat(" ", paddingLength)
-
-	textColor := color.New(textAttribute, color.Bold)
+	paddingLength := cellLength - contentLength
+	var leftPadding, rightPadding string
 	switch justification {
-	case leftJustify:
-		return textColor.Sprintf(" %s%s ", truncatedContent, padding), nil
-	case rightJustify:
-		return textColor.Sprintf(" %s%s ", padding, truncatedContent),
-			nil
+	case LeftJustify:
+		rightPadding = renderer.padding(paddingLength)
+	case RightJustify:
+		leftPadding = renderer.padding(paddingLength)
+	case CenterJustify:
+		leftPadding = renderer.padding(paddingLength / 2)
+		rightPadding = renderer.padding(paddingLength - paddingLength/2)
 	default:
-		return "", fmt.Errorf("did not match alignment")
+		return fmt.Errorf("did not match alignment")
+	}
+
+	prefix := renderer.colorPrefix(textAttribute)
+	w.WriteString(prefix)
+	w.WriteString(renderer.padding(padding))
+	w.WriteString(leftPadding)
+	w.WriteString(truncatedContent)
+	w.WriteString(rightPadding)
+	w.WriteString(renderer.padding(padding))
+	if prefix != "" {
+		w.WriteString(colorReset)
 	}
+	return nil
 }
 
 // renderHeader renders the header, as well as returns its horizontal length.
-func renderHeader(header string) (string, int) {
-	horizontalBorder := strings.Repeat("-", strLengthWithEncoding(header)+2)
+func renderHeader(header string, style BorderStyle) (string, int) {
+	horizontalBorder := strings.Repeat(
+		style.Horizontal,
+		strLengthWithEncoding(header)+2)
 	rendered := fmt.Sprintf(
-		"%s\n %s |\n",
+		"%s\n %s %s\n",
 		horizontalBorder,
-		header)
+		header,
+		style.Vertical)
 
 	return rendered, strLengthWithEncoding(horizontalBorder)
 }
 
+// minShrinkWidth is the narrowest a column is shrunk to fit a maximum table
+// width, leaving room for at least one character and an ellipsis.
+const minShrinkWidth = 4
+
+// shrinkColumns narrows the widest columns, one character at a time, until
+// the table fits in maxWidth or no column can be shrunk further. Columns are
+// not shrunk below their floors.
+func shrinkColumns(columnSizes []int, maxWidth int, floors []int) {
+	// Each column is padded by a space on each side and followed by a
+	// border, plus the border at the start of the row.
+	width := 1
+	for _, columnSize := range columnSizes {
+		width += columnSize + 3
+	}
+
+	for ; width > maxWidth; width-- {
+		widest := -1
+		for i, columnSize := range columnSizes {
+			if columnSize <= floors[i] {
+				continue
+			}
+			if widest == -1 || columnSize > columnSizes[widest] {
+				widest = i
+			}
+		}
+		if widest == -1 {
+			return
+		}
+		columnSizes[widest]--
+	}
+}
+
+// strLengthWithEncoding returns the number of columns str takes in a
+// terminal. Combining marks and escape sequences take none, and wide
+// characters take two.
 func strLengthWithEncoding(str string) int {
+	if isASCII(str) {
+		return len(str)
+	}
+
 	length := 0
-	for _, strRune := range str {
-		if shouldCountEncodedRune(strRune) {
-			length++
+	for i := 0; i < len(str); {
+		if n := escapeSequenceLength(str[i:]); n > 0 {
+			i += n
+			continue
 		}
+		strRune, size := utf8.DecodeRuneInString(str[i:])
+		length += runeWidth(strRune)
+		i += size
 	}
 	return length
 }
 
+// truncateStringWithEncoding returns the longest prefix of str that is at
+// most truncateLength columns wide, or str if truncateLength is negative.
+// Colors left open by the escape sequences of a truncated string are reset.
 func truncateStringWithEncoding(str string, truncateLength int) string {
 	if truncateLength == 0 {
 		return ""
 	}
+	if isASCII(str) {
+		if truncateLength > 0 && truncateLength < len(str) {
+			return str[:truncateLength]
+		}
+		return str
+	}
+
+	head := truncateWidth(str, truncateLength)
+	if len(head) < len(str) && strings.Contains(head, "\x1b[") {
+		head += colorReset
+	}
+	return head
+}
 
-	// Find the index at which we must truncate the string. Only truncate when
-	// we absolutely must, i.e. when a counted rune puts us over the
-	// truncateLength.
-	strTruncateIndex := 0
-	runeCount := 0
-	for _, strRune := range str {
-		if shouldCountEncodedRune(strRune) {
-			if runeCount == truncateLength {
-				break
+// truncateWidth returns the longest prefix of str that is at most width
+// columns wide, or str if width is negative. Only truncate when we
+// absolutely must, i.e. when a counted rune puts us over the width, so that
+// marks following the last rune are kept.
+func truncateWidth(str string, width int) string {
+	if width < 0 {
+		return str
+	}
+	length := 0
+	for i := 0; i < len(str); {
+		if n := escapeSequenceLength(str[i:]); n > 0 {
+			i += n
+			continue
+		}
+		strRune, size := utf8.DecodeRuneInString(str[i:])
+		if runeLength := runeWidth(strRune); runeLength > 0 {
+			if length+runeLength > width {
+				return str[:i]
 			}
-			runeCount++
+			length += runeLength
 		}
-		strTruncateIndex++
+		i += size
 	}
+	return str
+}
 
-	return string([]rune(str)[:strTruncateIndex])
+// isASCII reports whether str contains only ASCII characters, each of which
+// is one column wide. Strings with escape sequences are not.
+func isASCII(str string) bool {
+	for i := 0; i < len(str); i++ {
+		if str[i] >= utf8.RuneSelf || str[i] == '\x1b' {
+			return false
+		}
+	}
+	return true
 }
 
 func shouldCountEncodedRune(r rune) bool {
 	// DO NOT count non-spacing marks in the output!
+	if r < utf8.RuneSelf {
+		return true
+	}
+	if r < maxMarkTableRune {
+		markTableOnce.Do(buildMarkTable)
+		return markTable[r/64]&(1<<uint(r%64)) == 0
+	}
 	return !unicode.IsMark(r)
 }
+
+// maxMarkTableRune bounds the runes whose mark status is memoized in
+// markTable, which covers the Basic Multilingual Plane in 8KB.
+const maxMarkTableRune = 0x10000
+
+var (
+	markTableOnce sync.Once
+	markTable     [maxMarkTableRune / 64]uint64
+)
+
+// buildMarkTable sets the bit of every mark in markTable, so that looking up
+// a rune avoids searching the ranges of unicode.Mark.
+func buildMarkTable() {
+	for _, markRange := range unicode.Mark.R16 {
+		stride := uint32(markRange.Stride)
+		for r := uint32(markRange.Lo); r <= uint32(markRange.Hi); r += stride {
+			markTable[r/64] |= 1 << (r % 64)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package prettytest
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rubrikinc/pretty"
+)
+
+// update makes AssertGolden write golden files instead of comparing with
+// them. A test package using prettytest must not define its own -update
+// flag.
+var update = flag.Bool("update", false, "update golden files")
+
+// AssertGolden renders table and reports an error unless the output matches
+// the golden file at path, such as "testdata/report.txt", and returns
+// whether it does. The error shows the lines that differ as a unified diff.
+// When the tests are run with -update, the file is written instead:
+//
+//	go test ./... -update
+//
+// So that golden files do not depend on the environment the tests run in,
+// the table is rendered with Table.TestString.
+func AssertGolden(t testing.TB, table *pretty.Table, path string) bool {
+	t.Helper()
+	got, err := table.TestString()
+	if err != nil {
+		t.Errorf("cannot render table: %v", err)
+		return false
+	}
+
+	if *update {
+		err := os.MkdirAll(filepath.Dir(path), 0755)
+		if err == nil {
+			err = ioutil.WriteFile(path, []byte(got), 0644)
+		}
+		if err != nil {
+			t.Errorf("cannot update golden file: %v", err)
+			return false
+		}
+		return true
+	}
+
+	want, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Errorf(
+			"cannot read golden file, run with -update to create it: %v",
+			err)
+		return false
+	}
+	if string(want) == got {
+		return true
+	}
+	printer := pretty.NewDiffPrinter()
+	printer.SetColor(false)
+	t.Errorf(
+		"table does not match %s, run with -update to accept it:\n%s",
+		path,
+		printer.Format(string(want), got))
+	return false
+}
@@ -0,0 +1,48 @@
+package prettytest
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/pretty"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "vms.txt")
+	table := newTable(
+		t,
+		[]string{"Name", "Status"},
+		[]string{"vm-1", "Running"})
+	table.SetBorderStyle(pretty.BorderLight)
+
+	r := &recorder{TB: t}
+	assert.True(t, !AssertGolden(r, table, path))
+	assert.EqualInt(t, 1, len(r.errors))
+	assert.True(t, strings.HasPrefix(r.errors[0], "cannot read golden file"))
+
+	*update = true
+	assert.True(t, AssertGolden(r, table, path))
+	*update = false
+	golden, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+---------+\n"+
+			"| Name | Status  |\n"+
+			"+------+---------+\n"+
+			"| vm-1 | Running |\n"+
+			"+------+---------+\n",
+		string(golden))
+
+	r.errors = nil
+	assert.True(t, AssertGolden(r, table, path))
+	assert.Nil(t, table.AddRow("vm-2", "Stopped"))
+	assert.True(t, !AssertGolden(r, table, path))
+	assert.EqualInt(t, 1, len(r.errors))
+	assert.True(t, strings.HasSuffix(
+		r.errors[0],
+		" | vm-1 | Running |\n+| vm-2 | Stopped |\n +------+---------+\n"))
+}
@@ -0,0 +1,101 @@
+// Package prettytest helps test code that builds pretty tables, with
+// failures that point at the cells that differ rather than at two large
+// rendered strings.
+package prettytest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/pretty"
+)
+
+// AssertTablesEqual reports an error if got does not have the same columns
+// and rows as want, comparing cells as they are displayed, and returns
+// whether they are equal. The error lists each cell that differs, and any
+// missing or unexpected rows:
+//
+//	tables differ:
+//	  row 2, column "Status": want "Running", got "Stopped"
+//	  row 4: missing [vm-4 Running]
+func AssertTablesEqual(
+	t testing.TB,
+	want *pretty.Table,
+	got *pretty.Table,
+) bool {
+	t.Helper()
+	differences, err := tableDifferences(want, got)
+	if err != nil {
+		t.Errorf("cannot compare tables: %v", err)
+		return false
+	}
+	if len(differences) == 0 {
+		return true
+	}
+	t.Errorf("tables differ:\n  %s", strings.Join(differences, "\n  "))
+	return false
+}
+
+// tableDifferences describes the differences between two tables, one per
+// line.
+func tableDifferences(
+	want *pretty.Table,
+	got *pretty.Table,
+) ([]string, error) {
+	wantColumns := want.ColumnNames()
+	gotColumns := got.ColumnNames()
+	if !equalStrings(wantColumns, gotColumns) {
+		return []string{
+			fmt.Sprintf("columns: want %q, got %q", wantColumns, gotColumns),
+		}, nil
+	}
+
+	wantRows, err := want.Rows()
+	if err != nil {
+		return nil, err
+	}
+	gotRows, err := got.Rows()
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []string
+	for i := 0; i < len(wantRows) || i < len(gotRows); i++ {
+		// Rows are numbered from 1, as they are displayed.
+		switch {
+		case i >= len(gotRows):
+			differences = append(
+				differences,
+				fmt.Sprintf("row %d: missing %v", i+1, wantRows[i]))
+		case i >= len(wantRows):
+			differences = append(
+				differences,
+				fmt.Sprintf("row %d: unexpected %v", i+1, gotRows[i]))
+		default:
+			for j, column := range wantColumns {
+				if wantRows[i][j] != gotRows[i][j] {
+					differences = append(differences, fmt.Sprintf(
+						"row %d, column %q: want %q, got %q",
+						i+1,
+						column,
+						wantRows[i][j],
+						gotRows[i][j]))
+				}
+			}
+		}
+	}
+	return differences, nil
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,79 @@
+package prettytest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/rubrikinc/pretty"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+// recorder records the errors reported to it instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func newTable(
+	t *testing.T,
+	columns []string,
+	rows ...[]string,
+) *pretty.Table {
+	columnDefs := make([]pretty.ColumnDef, len(columns))
+	for i, column := range columns {
+		columnDefs[i] = pretty.NewColumnDef(column)
+	}
+	table, err := pretty.NewPrettyTable(columnDefs...)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows(rows))
+	return table
+}
+
+func TestAssertTablesEqual(t *testing.T) {
+	columns := []string{"Name", "Status"}
+	want := newTable(
+		t,
+		columns,
+		[]string{"vm-1", "Running"},
+		[]string{"vm-2", "Running"},
+		[]string{"vm-3", "Stopped"})
+
+	r := &recorder{TB: t}
+	assert.True(t, AssertTablesEqual(r, want, newTable(
+		t,
+		columns,
+		[]string{"vm-1", "Running"},
+		[]string{"vm-2", "Running"},
+		[]string{"vm-3", "Stopped"})))
+	assert.EqualInt(t, 0, len(r.errors))
+
+	assert.True(t, !AssertTablesEqual(r, want, newTable(
+		t,
+		columns,
+		[]string{"vm-1", "Running"},
+		[]string{"vm-2", "Stopped"})))
+	assert.DeepEqual(
+		t,
+		[]string{
+			"tables differ:\n" +
+				"  row 2, column \"Status\": want \"Running\", got \"Stopped\"\n" +
+				"  row 3: missing [vm-3 Stopped]",
+		},
+		r.errors)
+
+	r.errors = nil
+	assert.True(t, !AssertTablesEqual(r, want, newTable(t, []string{"Name"})))
+	assert.DeepEqual(
+		t,
+		[]string{
+			"tables differ:\n" +
+				"  columns: want [\"Name\" \"Status\"], got [\"Name\"]",
+		},
+		r.errors)
+}
@@ -0,0 +1,230 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// ProgressTheme is the set of characters used to draw a progress bar.
+type ProgressTheme struct {
+	Filled string
+	Empty  string
+	Left   string
+	Right  string
+	// Attributes color the filled part of the bar.
+	Attributes []color.Attribute
+}
+
+var (
+	// ProgressASCII draws progress bars with plain ASCII characters. It is
+	// the default.
+	ProgressASCII = ProgressTheme{
+		Filled: "#",
+		Empty:  "-",
+		Left:   "[",
+		Right:  "]",
+	}
+
+	// ProgressBlocks draws progress bars with block characters.
+	ProgressBlocks = ProgressTheme{
+		Filled:     "█",
+		Empty:      "░",
+		Attributes: []color.Attribute{color.FgGreen},
+	}
+)
+
+// defaultProgressWidth is the default width of the bar itself, excluding the
+// label and statistics.
+const defaultProgressWidth = 40
+
+// ProgressBar shows the progress of a long operation on a single line, which
+// is redrawn in place as progress is made:
+//
+//	Uploading [################------------------------]  40%  12.5/s  ETA 4s
+//
+// When not writing to a terminal, only the final line is written, by Finish.
+// A ProgressBar is safe for concurrent use.
+type ProgressBar struct {
+	mu sync.Mutex
+
+	w            io.Writer
+	isTerminal   bool
+	total        int64
+	current      int64
+	label        string
+	width        int
+	theme        ProgressTheme
+	colorEnabled *bool
+	start        time.Time
+	now          func() time.Time
+	finished     bool
+	// manager draws the bar instead, if it is one of several.
+	manager *MultiProgress
+}
+
+// NewProgressBar creates a ProgressBar writing to w, which is complete once
+// total units of work are done. The rate and ETA are measured from now.
+func NewProgressBar(w io.Writer, total int64) *ProgressBar {
+	return &ProgressBar{
+		w:          w,
+		isTerminal: isTerminal(w),
+		total:      total,
+		width:      defaultProgressWidth,
+		theme:      ProgressASCII,
+		start:      time.Now(),
+		now:        time.Now,
+	}
+}
+
+// SetLabel sets the text shown before the bar.
+func (bar *ProgressBar) SetLabel(label string) {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	bar.label = label
+}
+
+// SetWidth sets the width of the bar itself, excluding the label and
+// statistics. Negative widths are treated as 0.
+func (bar *ProgressBar) SetWidth(width int) {
+	if width < 0 {
+		width = 0
+	}
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	bar.width = width
+}
+
+// SetTheme sets the characters used to draw the bar.
+func (bar *ProgressBar) SetTheme(theme ProgressTheme) {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	bar.theme = theme
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (bar *ProgressBar) SetColor(enabled bool) {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	bar.colorEnabled = &enabled
+}
+
+// Add records n more units of work as done, and redraws the bar.
+func (bar *ProgressBar) Add(n int64) error {
+	return bar.update(func() {
+		bar.current += n
+	})
+}
+
+// Set records current units of work as done, and redraws the bar.
+func (bar *ProgressBar) Set(current int64) error {
+	return bar.update(func() {
+		bar.current = current
+	})
+}
+
+// Finish draws the bar a final time and ends its line. Later updates are
+// ignored.
+func (bar *ProgressBar) Finish() error {
+	bar.mu.Lock()
+	if bar.finished {
+		bar.mu.Unlock()
+		return nil
+	}
+	bar.finished = true
+	if manager := bar.manager; manager != nil {
+		bar.mu.Unlock()
+		return manager.redraw()
+	}
+	defer bar.mu.Unlock()
+
+	prefix := ""
+	if bar.isTerminal {
+		prefix = "\r"
+	}
+	_, err := io.WriteString(consoleWriter(bar.w), prefix+bar.line()+"\n")
+	return err
+}
+
+// String returns the current line of the bar.
+func (bar *ProgressBar) String() string {
+	bar.mu.Lock()
+	defer bar.mu.Unlock()
+	return bar.line()
+}
+
+// update changes the progress and redraws the bar in place, if writing to a
+// terminal.
+func (bar *ProgressBar) update(change func()) error {
+	bar.mu.Lock()
+	if bar.finished {
+		bar.mu.Unlock()
+		return nil
+	}
+	change()
+	if manager := bar.manager; manager != nil {
+		// The manager locks every bar to draw them.
+		bar.mu.Unlock()
+		return manager.redraw()
+	}
+	defer bar.mu.Unlock()
+
+	if !bar.isTerminal {
+		return nil
+	}
+	// Return to the start of the line, and clear what is left of the
+	// previous line after drawing.
+	_, err := io.WriteString(consoleWriter(bar.w), "\r"+bar.line()+"\x1b[K")
+	return err
+}
+
+// line renders the bar and its statistics.
+func (bar *ProgressBar) line() string {
+	fraction := 1.0
+	if bar.total > 0 {
+		fraction = float64(bar.current) / float64(bar.total)
+	}
+	if fraction < 0 {
+		fraction = 0
+	} else if fraction > 1 {
+		fraction = 1
+	}
+
+	var builder strings.Builder
+	if bar.label != "" {
+		builder.WriteString(bar.label + " ")
+	}
+	builder.WriteString(bar.theme.Left)
+	filled := int(fraction * float64(bar.width))
+	builder.WriteString(colorize(
+		strings.Repeat(bar.theme.Filled, filled),
+		colorsEnabled(bar.colorEnabled),
+		bar.theme.Attributes...))
+	builder.WriteString(strings.Repeat(bar.theme.Empty, bar.width-filled))
+	builder.WriteString(bar.theme.Right)
+	fmt.Fprintf(&builder, " %3d%%", int(fraction*100))
+
+	elapsed := bar.now().Sub(bar.start)
+	if elapsed <= 0 || bar.current <= 0 {
+		builder.WriteString("  --/s  ETA --")
+		return builder.String()
+	}
+	rate := float64(bar.current) / elapsed.Seconds()
+	remaining := time.Duration(float64(bar.total-bar.current) / rate *
+		float64(time.Second))
+	if remaining < 0 {
+		remaining = 0
+	}
+	fmt.Fprintf(
+		&builder,
+		"  %s/s  ETA %v",
+		formatNumeric(math.Round(rate*10)/10),
+		remaining.Round(time.Second))
+	return builder.String()
+}
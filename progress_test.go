@@ -0,0 +1,76 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+// newTestProgressBar creates a ProgressBar whose clock only moves when the
+// returned time is changed.
+func newTestProgressBar(
+	w *bytes.Buffer,
+	total int64,
+) (*ProgressBar, *time.Time) {
+	bar := NewProgressBar(w, total)
+	now := bar.start
+	bar.now = func() time.Time { return now }
+	return bar, &now
+}
+
+func TestProgressBar(t *testing.T) {
+	var buffer bytes.Buffer
+	bar, now := newTestProgressBar(&buffer, 200)
+	bar.SetLabel("Uploading")
+	bar.SetWidth(10)
+	assert.EqualString(
+		t,
+		"Uploading [----------]   0%  --/s  ETA --",
+		bar.String())
+
+	*now = now.Add(4 * time.Second)
+	assert.Nil(t, bar.Add(50))
+	assert.EqualString(
+		t,
+		"Uploading [##--------]  25%  12.5/s  ETA 12s",
+		bar.String())
+
+	// Nothing is drawn in place when not writing to a terminal.
+	assert.EqualString(t, "", buffer.String())
+	assert.Nil(t, bar.Set(200))
+	assert.Nil(t, bar.Finish())
+	assert.Nil(t, bar.Add(10))
+	assert.EqualString(
+		t,
+		"Uploading [##########] 100%  50/s  ETA 0s\n",
+		buffer.String())
+}
+
+func TestProgressBarNegativeWidth(t *testing.T) {
+	var buffer bytes.Buffer
+	bar, _ := newTestProgressBar(&buffer, 10)
+	bar.SetWidth(-5)
+	assert.EqualString(t, "[]   0%  --/s  ETA --", bar.String())
+}
+
+func TestProgressBarInPlace(t *testing.T) {
+	var buffer bytes.Buffer
+	bar, now := newTestProgressBar(&buffer, 4)
+	bar.isTerminal = true
+	bar.SetWidth(4)
+	bar.SetTheme(ProgressBlocks)
+	bar.SetColor(false)
+
+	*now = now.Add(time.Second)
+	assert.Nil(t, bar.Add(1))
+	assert.Nil(t, bar.Add(1))
+	assert.Nil(t, bar.Finish())
+	assert.EqualString(
+		t,
+		"\r█░░░  25%  1/s  ETA 3s\x1b[K"+
+			"\r██░░  50%  2/s  ETA 1s\x1b[K"+
+			"\r██░░  50%  2/s  ETA 1s\n",
+		buffer.String())
+}
@@ -0,0 +1,115 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Quote renders a block of text behind a colored bar in the left margin, to
+// set messages from servers or remediation steps apart from other output:
+//
+//	┃ Snapshot failed: the datastore is full.
+//	┃ Free up space and retry the backup.
+//
+// An optional title is shown in bold on the first line. Text is wrapped to
+// the width of the terminal.
+type Quote struct {
+	title string
+	text  string
+
+	bar          string
+	attributes   []color.Attribute
+	width        int
+	colorEnabled *bool
+}
+
+// NewQuote creates a Quote of the given text.
+func NewQuote(text string) *Quote {
+	return &Quote{
+		text:       text,
+		bar:        BorderHeavy.Vertical,
+		attributes: []color.Attribute{color.FgCyan},
+	}
+}
+
+// SetTitle sets a line shown in bold above the text.
+func (quote *Quote) SetTitle(title string) {
+	quote.title = title
+}
+
+// SetBar sets the string drawn in the margin of each line, which defaults to
+// a heavy vertical line.
+func (quote *Quote) SetBar(bar string) {
+	quote.bar = bar
+}
+
+// SetBarColor sets the color attributes of the bar, which default to cyan.
+func (quote *Quote) SetBarColor(attributes ...color.Attribute) {
+	quote.attributes = attributes
+}
+
+// SetWidth sets the width that lines are wrapped to, including the bar. A
+// width of 0, the default, wraps to the width of the terminal, and does not
+// wrap when not writing to a terminal.
+func (quote *Quote) SetWidth(width int) {
+	quote.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (quote *Quote) SetColor(enabled bool) {
+	quote.colorEnabled = &enabled
+}
+
+// PrettyString renders the quote, wrapped as if written to stdout.
+func (quote *Quote) PrettyString() string {
+	return quote.render(os.Stdout)
+}
+
+// Print prints the quote to stdout.
+func (quote *Quote) Print() error {
+	return quote.Fprint(os.Stdout)
+}
+
+// Fprint prints the quote to w.
+func (quote *Quote) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), quote.render(w))
+	return err
+}
+
+// render renders the quote, wrapped to the width of w if it is a terminal.
+func (quote *Quote) render(w io.Writer) string {
+	width := quote.width
+	if width == 0 {
+		width, _ = terminalWidth(w)
+	}
+	colorEnabled := colorsEnabled(quote.colorEnabled)
+
+	// The bar is followed by a space.
+	wrapWidth := 0
+	if width > 0 {
+		wrapWidth = width - strLengthWithEncoding(quote.bar) - 1
+		if wrapWidth < minWrapWidth {
+			wrapWidth = minWrapWidth
+		}
+	}
+
+	var lines []string
+	if quote.title != "" {
+		for _, line := range Wrap(quote.title, wrapWidth) {
+			lines = append(lines, colorize(line, colorEnabled, color.Bold))
+		}
+	}
+	lines = append(lines, Wrap(quote.text, wrapWidth)...)
+
+	bar := colorize(quote.bar, colorEnabled, quote.attributes...)
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(strings.TrimRight(bar+" "+line, " "))
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
@@ -0,0 +1,36 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestQuote(t *testing.T) {
+	quote := NewQuote(
+		"Snapshot failed: the datastore is full.\n\nFree up space and retry.")
+	quote.SetColor(false)
+	quote.SetWidth(30)
+	assert.EqualString(
+		t,
+		"┃ Snapshot failed: the\n"+
+			"┃ datastore is full.\n"+
+			"┃\n"+
+			"┃ Free up space and retry.\n",
+		quote.PrettyString())
+}
+
+func TestQuoteWithTitle(t *testing.T) {
+	quote := NewQuote("Retry later")
+	quote.SetTitle("Server message")
+	quote.SetBar(">")
+	quote.SetColor(true)
+	var buffer bytes.Buffer
+	assert.Nil(t, quote.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"\x1b[36m>\x1b[0m \x1b[1mServer message\x1b[0m\n"+
+			"\x1b[36m>\x1b[0m Retry later\n",
+		buffer.String())
+}
@@ -0,0 +1,11 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package pretty
+
+import "fmt"
+
+// makeRaw puts the terminal open as fd into raw mode, which is not supported
+// on this platform.
+func makeRaw(fd int) (func() error, error) {
+	return nil, fmt.Errorf("raw terminal mode is not supported on this platform")
+}
@@ -0,0 +1,11 @@
+//go:build linux || solaris
+
+package pretty
+
+import "golang.org/x/sys/unix"
+
+// Requests to read and write the settings of a terminal.
+const (
+	ioctlReadTermios  = unix.TCGETS
+	ioctlWriteTermios = unix.TCSETS
+)
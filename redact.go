@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Redaction is how FormatRedacted hides sensitive values.
+type Redaction uint
+
+const (
+	// RedactFull replaces values with a mask that does not reveal their
+	// length.
+	RedactFull Redaction = iota
+	// RedactLastFour masks all but the last four characters of values, as
+	// is common for card numbers and tokens. Values shorter than eight
+	// characters are masked in full.
+	RedactLastFour
+	// RedactHash replaces values with a prefix of their SHA-256 hash, such
+	// as "sha256:9f86d081", so that equal values can be recognized without
+	// being revealed.
+	RedactHash
+)
+
+// redactionMask replaces redacted values, and is always the same length.
+const redactionMask = "********"
+
+// FormatRedacted returns a Formatter for sensitive columns, such as tokens
+// and credentials, that hides their values as given. Empty values are left
+// empty. Since formatters are applied to every output format, including the
+// snapshots written by Table.LogValue, the values never appear in terminal
+// output or logs. Note that Table.MarshalJSON and Table.GobEncode store
+// values as they are, unredacted, as do the temporary files of tables with a
+// spill threshold.
+func FormatRedacted(redaction Redaction) Formatter {
+	return func(value interface{}) string {
+		return redact(formatValue(value), redaction)
+	}
+}
+
+func redact(str string, redaction Redaction) string {
+	if str == "" {
+		return ""
+	}
+	switch redaction {
+	case RedactLastFour:
+		runes := []rune(str)
+		if len(runes) >= 8 {
+			return redactionMask[:4] + string(runes[len(runes)-4:])
+		}
+	case RedactHash:
+		sum := sha256.Sum256([]byte(str))
+		return "sha256:" + hex.EncodeToString(sum[:4])
+	}
+	return redactionMask
+}
@@ -0,0 +1,39 @@
+package pretty
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFormatRedacted(t *testing.T) {
+	full := FormatRedacted(RedactFull)
+	assert.EqualString(t, "********", full("hunter2"))
+	assert.EqualString(t, "********", full("a much longer secret"))
+	assert.EqualString(t, "", full(""))
+
+	lastFour := FormatRedacted(RedactLastFour)
+	assert.EqualString(t, "****9012", lastFour("4111-1111-5678-9012"))
+	assert.EqualString(t, "********", lastFour("short"))
+
+	hash := FormatRedacted(RedactHash)
+	assert.EqualString(t, "sha256:9f86d081", hash("test"))
+	assert.EqualString(t, hash("test"), hash("test"))
+}
+
+func TestRedactedColumnExports(t *testing.T) {
+	table, err := NewTable([]ColumnDef{
+		NewColumnDef("User"),
+		NewColumnDef("Token").WithFormatter(FormatRedacted(RedactFull)),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("ada", "ghp_s3cr3tT0k3n"))
+
+	for _, format := range []Format{FormatTable, FormatJSON, FormatCSV} {
+		var buffer bytes.Buffer
+		assert.Nil(t, table.WriteFormat(&buffer, format))
+		assert.False(t, strings.Contains(buffer.String(), "s3cr3t"))
+	}
+}
@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+// expiringContext reports itself as canceled after its Err method has been
+// called a given number of times.
+type expiringContext struct {
+	context.Context
+	remainingChecks int
+}
+
+func (ctx *expiringContext) Err() error {
+	if ctx.remainingChecks == 0 {
+		return context.Canceled
+	}
+	ctx.remainingChecks--
+	return nil
+}
+
+func TestRenderContext(t *testing.T) {
+	table := createBasicTable(t)
+
+	var buffer bytes.Buffer
+	err := table.RenderContext(context.Background(), &buffer)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/basic_table.txt"),
+		buffer.String())
+}
+
+func TestRenderContextCanceled(t *testing.T) {
+	table := createBasicTable(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var buffer bytes.Buffer
+	err := table.RenderContext(ctx, &buffer)
+	assert.Equal(t, context.Canceled, err)
+	assert.EqualString(t, "", buffer.String())
+
+	// Cancel after the first two rows, whose output is flushed.
+	buffer.Reset()
+	ctx = &expiringContext{Context: context.Background(), remainingChecks: 3}
+	err = table.RenderContext(ctx, &buffer)
+	assert.Equal(t, context.Canceled, err)
+	lines := strings.Split(readFileAsString(t, "test/basic_table.txt"), "\n")
+	assert.EqualString(t, strings.Join(lines[:5], "\n")+"\n", buffer.String())
+}
@@ -0,0 +1,66 @@
+package pretty
+
+import "fmt"
+
+// defaultRowCountFormat is the format of the row count, which is translated
+// unless the program sets its own.
+const defaultRowCountFormat = "Count: %d"
+
+// RowCountPosition is where the row count of a table is printed.
+type RowCountPosition uint
+
+const (
+	// RowCountBelow prints the row count below the table. It is the default.
+	RowCountBelow RowCountPosition = iota
+	// RowCountAbove prints the row count above the table and its header.
+	// A StreamWriter cannot print it, since its rows are not counted until
+	// the end.
+	RowCountAbove RowCountPosition = iota
+)
+
+// SetRowCountFormat sets the line printed by ShowRowCount, which is
+// formatted with fmt.Sprintf and the number of rows, as in "%d VMs". If plural
+// is not empty, it is used instead of singular for counts other than 1, as in
+// SetRowCountFormat("%d VM", "%d VMs"). Empty formats restore the default,
+// "Count: %d".
+func (table *Table) SetRowCountFormat(singular string, plural string) {
+	table.rowCountFormat = singular
+	table.rowCountPluralFormat = plural
+}
+
+// SetRowCountPosition sets where the row count is printed, which defaults to
+// below the table.
+func (table *Table) SetRowCountPosition(position RowCountPosition) {
+	table.rowCountPosition = position
+}
+
+// WithRowCountFormat sets the line that shows the row count. See
+// Table.SetRowCountFormat.
+func WithRowCountFormat(singular string, plural string) Option {
+	return func(table *Table) error {
+		table.SetRowCountFormat(singular, plural)
+		return nil
+	}
+}
+
+// WithRowCountPosition sets where the row count is printed. See
+// Table.SetRowCountPosition.
+func WithRowCountPosition(position RowCountPosition) Option {
+	return func(table *Table) error {
+		table.SetRowCountPosition(position)
+		return nil
+	}
+}
+
+// rowCountLine returns the line that shows the row count, with its line
+// break.
+func (table *Table) rowCountLine(rowCount int) string {
+	format := table.rowCountFormat
+	if rowCount != 1 && table.rowCountPluralFormat != "" {
+		format = table.rowCountPluralFormat
+	}
+	if format == "" {
+		return translate(defaultRowCountFormat, rowCount) + "\n"
+	}
+	return fmt.Sprintf(format, rowCount) + "\n"
+}
@@ -0,0 +1,65 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRowCountFormat(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithRowCount(true),
+		WithRowCountFormat("%d VM", "%d VMs"),
+		WithColor(false))
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("vm-1"))
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+\n| Name |\n+------+\n| vm-1 |\n+------+\n1 VM\n",
+		output)
+
+	assert.Nil(t, table.AddRow("vm-2"))
+	table.SetRowCountPosition(RowCountAbove)
+	output, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"2 VMs\n+------+\n| Name |\n+------+\n| vm-1 |\n| vm-2 |\n+------+\n",
+		output)
+
+	// The settings survive serialization.
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	restored := &Table{}
+	assert.Nil(t, json.Unmarshal(data, restored))
+	assert.Equal(t, "%d VMs", restored.rowCountPluralFormat)
+	assert.Equal(t, RowCountAbove, restored.rowCountPosition)
+
+	// Streamed tables cannot print the count above, since it is only
+	// known at the end.
+	streamed, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithRowCount(true),
+		WithRowCountPosition(RowCountAbove),
+		WithColor(false))
+	assert.Nil(t, err)
+	var buffer bytes.Buffer
+	stream := NewStreamWriter(&buffer, streamed, 0)
+	assert.NotNil(t, stream.WriteRow("vm-1"))
+	assert.EqualString(t, "", buffer.String())
+
+	table.SetRowCountFormat("", "")
+	table.SetRowCountPosition(RowCountBelow)
+	output, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+\n| Name |\n+------+\n| vm-1 |\n| vm-2 |\n+------+\nCount: 2\n",
+		output)
+}
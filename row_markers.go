@@ -0,0 +1,140 @@
+package pretty
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// RowChange is how a row differs from a previous state, as shown by row
+// markers.
+type RowChange uint
+
+const (
+	// RowUnchanged is a row without a change, which has an empty marker.
+	RowUnchanged RowChange = iota
+	// RowAdded is a new row, marked with a green +.
+	RowAdded
+	// RowRemoved is a deleted row, marked with a red -.
+	RowRemoved
+	// RowChanged is a modified row, marked with a yellow ~.
+	RowChanged
+)
+
+// marker returns the marker of rows with the change.
+func (change RowChange) marker() string {
+	switch change {
+	case RowAdded:
+		return diffMarkerAdded
+	case RowRemoved:
+		return diffMarkerRemoved
+	case RowChanged:
+		return diffMarkerChanged
+	}
+	return ""
+}
+
+// color returns the color of rows with the change, or color.Reset if they
+// keep the column colors.
+func (change RowChange) color() color.Attribute {
+	switch change {
+	case RowAdded:
+		return color.FgGreen
+	case RowRemoved:
+		return color.FgRed
+	case RowChanged:
+		return color.FgYellow
+	}
+	return color.Reset
+}
+
+// SetRowChange records how the row at index, counting from 0, has changed.
+// The change is shown when row markers are enabled with SetRowMarkers. It is
+// kept when the table is sorted and cleared by SetRows.
+func (table *Table) SetRowChange(index int, change RowChange) error {
+	if index < 0 || index >= table.rowCount() {
+		return fmt.Errorf(
+			"row %d out of range [0, %d)",
+			index,
+			table.rowCount())
+	}
+	if table.rowChanges == nil {
+		table.rowChanges = make(map[int]RowChange)
+	}
+	table.rowChanges[index] = change
+	return nil
+}
+
+// AddChangedRow adds a row to the table, as AddRow does, and records how it
+// has changed. See SetRowChange.
+func (table *Table) AddChangedRow(change RowChange, row ...string) error {
+	rowCount := table.rowCount()
+	if err := table.AddRow(row...); err != nil {
+		return err
+	}
+	if table.rowCount() == rowCount {
+		// The row was dropped because errors are deferred.
+		return nil
+	}
+	return table.SetRowChange(rowCount, change)
+}
+
+// SetRowMarkers toggles a leading column marking the rows that have changed
+// with +, - or ~, colored green, red and yellow, for previews of changes
+// such as plans. See SetRowChange.
+func (table *Table) SetRowMarkers(rowMarkers bool) {
+	table.rowMarkers = rowMarkers
+}
+
+// WithRowMarkers toggles row markers. See Table.SetRowMarkers.
+func WithRowMarkers(rowMarkers bool) Option {
+	return func(table *Table) error {
+		table.SetRowMarkers(rowMarkers)
+		return nil
+	}
+}
+
+// renderMarked renders the table with a leading column holding the markers
+// of the rows.
+func (table *Table) renderMarked(ctx context.Context, w renderWriter) error {
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+	marked := *table
+	marked.rowMarkers = false
+	marked.columnDefs = append(
+		[]ColumnDef{NewColumnDef("").WithAlignment(LeftJustify)},
+		table.columnDefs...)
+	marked.rows = make([][]string, len(table.rows))
+	for i, row := range table.rows {
+		marker := table.rowChanges[i].marker()
+		marked.rows[i] = append([]string{marker}, row...)
+	}
+	if table.values != nil {
+		marked.values = make([][]interface{}, len(table.values))
+		for i, values := range table.values {
+			if values != nil {
+				marker := table.rowChanges[i].marker()
+				marked.values[i] = append([]interface{}{marker}, values...)
+			}
+		}
+	}
+	marked.rowColorOverrides = make(map[int]color.Attribute)
+	for i, change := range table.rowChanges {
+		if change != RowUnchanged {
+			marked.rowColorOverrides[i] = change.color()
+		}
+	}
+	for i, attribute := range table.rowColorOverrides {
+		marked.rowColorOverrides[i] = attribute
+	}
+	if table.fixedWidths != nil {
+		marked.fixedWidths = append([]int{1}, table.fixedWidths...)
+	}
+	marked.resetColumnWidths()
+
+	err := marked.render(ctx, w)
+	table.truncations = marked.truncations
+	return err
+}
@@ -0,0 +1,53 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRowMarkers(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Resource"), NewColumnDef("Size")},
+		WithColor(false),
+		WithRowMarkers(true),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddChangedRow(RowAdded, "vm-c", "4"))
+	assert.Nil(t, table.AddRow("vm-a", "2"))
+	assert.Nil(t, table.AddChangedRow(RowChanged, "vm-b", "8"))
+	assert.Nil(t, table.SetRowChange(1, RowRemoved))
+	assert.True(t, table.SetRowChange(3, RowAdded) != nil)
+	assert.Nil(t, table.SortBy("Resource", false))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---+----------+------+\n"+
+			"|   | Resource | Size |\n"+
+			"+---+----------+------+\n"+
+			"| - |     vm-a |    2 |\n"+
+			"| ~ |     vm-b |    8 |\n"+
+			"| + |     vm-c |    4 |\n"+
+			"+---+----------+------+\n",
+		rendered)
+
+	assert.Equal(t, color.FgYellow, table.rowChanges[1].color())
+	assert.Equal(t, color.Reset, RowUnchanged.color())
+
+	table.SetRowMarkers(false)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+----------+------+\n"+
+			"| Resource | Size |\n"+
+			"+----------+------+\n"+
+			"|     vm-a |    2 |\n"+
+			"|     vm-b |    8 |\n"+
+			"|     vm-c |    4 |\n"+
+			"+----------+------+\n",
+		rendered)
+}
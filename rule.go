@@ -0,0 +1,21 @@
+package pretty
+
+import "strings"
+
+// Rule returns a horizontal line width characters wide, drawn with the
+// horizontal border of style, for separating blocks of output that are not
+// tables. A width of 0 or less uses the width of the terminal, and the zero
+// BorderStyle draws with BorderASCII, as for tables.
+func Rule(width int, style BorderStyle) string {
+	if style == (BorderStyle{}) {
+		style = BorderASCII
+	}
+	return strings.Repeat(style.Horizontal, lineWidth(width)) + "\n"
+}
+
+// RuleToTerminal returns a Rule as wide as the terminal, drawn with the
+// default border style of tables, which end users can change with EnvStyle.
+func RuleToTerminal() string {
+	style, _ := environmentBorderStyle()
+	return Rule(0, style)
+}
@@ -0,0 +1,27 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRule(t *testing.T) {
+	assert.EqualString(t, "-----\n", Rule(5, BorderStyle{}))
+	assert.EqualString(t, "═══\n", Rule(3, BorderDouble))
+}
+
+func TestRuleToTerminal(t *testing.T) {
+	t.Setenv(EnvStyle, "heavy")
+	assert.EqualString(
+		t,
+		strings.Repeat("━", defaultTerminalWidth)+"\n",
+		RuleToTerminal())
+
+	t.Setenv(EnvStyle, "unknown")
+	assert.EqualString(
+		t,
+		strings.Repeat("-", defaultTerminalWidth)+"\n",
+		RuleToTerminal())
+}
@@ -0,0 +1,70 @@
+package pretty
+
+import (
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// processStart is about when the process started, as packages are
+// initialized then.
+var processStart = time.Now()
+
+// RuntimeStatsTable creates a table of statistics about the Go runtime of the
+// process, for --debug output and support bundles:
+//
+//	┌─────────────────┬──────────┐
+//	│ Go version      │ go1.21.0 │
+//	│ Goroutines      │       12 │
+//	│ Heap in use     │  3.2 MiB │
+//	│ Last GC pause   │   51.2µs │
+//	│ Uptime          │    4m12s │
+//	│ ...             │      ... │
+//	└─────────────────┴──────────┘
+//
+// The table has light borders and no column names, which opts may change.
+func RuntimeStatsTable(opts ...Option) (*Table, error) {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	lastPause := time.Duration(0)
+	if stats.NumGC > 0 {
+		lastPause = time.Duration(
+			stats.PauseNs[(stats.NumGC+255)%uint32(len(stats.PauseNs))])
+	}
+
+	rows := [][]string{
+		{"Go version", runtime.Version()},
+		{"CPUs", strconv.Itoa(runtime.NumCPU())},
+		{"Goroutines", strconv.Itoa(runtime.NumGoroutine())},
+		{"Heap in use", formatBytes(float64(stats.HeapInuse))},
+		{"Heap objects", strconv.FormatUint(stats.HeapObjects, 10)},
+		{"Total allocated", formatBytes(float64(stats.TotalAlloc))},
+		{"System memory", formatBytes(float64(stats.Sys))},
+		{"GC cycles", strconv.FormatUint(uint64(stats.NumGC), 10)},
+		{"Last GC pause", roundDuration(lastPause).String()},
+		{
+			"Total GC pause",
+			roundDuration(time.Duration(stats.PauseTotalNs)).String(),
+		},
+		{"Uptime", roundDuration(time.Since(processStart)).String()},
+	}
+	for _, row := range rows {
+		row[0] = translate(row[0])
+	}
+
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Statistic").WithAlignment(LeftJustify),
+			NewColumnDef("Value"),
+		},
+		append(
+			[]Option{WithBorderStyle(BorderLight), WithColumnNames(false)},
+			opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	if err := table.SetRows(rows); err != nil {
+		return nil, err
+	}
+	return table, nil
+}
@@ -0,0 +1,26 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestRuntimeStatsTable(t *testing.T) {
+	table, err := RuntimeStatsTable(WithColor(false), WithColumnNames(true))
+	assert.Nil(t, err)
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(output, "┌"))
+	assert.True(t, strings.Contains(output, "│ Statistic "))
+	for _, statistic := range []string{
+		"Goroutines",
+		"Heap in use",
+		"Last GC pause",
+		"Uptime",
+	} {
+		assert.True(t, strings.Contains(output, "│ "+statistic+" "))
+	}
+}
@@ -0,0 +1,89 @@
+package pretty
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Sanitization is how characters that could control a terminal are removed
+// from values that come from untrusted sources, so that printing them cannot
+// change colors, move the cursor, set the window title or hide text.
+type Sanitization uint
+
+const (
+	// SanitizeNone prints values as they are, including any escape
+	// sequences. It is the default.
+	SanitizeNone Sanitization = iota
+	// SanitizeStrip removes escape sequences and other non-printable
+	// characters, such as control characters and bidirectional overrides.
+	// Tabs and line breaks are replaced by spaces.
+	SanitizeStrip Sanitization = iota
+	// SanitizeEscape shows non-printable characters as they are written in
+	// Go string literals, such as \x1b or \u202e, so that they can be seen.
+	SanitizeEscape Sanitization = iota
+)
+
+// SetSanitization sets how the values of cells are sanitized before they
+// are printed, for tables of data from untrusted sources. Values are
+// sanitized before formatters are given them, and the output of formatters
+// and derived columns is kept as it is, so that they can still add colors.
+// The header and column names are never sanitized.
+func (table *Table) SetSanitization(sanitization Sanitization) {
+	table.sanitization = sanitization
+}
+
+// WithSanitization sets how the values of cells are sanitized. See
+// Table.SetSanitization.
+func WithSanitization(sanitization Sanitization) Option {
+	return func(table *Table) error {
+		table.SetSanitization(sanitization)
+		return nil
+	}
+}
+
+// Sanitize removes or escapes the characters of str that could control a
+// terminal, as tables do with SetSanitization.
+func Sanitize(str string, sanitization Sanitization) string {
+	if sanitization == SanitizeNone || isPrintable(str) {
+		return str
+	}
+
+	var builder strings.Builder
+	for i := 0; i < len(str); {
+		r, size := utf8.DecodeRuneInString(str[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			// Invalid bytes such as 0x9b may be read as control characters.
+			if sanitization == SanitizeEscape {
+				builder.WriteString(fmt.Sprintf(`\x%02x`, str[i]))
+			}
+		case unicode.IsGraphic(r):
+			builder.WriteString(str[i : i+size])
+		case sanitization == SanitizeEscape:
+			quoted := strconv.QuoteRune(r)
+			builder.WriteString(quoted[1 : len(quoted)-1])
+		case r == '\t' || r == '\n':
+			builder.WriteString(" ")
+		case r == '\x1b':
+			if length := escapeSequenceLength(str[i:]); length > 0 {
+				size = length
+			}
+		}
+		i += size
+	}
+	return builder.String()
+}
+
+// isPrintable returns whether every character of str is valid and printable,
+// including spaces other than tabs and line breaks.
+func isPrintable(str string) bool {
+	for _, r := range str {
+		if r == utf8.RuneError || !unicode.IsGraphic(r) {
+			return false
+		}
+	}
+	return true
+}
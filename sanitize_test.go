@@ -0,0 +1,49 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSanitize(t *testing.T) {
+	hostile := "ok\x1b[2J\x1b]0;pwned\a\tdone\u202eevil\xff"
+	assert.Equal(t, hostile, Sanitize(hostile, SanitizeNone))
+	assert.Equal(t, "ok doneevil", Sanitize(hostile, SanitizeStrip))
+	assert.Equal(
+		t,
+		`ok\x1b[2J\x1b]0;pwned\a\tdone\u202eevil\xff`,
+		Sanitize(hostile, SanitizeEscape))
+	assert.Equal(
+		t,
+		"Crème brûlée 日本",
+		Sanitize("Crème brûlée 日本", SanitizeStrip))
+}
+
+func TestTableWithSanitization(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Name"),
+			NewColumnDef("Upper").WithFormatter(func(value interface{}) string {
+				return strings.ToUpper(value.(string))
+			}),
+		},
+		WithSanitization(SanitizeEscape),
+		WithColor(false))
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("vm\x1b[31m", "a\x07"))
+	assert.Nil(t, table.AddValues("vm", "b\x1b"))
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------------+-------+\n"+
+			"| Name       | Upper |\n"+
+			"+------------+-------+\n"+
+			"| vm\\x1b[31m |   A\\A |\n"+
+			"|         vm | B\\X1B |\n"+
+			"+------------+-------+\n",
+		output)
+}
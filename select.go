@@ -0,0 +1,64 @@
+package pretty
+
+import "fmt"
+
+// SelectColumns restricts the table to the named columns, in the given order.
+// Derived columns keep the values computed from the full row, even if the
+// columns they depend on are not selected.
+func (table *Table) SelectColumns(columns ...string) error {
+	if len(columns) == 0 {
+		return fmt.Errorf("must select at least 1 column")
+	}
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+
+	indices := make([]int, len(columns))
+	for i, column := range columns {
+		index, err := table.columnIndex(column)
+		if err != nil {
+			return err
+		}
+		indices[i] = index
+	}
+
+	columnDefs := make([]ColumnDef, len(indices))
+	for i, index := range indices {
+		columnDef := table.columnDefs[index]
+		columnDef.derive = nil
+		columnDefs[i] = columnDef
+	}
+	rows := make([][]string, len(table.rows))
+	for i := range table.rows {
+		selected := make([]string, len(indices))
+		for j, index := range indices {
+			selected[j] = table.cellValue(i, index)
+		}
+		rows[i] = selected
+	}
+
+	// Raw values are kept so that formatters still receive them, except for
+	// derived columns, whose computed values are kept instead.
+	var values [][]interface{}
+	if table.values != nil {
+		values = make([][]interface{}, len(table.values))
+		for i, rowValues := range table.values {
+			if rowValues == nil {
+				continue
+			}
+			values[i] = make([]interface{}, len(indices))
+			for j, index := range indices {
+				values[i][j] = rowValues[index]
+				if table.columnDefs[index].derive != nil {
+					values[i][j] = rows[i][j]
+				}
+			}
+		}
+	}
+
+	table.columnDefs = columnDefs
+	table.rows = rows
+	table.values = values
+	table.resetColumnWidths()
+	return nil
+}
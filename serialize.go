@@ -0,0 +1,459 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// tableSnapshot is the serialized form of a Table.
+type tableSnapshot struct {
+	Header       *string          `json:"header,omitempty"`
+	Columns      []columnSnapshot `json:"columns"`
+	Rows         [][]string       `json:"rows"`
+	ShowRowCount bool             `json:"showRowCount,omitempty"`
+	TypeSniffing bool             `json:"typeSniffing,omitempty"`
+	RowColors    map[int]int      `json:"rowColors,omitempty"`
+	Border       *BorderStyle     `json:"border,omitempty"`
+	MaxWidth     int              `json:"maxWidth,omitempty"`
+	Color        *bool            `json:"color,omitempty"`
+	// ColorDisabled keeps disabled colors in gob, which does not distinguish
+	// a pointer to false from a nil pointer.
+	ColorDisabled bool `json:"-"`
+	// RowCountFormats holds the singular and plural formats of the row
+	// count, if they are set.
+	RowCountFormats []string `json:"rowCountFormats,omitempty"`
+	RowCountAbove   bool     `json:"rowCountAbove,omitempty"`
+	FixedWidths     []int    `json:"fixedWidths,omitempty"`
+
+	HideColumnNames bool           `json:"hideColumnNames,omitempty"`
+	Style           *styleSnapshot `json:"style,omitempty"`
+	Sanitization    Sanitization   `json:"sanitization,omitempty"`
+	Wide            bool           `json:"wide,omitempty"`
+	ShrinkStrategy  ShrinkStrategy `json:"shrinkStrategy,omitempty"`
+	// MaxRows holds the number of first and last rows shown, if they are
+	// limited.
+	MaxRows         []int             `json:"maxRows,omitempty"`
+	Stacked         bool              `json:"stacked,omitempty"`
+	StackKeyColumns []string          `json:"stackKeyColumns,omitempty"`
+	UnitPlacement   UnitPlacement     `json:"unitPlacement,omitempty"`
+	TimeZone        string            `json:"timeZone,omitempty"`
+	RowMarkers      bool              `json:"rowMarkers,omitempty"`
+	RowChanges      map[int]RowChange `json:"rowChanges,omitempty"`
+	Annotations     map[int]string    `json:"annotations,omitempty"`
+
+	TruncationNote    string     `json:"truncationNote,omitempty"`
+	SortColumn        string     `json:"sortColumn,omitempty"`
+	SortDescending    bool       `json:"sortDescending,omitempty"`
+	ShowSortIndicator bool       `json:"showSortIndicator,omitempty"`
+	Accessible        bool       `json:"accessible,omitempty"`
+	PipedFormat       Format     `json:"pipedFormat,omitempty"`
+	RenderWorkers     int        `json:"renderWorkers,omitempty"`
+	DeferErrors       bool       `json:"deferErrors,omitempty"`
+	LenientRows       bool       `json:"lenientRows,omitempty"`
+	ExportEncoding    Encoding   `json:"exportEncoding,omitempty"`
+	ColorDepth        ColorDepth `json:"colorDepth,omitempty"`
+	// Hyperlinks is stored as text, like the alignment of columns, so that
+	// disabled hyperlinks are kept.
+	Hyperlinks        string            `json:"hyperlinks,omitempty"`
+	HyperlinkFallback HyperlinkFallback `json:"hyperlinkFallback,omitempty"`
+}
+
+type columnSnapshot struct {
+	Name     string `json:"name"`
+	MaxWidth *int   `json:"maxWidth,omitempty"`
+	// Alignment is stored by name, since gob does not distinguish a pointer
+	// to the zero value from a nil pointer.
+	Alignment string `json:"alignment,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+	Suffix    string `json:"suffix,omitempty"`
+	Unit      string `json:"unit,omitempty"`
+
+	Style         *styleSnapshot `json:"style,omitempty"`
+	TimeZone      string         `json:"timeZone,omitempty"`
+	ShortIDLength int            `json:"shortIDLength,omitempty"`
+	StatusSymbols bool           `json:"statusSymbols,omitempty"`
+}
+
+type styleSnapshot struct {
+	Color       int    `json:"color,omitempty"`
+	HeaderColor int    `json:"headerColor,omitempty"`
+	Alignment   string `json:"alignment,omitempty"`
+	// Padding is stored as text, like the alignment of columns, so that a
+	// padding of 0 is kept.
+	Padding  string   `json:"padding,omitempty"`
+	Overflow Overflow `json:"overflow,omitempty"`
+}
+
+// MarshalJSON encodes the column definitions, configuration and rows of the
+// table. Functions cannot be serialized, so derived columns are stored with
+// their computed values as regular columns, and formatters, validators,
+// value colors and shrink weights are dropped. Settings read from environment
+// variables are not stored, so that the decoding program applies its own, and
+// neither are the errors and warnings found while adding rows.
+//
+// Values are stored sanitized, but without their formatters: the values of
+// columns hidden with FormatRedacted appear in full.
+func (table *Table) MarshalJSON() ([]byte, error) {
+	if err := table.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+	return json.Marshal(table.snapshot())
+}
+
+// UnmarshalJSON decodes a table encoded by MarshalJSON, replacing the
+// contents of this table.
+func (table *Table) UnmarshalJSON(data []byte) error {
+	var snapshot tableSnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	return table.restore(snapshot)
+}
+
+// GobEncode encodes the table in the same way as MarshalJSON.
+func (table *Table) GobEncode() ([]byte, error) {
+	if err := table.loadSpilledRows(); err != nil {
+		return nil, err
+	}
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(table.snapshot()); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GobDecode decodes a table encoded by GobEncode, replacing the contents of
+// this table.
+func (table *Table) GobDecode(data []byte) error {
+	var snapshot tableSnapshot
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&snapshot)
+	if err != nil {
+		return err
+	}
+	return table.restore(snapshot)
+}
+
+func (table *Table) snapshot() tableSnapshot {
+	snapshot := tableSnapshot{
+		Header:          table.header,
+		Columns:         make([]columnSnapshot, len(table.columnDefs)),
+		Rows:            make([][]string, len(table.rows)),
+		ShowRowCount:    table.shouldPrintRowCount,
+		TypeSniffing:    table.sniffTypes,
+		HideColumnNames: table.hideColumnNames,
+		Style:           newStyleSnapshot(table.style),
+		Sanitization:    table.sanitization,
+		Wide:            table.wide,
+		ShrinkStrategy:  table.shrinkStrategy,
+		Stacked:         table.stacked,
+		StackKeyColumns: table.stackKeyColumns,
+		UnitPlacement:   table.unitPlacement,
+		TimeZone:        locationName(table.timeZone),
+		RowMarkers:      table.rowMarkers,
+
+		TruncationNote:    table.truncationNote,
+		SortColumn:        table.sortColumn,
+		SortDescending:    table.sortDescending,
+		ShowSortIndicator: table.showSortIndicator,
+		PipedFormat:       table.pipedFormat,
+		RenderWorkers:     table.renderWorkers,
+		DeferErrors:       table.deferErrors,
+		LenientRows:       table.lenientRows,
+		ExportEncoding:    table.exportEncoding,
+		ColorDepth:        table.colorDepth,
+		HyperlinkFallback: table.hyperlinkFallback,
+	}
+	if !table.accessibleFromEnvironment {
+		snapshot.Accessible = table.accessible
+	}
+	if table.hyperlinks != nil {
+		snapshot.Hyperlinks = strconv.FormatBool(*table.hyperlinks)
+	}
+	if !table.maxWidthFromEnvironment {
+		snapshot.MaxWidth = table.maxWidth
+	}
+	if !table.colorFromEnvironment && table.colorEnabled != nil {
+		snapshot.Color = table.colorEnabled
+		snapshot.ColorDisabled = !*table.colorEnabled
+	}
+	if table.maxHeadRows+table.maxTailRows > 0 {
+		snapshot.MaxRows = []int{table.maxHeadRows, table.maxTailRows}
+	}
+	if table.rowCountFormat != "" || table.rowCountPluralFormat != "" {
+		snapshot.RowCountFormats = []string{
+			table.rowCountFormat,
+			table.rowCountPluralFormat,
+		}
+	}
+	snapshot.RowCountAbove = table.rowCountPosition == RowCountAbove
+	snapshot.FixedWidths = table.fixedWidths
+	if table.border != (BorderStyle{}) && !table.borderFromEnvironment {
+		snapshot.Border = &table.border
+	}
+	for i, columnDef := range table.columnDefs {
+		snapshot.Columns[i] = columnSnapshot{
+			Name:          columnDef.name,
+			MaxWidth:      columnDef.maxWidth,
+			Prefix:        columnDef.prefix,
+			Suffix:        columnDef.suffix,
+			Unit:          columnDef.unit,
+			TimeZone:      locationName(columnDef.timeZone),
+			ShortIDLength: columnDef.shortIDLength,
+			StatusSymbols: columnDef.statusSymbols,
+		}
+		if columnDef.alignment != nil {
+			snapshot.Columns[i].Alignment = columnDef.alignment.String()
+		}
+		if columnDef.style != nil {
+			style := newStyleSnapshot(*columnDef.style)
+			snapshot.Columns[i].Style = style
+		}
+	}
+	for i, row := range table.rows {
+		values := make([]string, len(row))
+		for j := range row {
+			values[j] = table.cellValue(i, j)
+		}
+		snapshot.Rows[i] = values
+	}
+	if len(table.rowColorOverrides) > 0 {
+		snapshot.RowColors = make(map[int]int, len(table.rowColorOverrides))
+		for row, attribute := range table.rowColorOverrides {
+			snapshot.RowColors[row] = int(attribute)
+		}
+	}
+	if len(table.rowChanges) > 0 {
+		snapshot.RowChanges = table.rowChanges
+	}
+	if len(table.rowAnnotations) > 0 {
+		snapshot.Annotations = table.rowAnnotations
+	}
+	return snapshot
+}
+
+func (table *Table) restore(snapshot tableSnapshot) error {
+	columnDefs := make([]ColumnDef, len(snapshot.Columns))
+	for i, column := range snapshot.Columns {
+		columnDefs[i] = ColumnDef{
+			name:          column.Name,
+			maxWidth:      column.MaxWidth,
+			prefix:        column.Prefix,
+			suffix:        column.Suffix,
+			unit:          column.Unit,
+			shortIDLength: column.ShortIDLength,
+			statusSymbols: column.StatusSymbols,
+		}
+		if column.Alignment != "" {
+			alignment, err := parseAlignment(column.Alignment)
+			if err != nil {
+				return err
+			}
+			columnDefs[i].alignment = &alignment
+		}
+		if column.Style != nil {
+			style, err := column.Style.style()
+			if err != nil {
+				return err
+			}
+			columnDefs[i].style = &style
+		}
+		location, err := loadLocation(column.TimeZone)
+		if err != nil {
+			return err
+		}
+		columnDefs[i].timeZone = location
+	}
+	restored, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return err
+	}
+	if err := restored.SetRows(snapshot.Rows); err != nil {
+		return err
+	}
+
+	restored.header = snapshot.Header
+	restored.shouldPrintRowCount = snapshot.ShowRowCount
+	if len(snapshot.RowCountFormats) == 2 {
+		restored.rowCountFormat = snapshot.RowCountFormats[0]
+		restored.rowCountPluralFormat = snapshot.RowCountFormats[1]
+	}
+	if snapshot.RowCountAbove {
+		restored.rowCountPosition = RowCountAbove
+	}
+	if len(snapshot.FixedWidths) > 0 {
+		err := restored.SetFixedLayout(snapshot.FixedWidths...)
+		if err != nil {
+			return err
+		}
+	}
+	restored.sniffTypes = snapshot.TypeSniffing
+	restored.hideColumnNames = snapshot.HideColumnNames
+	restored.SetSanitization(snapshot.Sanitization)
+	restored.SetWide(snapshot.Wide)
+	restored.SetShrinkStrategy(snapshot.ShrinkStrategy)
+	restored.SetUnitPlacement(snapshot.UnitPlacement)
+	restored.SetRowMarkers(snapshot.RowMarkers)
+	restored.SetTruncationNote(snapshot.TruncationNote)
+	restored.ShowSortIndicator(snapshot.ShowSortIndicator)
+	restored.SetPipedFormat(snapshot.PipedFormat)
+	restored.SetRenderWorkers(snapshot.RenderWorkers)
+	restored.SetDeferErrors(snapshot.DeferErrors)
+	restored.SetLenientRows(snapshot.LenientRows)
+	restored.SetExportEncoding(snapshot.ExportEncoding)
+	restored.SetColorDepth(snapshot.ColorDepth)
+	restored.SetHyperlinkFallback(snapshot.HyperlinkFallback)
+	if snapshot.Hyperlinks != "" {
+		hyperlinks, err := strconv.ParseBool(snapshot.Hyperlinks)
+		if err != nil {
+			return fmt.Errorf(
+				"invalid hyperlinks %q",
+				snapshot.Hyperlinks)
+		}
+		restored.SetHyperlinks(hyperlinks)
+	}
+	if snapshot.SortColumn != "" {
+		// The rows are stored in sorted order.
+		_, err := restored.columnIndex(snapshot.SortColumn)
+		if err != nil {
+			return err
+		}
+		restored.sortColumn = snapshot.SortColumn
+		restored.sortDescending = snapshot.SortDescending
+	}
+	if snapshot.Style != nil {
+		style, err := snapshot.Style.style()
+		if err != nil {
+			return err
+		}
+		if err := restored.SetStyle(style); err != nil {
+			return err
+		}
+	}
+	if len(snapshot.MaxRows) == 2 {
+		restored.SetMaxRowsSplit(
+			snapshot.MaxRows[0],
+			snapshot.MaxRows[1])
+	}
+	if snapshot.Stacked || len(snapshot.StackKeyColumns) > 0 {
+		err := restored.SetStacked(
+			snapshot.Stacked,
+			snapshot.StackKeyColumns...)
+		if err != nil {
+			return err
+		}
+	}
+	location, err := loadLocation(snapshot.TimeZone)
+	if err != nil {
+		return err
+	}
+	restored.SetTimeZone(location)
+
+	// Settings that are not stored keep the defaults of this program, which
+	// may come from its environment.
+	if snapshot.MaxWidth > 0 {
+		restored.SetMaxWidth(snapshot.MaxWidth)
+	}
+	if snapshot.Color != nil {
+		restored.SetColor(*snapshot.Color)
+	} else if snapshot.ColorDisabled {
+		restored.SetColor(false)
+	}
+	if snapshot.Border != nil {
+		restored.SetBorderStyle(*snapshot.Border)
+	}
+	if snapshot.Accessible {
+		restored.SetAccessible(true)
+	}
+	if len(snapshot.RowColors) > 0 {
+		restored.rowColorOverrides = make(map[int]color.Attribute)
+		for row, attribute := range snapshot.RowColors {
+			restored.rowColorOverrides[row] = color.Attribute(attribute)
+		}
+	}
+	for row, change := range snapshot.RowChanges {
+		if err := restored.SetRowChange(row, change); err != nil {
+			return err
+		}
+	}
+	for row, annotation := range snapshot.Annotations {
+		err := restored.SetRowAnnotation(row, annotation)
+		if err != nil {
+			return err
+		}
+	}
+
+	*table = *restored
+	return nil
+}
+
+// newStyleSnapshot returns the serialized form of style, or nil if no field
+// of the style is set.
+func newStyleSnapshot(style Style) *styleSnapshot {
+	if style == (Style{}) {
+		return nil
+	}
+	snapshot := &styleSnapshot{
+		Color:       int(style.Color),
+		HeaderColor: int(style.HeaderColor),
+		Overflow:    style.Overflow,
+	}
+	if style.Alignment != nil {
+		snapshot.Alignment = style.Alignment.String()
+	}
+	if style.Padding != nil {
+		snapshot.Padding = strconv.Itoa(*style.Padding)
+	}
+	return snapshot
+}
+
+func (snapshot *styleSnapshot) style() (Style, error) {
+	style := Style{
+		Color:       color.Attribute(snapshot.Color),
+		HeaderColor: color.Attribute(snapshot.HeaderColor),
+		Overflow:    snapshot.Overflow,
+	}
+	if snapshot.Alignment != "" {
+		alignment, err := parseAlignment(snapshot.Alignment)
+		if err != nil {
+			return Style{}, err
+		}
+		style.Alignment = &alignment
+	}
+	if snapshot.Padding != "" {
+		padding, err := strconv.Atoi(snapshot.Padding)
+		if err != nil {
+			return Style{}, fmt.Errorf(
+				"invalid padding %q",
+				snapshot.Padding)
+		}
+		style.Padding = &padding
+	}
+	return style, nil
+}
+
+// locationName returns the name of location for serialization, or "" if it
+// is nil.
+func locationName(location *time.Location) string {
+	if location == nil {
+		return ""
+	}
+	return location.String()
+}
+
+// loadLocation returns the location named by locationName, or nil for "".
+func loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %v", name, err)
+	}
+	return location, nil
+}
@@ -0,0 +1,206 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTableJSONRoundTrip(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetHeader("Employees")
+
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+
+	var decoded Table
+	err = json.Unmarshal(data, &decoded)
+	assert.Nil(t, err)
+	assertExpectedTable(t, &decoded, "basic_table_with_header.txt")
+}
+
+func TestTableGobRoundTrip(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDefWithWidth("Words", 10).WithAlignment(LeftJustify),
+		NewDerivedColumnDef("Length", func(row map[string]string) string {
+			return formatNumeric(float64(len(row["Words"])))
+		}))
+	assert.Nil(t, err)
+	err = table.AddRow("A", "Short")
+	assert.Nil(t, err)
+	err = table.AddRow("E", "this one is way too long")
+	assert.Nil(t, err)
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	err = gob.NewEncoder(&buffer).Encode(table)
+	assert.Nil(t, err)
+
+	decoded := &Table{}
+	err = gob.NewDecoder(&buffer).Decode(decoded)
+	assert.Nil(t, err)
+
+	// The derived column is restored as a regular column.
+	actual, err := decoded.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, actual)
+	assert.Nil(t, decoded.AddRow("F", "new", "3"))
+}
+
+func TestTableSettingsRoundTrip(t *testing.T) {
+	padding := 0
+	alignment := LeftJustify
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Job").WithStyle(Style{
+				Color:   color.FgCyan,
+				Padding: &padding,
+			}),
+			NewColumnDef("Started").WithTimeZone(time.UTC),
+			NewColumnDef("ID").WithShortIDs(6),
+			NewColumnDef("Status").
+				WithStatusSymbols().
+				WithUnit("state"),
+		},
+		WithStyle(Style{
+			HeaderColor: color.FgGreen,
+			Alignment:   &alignment,
+			Overflow:    OverflowWrap,
+		}),
+		WithSanitization(SanitizeEscape),
+		WithShrinkStrategy(ShrinkProportional),
+		WithMaxRowsSplit(2, 1),
+		WithUnitPlacement(UnitBelowName),
+		WithTimeZone(time.UTC),
+		WithRowMarkers(true),
+	)
+	assert.Nil(t, err)
+	table.ShowColumnNames(false)
+	table.SetWide(true)
+	assert.Nil(t, table.SetStacked(true, "Job"))
+	table.SetTruncationNote("* truncated")
+	table.ShowSortIndicator(true)
+	table.SetAccessible(true)
+	table.SetPipedFormat(FormatTSV)
+	table.SetRenderWorkers(4)
+	table.SetDeferErrors(true)
+	table.SetLenientRows(true)
+	table.SetExportEncoding(EncodingLatin1)
+	table.SetColorDepth(ColorDepth256)
+	table.SetHyperlinks(false)
+	table.SetHyperlinkFallback(HyperlinkShowTextAndURL)
+	table.SetColor(false)
+	assert.Nil(t, table.AddChangedRow(
+		RowAdded,
+		"backup",
+		"2024-01-02T03:04:05Z",
+		"0123456789abcdef",
+		"success"))
+	assert.Nil(t, table.AddAnnotatedRow(
+		"disk full",
+		"archive",
+		"2024-01-02T04:05:06Z",
+		"fedcba9876543210",
+		"failure"))
+	assert.Nil(t, table.SortBy("Job", true))
+
+	assertRestored := func(decoded *Table) {
+		assert.DeepEqual(t, table.snapshot(), decoded.snapshot())
+		assert.True(t, decoded.hideColumnNames)
+		assert.True(t, decoded.wide)
+		assert.True(t, decoded.stacked)
+		assert.True(t, decoded.rowMarkers)
+		assert.Equal(t, SanitizeEscape, decoded.sanitization)
+		assert.Equal(t, ShrinkProportional, decoded.shrinkStrategy)
+		assert.Equal(t, UnitBelowName, decoded.unitPlacement)
+		assert.Equal(t, 2, decoded.maxHeadRows)
+		assert.Equal(t, 1, decoded.maxTailRows)
+		assert.Equal(t, time.UTC, decoded.timeZone)
+		assert.Equal(t, OverflowWrap, decoded.style.Overflow)
+		assert.Equal(t, 0, *decoded.columnDefs[0].style.Padding)
+		assert.Equal(t, time.UTC, decoded.columnDefs[1].timeZone)
+		assert.Equal(t, 6, decoded.columnDefs[2].shortIDLength)
+		assert.True(t, decoded.columnDefs[3].statusSymbols)
+		assert.Equal(t, RowAdded, decoded.rowChanges[0])
+		assert.EqualString(t, "disk full", decoded.rowAnnotations[1])
+		assert.EqualString(t, "* truncated", decoded.truncationNote)
+		assert.EqualString(t, "Job", decoded.sortColumn)
+		assert.True(t, decoded.sortDescending)
+		assert.True(t, decoded.showSortIndicator)
+		assert.True(t, decoded.accessible)
+		assert.Equal(t, FormatTSV, decoded.pipedFormat)
+		assert.Equal(t, 4, decoded.renderWorkers)
+		assert.True(t, decoded.deferErrors)
+		assert.True(t, decoded.lenientRows)
+		assert.Equal(t, EncodingLatin1, decoded.exportEncoding)
+		assert.Equal(t, ColorDepth256, decoded.colorDepth)
+		assert.True(t, !*decoded.hyperlinks)
+		assert.Equal(
+			t,
+			HyperlinkShowTextAndURL,
+			decoded.hyperlinkFallback)
+		assert.True(t, !*decoded.colorEnabled)
+	}
+
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+	var fromJSON Table
+	assert.Nil(t, json.Unmarshal(data, &fromJSON))
+	assertRestored(&fromJSON)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buffer).Encode(table))
+	var fromGob Table
+	assert.Nil(t, gob.NewDecoder(&buffer).Decode(&fromGob))
+	assertRestored(&fromGob)
+}
+
+func TestTableSerializationSkipsEnvironment(t *testing.T) {
+	t.Setenv(EnvMaxWidth, "40")
+	t.Setenv(EnvNoColor, "1")
+	t.Setenv(EnvStyle, "light")
+	table := createBasicTable(t)
+	data, err := json.Marshal(table)
+	assert.Nil(t, err)
+
+	var snapshot tableSnapshot
+	assert.Nil(t, json.Unmarshal(data, &snapshot))
+	assert.Equal(t, 0, snapshot.MaxWidth)
+	assert.True(t, snapshot.Color == nil)
+	assert.True(t, snapshot.Border == nil)
+
+	// The decoding program applies its own environment.
+	t.Setenv(EnvMaxWidth, "")
+	t.Setenv(EnvNoColor, "")
+	t.Setenv(EnvStyle, "")
+	var decoded Table
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 0, decoded.maxWidth)
+	assert.True(t, decoded.colorEnabled == nil)
+	assert.Equal(t, BorderStyle{}, decoded.border)
+
+	table.SetMaxWidth(60)
+	data, err = json.Marshal(table)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, 60, decoded.maxWidth)
+}
+
+func TestTableUnmarshalInvalidJSON(t *testing.T) {
+	var table Table
+	err := json.Unmarshal([]byte(`{"columns": []}`), &table)
+	assert.NotNil(t, err)
+
+	err = json.Unmarshal(
+		[]byte(`{"columns": [{"name": "A"}], "rows": [["1", "2"]]}`),
+		&table)
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,34 @@
+package pretty
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// severityColors maps severities, in upper case, to their colors.
+var severityColors = map[string]color.Attribute{
+	"CRITICAL": color.FgMagenta,
+	"FATAL":    color.FgMagenta,
+	"ERROR":    color.FgRed,
+	"WARN":     color.FgYellow,
+	"WARNING":  color.FgYellow,
+	"INFO":     color.FgBlue,
+	"DEBUG":    color.FgHiBlack,
+	"TRACE":    color.FgHiBlack,
+}
+
+// SeverityColor returns the standard color of a log severity, ignoring case:
+// magenta for CRITICAL and FATAL, red for ERROR, yellow for WARN and WARNING,
+// blue for INFO, and gray for DEBUG and TRACE. Other values get color.Reset.
+// Pass it to ColumnDef.WithValueColor, or use ColumnDef.WithSeverityColors,
+// to color a severity column.
+func SeverityColor(severity string) color.Attribute {
+	return severityColors[strings.ToUpper(strings.TrimSpace(severity))]
+}
+
+// WithSeverityColors returns a copy of the ColumnDef whose values are colored
+// by SeverityColor.
+func (columnDef ColumnDef) WithSeverityColors() ColumnDef {
+	return columnDef.WithValueColor(SeverityColor)
+}
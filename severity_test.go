@@ -0,0 +1,34 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSeverityColor(t *testing.T) {
+	assert.Equal(t, color.FgMagenta, SeverityColor("CRITICAL"))
+	assert.Equal(t, color.FgRed, SeverityColor("error"))
+	assert.Equal(t, color.FgYellow, SeverityColor(" Warn "))
+	assert.Equal(t, color.FgBlue, SeverityColor("INFO"))
+	assert.Equal(t, color.FgHiBlack, SeverityColor("debug"))
+	assert.Equal(t, color.Reset, SeverityColor("NOTICE"))
+}
+
+func TestColumnSeverityColors(t *testing.T) {
+	table, err := NewTable([]ColumnDef{
+		NewColumnDef("Level").WithSeverityColors(),
+		NewColumnDef("Message"),
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("ERROR", "disk full"))
+
+	columnDefs := table.resolvedColumnDefs()
+	renderer := table.newRowRenderer(columnDefs, []int{5, 9})
+	row := table.renderedRows(columnDefs)[0]
+	assert.DeepEqual(
+		t,
+		[]color.Attribute{color.FgRed, color.FgGreen},
+		renderer.cellColors(row, rowColors))
+}
@@ -0,0 +1,124 @@
+package pretty
+
+// ShrinkStrategy is how columns are narrowed to fit a table in its maximum
+// width. See Table.SetMaxWidth.
+type ShrinkStrategy uint
+
+const (
+	// ShrinkLongestFirst narrows the widest column, one character at a
+	// time, so that long values are truncated before short ones. It is the
+	// default.
+	ShrinkLongestFirst ShrinkStrategy = iota
+	// ShrinkProportional narrows each column in proportion to its width.
+	ShrinkProportional ShrinkStrategy = iota
+	// ShrinkEqual narrows every column by the same amount.
+	ShrinkEqual ShrinkStrategy = iota
+	// ShrinkWeighted narrows each column in proportion to its shrink weight,
+	// set with ColumnDef.WithShrinkWeight, so that important columns keep
+	// their width.
+	ShrinkWeighted ShrinkStrategy = iota
+)
+
+// defaultShrinkWeight is the shrink weight of columns that have none.
+const defaultShrinkWeight = 1
+
+// SetShrinkStrategy sets how columns are narrowed when the table is wider
+// than its maximum width.
+func (table *Table) SetShrinkStrategy(strategy ShrinkStrategy) {
+	table.shrinkStrategy = strategy
+}
+
+// WithShrinkStrategy sets how columns are narrowed to fit the maximum width.
+// See Table.SetShrinkStrategy.
+func WithShrinkStrategy(strategy ShrinkStrategy) Option {
+	return func(table *Table) error {
+		table.SetShrinkStrategy(strategy)
+		return nil
+	}
+}
+
+// WithShrinkWeight returns a copy of the ColumnDef that gives up width in
+// proportion to weight when the table is shrunk with ShrinkWeighted. Columns
+// have a weight of 1 by default, and columns with a weight of 0 are never
+// shrunk.
+func (columnDef ColumnDef) WithShrinkWeight(weight int) ColumnDef {
+	columnDef.shrinkWeight = &weight
+	return columnDef
+}
+
+// shrink narrows columnSizes to fit the maximum width, according to the
+// shrink strategy.
+func (table *Table) shrink(columnDefs []ColumnDef, columnSizes []int) {
+	floors := table.shrinkFloors(columnDefs)
+	if table.shrinkStrategy == ShrinkLongestFirst {
+		shrinkColumns(columnSizes, table.maxWidth, floors)
+		return
+	}
+
+	weights := make([]int, len(columnSizes))
+	for i, columnDef := range columnDefs {
+		switch table.shrinkStrategy {
+		case ShrinkProportional:
+			weights[i] = columnSizes[i]
+		case ShrinkEqual:
+			weights[i] = defaultShrinkWeight
+		case ShrinkWeighted:
+			weights[i] = defaultShrinkWeight
+			if columnDef.shrinkWeight != nil {
+				weights[i] = *columnDef.shrinkWeight
+			}
+		}
+	}
+	shrinkColumnsWeighted(columnSizes, table.maxWidth, weights, floors)
+}
+
+// shrinkFloors returns the narrowest each column is shrunk to, which leaves
+// minShrinkWidth columns of content inside its padding.
+func (table *Table) shrinkFloors(columnDefs []ColumnDef) []int {
+	floors := make([]int, len(columnDefs))
+	paddings := table.columnPaddings(columnDefs)
+	for i := range floors {
+		floors[i] = minShrinkWidth
+		if paddings != nil {
+			floors[i] += 2 * paddings[i]
+		}
+	}
+	return floors
+}
+
+// shrinkColumnsWeighted narrows columns one character at a time, each time
+// choosing the column that has given up the least width relative to its
+// weight, until the table fits in maxWidth or no column can be shrunk
+// further. Columns are not shrunk below their floors.
+func shrinkColumnsWeighted(
+	columnSizes []int,
+	maxWidth int,
+	weights []int,
+	floors []int,
+) {
+	width := 1
+	for _, columnSize := range columnSizes {
+		width += columnSize + 3
+	}
+
+	shrunk := make([]int, len(columnSizes))
+	for ; width > maxWidth; width-- {
+		next := -1
+		for i, columnSize := range columnSizes {
+			if columnSize <= floors[i] || weights[i] <= 0 {
+				continue
+			}
+			// Compare shrunk[i]/weights[i] with that of next without
+			// dividing.
+			if next == -1 ||
+				shrunk[i]*weights[next] < shrunk[next]*weights[i] {
+				next = i
+			}
+		}
+		if next == -1 {
+			return
+		}
+		columnSizes[next]--
+		shrunk[next]++
+	}
+}
@@ -0,0 +1,38 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestShrinkStrategies(t *testing.T) {
+	tests := []struct {
+		strategy ShrinkStrategy
+		weights  []int
+		expected []int
+	}{
+		{ShrinkLongestFirst, nil, []int{12, 10, 8}},
+		{ShrinkProportional, nil, []int{16, 8, 6}},
+		{ShrinkEqual, nil, []int{17, 7, 6}},
+		{ShrinkWeighted, []int{0, 1, 3}, []int{20, 6, 4}},
+	}
+	for _, test := range tests {
+		columnDefs := make([]ColumnDef, 3)
+		for i := range columnDefs {
+			columnDef := NewColumnDef("")
+			if test.weights != nil {
+				columnDef = columnDef.WithShrinkWeight(test.weights[i])
+			}
+			columnDefs[i] = columnDef
+		}
+		table := &Table{
+			columnDefs:     columnDefs,
+			maxWidth:       40,
+			shrinkStrategy: test.strategy,
+		}
+		columnSizes := []int{20, 10, 8}
+		table.shrink(columnDefs, columnSizes)
+		assert.DeepEqual(t, test.expected, columnSizes)
+	}
+}
@@ -0,0 +1,132 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+)
+
+// defaultSideBySideGutter is the default number of spaces between tables laid
+// out by SideBySide.
+const defaultSideBySideGutter = 4
+
+// SideBySide lays out tables next to each other with their tops aligned, for
+// comparisons such as "Before" and "After":
+//
+//	--------          -------
+//	 Before |          After |
+//	+------+-----+    +------+-----+
+//	| Name | CPU |    | Name | CPU |
+//	+------+-----+    +------+-----+
+//	| vm-1 |   2 |    | vm-1 |   4 |
+//	+------+-----+    | vm-2 |   1 |
+//	                  +------+-----+
+//
+// If the tables do not fit in a line together, they are stacked instead,
+// separated by blank lines.
+type SideBySide struct {
+	tables []*Table
+	gutter int
+	width  int
+}
+
+// NewSideBySide creates a SideBySide laying out the given tables, from left
+// to right.
+func NewSideBySide(tables ...*Table) *SideBySide {
+	return &SideBySide{
+		tables: tables,
+		gutter: defaultSideBySideGutter,
+	}
+}
+
+// SetGutter sets the number of spaces between tables, which defaults to 4.
+func (sideBySide *SideBySide) SetGutter(gutter int) {
+	sideBySide.gutter = gutter
+}
+
+// SetWidth sets the width of a line. A width of 0, the default, uses the width
+// of the terminal, or defaultTerminalWidth when not writing to one.
+func (sideBySide *SideBySide) SetWidth(width int) {
+	sideBySide.width = width
+}
+
+// PrettyString renders the tables, laid out as if written to stdout.
+func (sideBySide *SideBySide) PrettyString() (string, error) {
+	return sideBySide.render(os.Stdout)
+}
+
+// Print prints the tables to stdout.
+func (sideBySide *SideBySide) Print() error {
+	return sideBySide.Fprint(os.Stdout)
+}
+
+// Fprint prints the tables to w.
+func (sideBySide *SideBySide) Fprint(w io.Writer) error {
+	output, err := sideBySide.render(w)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(consoleWriter(w), output)
+	return err
+}
+
+// render lays out the tables to fit the width of w if it is a terminal.
+func (sideBySide *SideBySide) render(w io.Writer) (string, error) {
+	width := sideBySide.width
+	if width == 0 {
+		var ok bool
+		if width, ok = terminalWidth(w); !ok {
+			width = defaultTerminalWidth
+		}
+	}
+
+	blocks := make([][]string, len(sideBySide.tables))
+	blockWidths := make([]int, len(sideBySide.tables))
+	blockHeight := 0
+	totalWidth := sideBySide.gutter * (len(sideBySide.tables) - 1)
+	for i, table := range sideBySide.tables {
+		output, err := table.PrettyString()
+		if err != nil {
+			return "", err
+		}
+		blocks[i] = strings.Split(strings.TrimSuffix(output, "\n"), "\n")
+		for _, line := range blocks[i] {
+			if strLengthWithEncoding(line) > blockWidths[i] {
+				blockWidths[i] = strLengthWithEncoding(line)
+			}
+		}
+		if len(blocks[i]) > blockHeight {
+			blockHeight = len(blocks[i])
+		}
+		totalWidth += blockWidths[i]
+	}
+
+	var builder strings.Builder
+	if totalWidth > width {
+		for i, block := range blocks {
+			if i > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(strings.Join(block, "\n") + "\n")
+		}
+		return builder.String(), nil
+	}
+
+	gutter := strings.Repeat(" ", sideBySide.gutter)
+	for row := 0; row < blockHeight; row++ {
+		line := ""
+		for i, block := range blocks {
+			if i > 0 {
+				line += gutter
+			}
+			blockLine := ""
+			if row < len(block) {
+				blockLine = block[row]
+			}
+			line += Pad(blockLine, blockWidths[i], LeftJustify)
+		}
+		builder.WriteString(strings.TrimRight(line, " "))
+		builder.WriteString("\n")
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,64 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSideBySide(t *testing.T) {
+	before, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("CPU")},
+		WithHeader("Before"),
+		WithColor(false))
+	assert.Nil(t, err)
+	assert.Nil(t, before.AddRow("vm-1", "2"))
+	after, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("CPU")},
+		WithHeader("After"),
+		WithColor(false))
+	assert.Nil(t, err)
+	assert.Nil(t, after.AddRow("vm-1", "4"))
+	assert.Nil(t, after.AddRow("vm-2", "1"))
+
+	sideBySide := NewSideBySide(before, after)
+	var buffer bytes.Buffer
+	assert.Nil(t, sideBySide.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"--------          -------\n"+
+			" Before |          After |\n"+
+			"+------+-----+    +------+-----+\n"+
+			"| Name | CPU |    | Name | CPU |\n"+
+			"+------+-----+    +------+-----+\n"+
+			"| vm-1 |   2 |    | vm-1 |   4 |\n"+
+			"+------+-----+    | vm-2 |   1 |\n"+
+			"                  +------+-----+\n",
+		buffer.String())
+
+	// Tables that do not fit are stacked.
+	sideBySide.SetGutter(1)
+	sideBySide.SetWidth(28)
+	buffer.Reset()
+	assert.Nil(t, sideBySide.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"--------\n"+
+			" Before |\n"+
+			"+------+-----+\n"+
+			"| Name | CPU |\n"+
+			"+------+-----+\n"+
+			"| vm-1 |   2 |\n"+
+			"+------+-----+\n"+
+			"\n"+
+			"-------\n"+
+			" After |\n"+
+			"+------+-----+\n"+
+			"| Name | CPU |\n"+
+			"+------+-----+\n"+
+			"| vm-1 |   4 |\n"+
+			"| vm-2 |   1 |\n"+
+			"+------+-----+\n",
+		buffer.String())
+}
@@ -0,0 +1,36 @@
+//go:build go1.21
+
+package pretty
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+)
+
+// LogValue implements slog.LogValuer, so that a table logged as an attribute
+// is written as a compact snapshot: a line per row, with the columns aligned
+// and separated by two spaces, and without borders or colors.
+//
+//	logger.Info("rebalanced", "nodes", table)
+//
+// If the table cannot be rendered, the error is logged instead.
+func (table *Table) LogValue() slog.Value {
+	var buffer bytes.Buffer
+	if err := table.WriteFormat(&buffer, FormatTSV); err != nil {
+		return slog.AnyValue(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+	rows := make([][]string, len(lines))
+	for i, line := range lines {
+		rows[i] = strings.Split(line, "\t")
+	}
+	return slog.StringValue(strings.Join(AlignColumns(rows), "\n"))
+}
+
+// LogValue implements slog.LogValuer. See Table.LogValue.
+func (syncTable *SyncTable) LogValue() slog.Value {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.LogValue()
+}
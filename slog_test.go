@@ -0,0 +1,44 @@
+//go:build go1.21
+
+package pretty
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestLogValue(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Node"),
+		NewColumnDef("Status"))
+	assert.Nil(t, err)
+	table.SetColor(true)
+	assert.Nil(t, table.AddRow("node-1", "OK"))
+	assert.Nil(t, table.AddRow("node-10", "DEGRADED"))
+
+	assert.Equal(
+		t,
+		"Node     Status\n"+
+			"node-1   OK\n"+
+			"node-10  DEGRADED",
+		table.LogValue().String())
+
+	var buffer bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buffer, nil))
+	logger.Info("rebalanced", "nodes", NewSyncTable(table))
+	var record struct {
+		Nodes string `json:"nodes"`
+	}
+	assert.Nil(t, json.Unmarshal(buffer.Bytes(), &record))
+	assert.Equal(t, table.LogValue().String(), record.Nodes)
+
+	table.ShowColumnNames(false)
+	assert.Equal(
+		t,
+		"node-1   OK\nnode-10  DEGRADED",
+		table.LogValue().String())
+}
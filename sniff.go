@@ -0,0 +1,169 @@
+package pretty
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ColumnType is the kind of data held by a column, as inferred from its
+// values.
+type ColumnType uint
+
+const (
+	// ColumnTypeText is free-form text.
+	ColumnTypeText ColumnType = iota
+	// ColumnTypeNumber is integer or floating point numbers.
+	ColumnTypeNumber ColumnType = iota
+	// ColumnTypeBool is boolean values such as true, false, T or F.
+	ColumnTypeBool ColumnType = iota
+	// ColumnTypeTime is dates or timestamps.
+	ColumnTypeTime ColumnType = iota
+)
+
+// sniffTimeLayout is a layout recognized as ColumnTypeTime.
+type sniffTimeLayout struct {
+	layout string
+	// dateOnly is whether the layout has no time of day, so that its times
+	// are displayed as dates.
+	dateOnly bool
+	// zoneless is whether the layout has no offset, so that its times are
+	// displayed without one.
+	zoneless bool
+}
+
+// sniffTimeLayouts are the layouts recognized as ColumnTypeTime.
+var sniffTimeLayouts = []sniffTimeLayout{
+	{layout: time.RFC3339Nano},
+	{layout: "2006-01-02 15:04:05Z07:00"},
+	{layout: "2006-01-02 15:04:05", zoneless: true},
+	{layout: "2006-01-02T15:04:05", zoneless: true},
+	{layout: time.RFC1123Z},
+	{layout: time.RFC1123},
+	{layout: "2006-01-02", dateOnly: true, zoneless: true},
+}
+
+// SniffColumnType infers the type of a column from its values. Empty values
+// are ignored, and a column is only given a type other than ColumnTypeText if
+// all of its other values parse as that type.
+func SniffColumnType(values []string) ColumnType {
+	isNumber, isBool, isTime := true, true, true
+	seen := false
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		seen = true
+		if isNumber {
+			_, err := strconv.ParseFloat(value, 64)
+			isNumber = err == nil
+		}
+		if isBool {
+			_, err := strconv.ParseBool(value)
+			isBool = err == nil
+		}
+		if isTime {
+			_, _, isTime = parseSniffedTime(value)
+		}
+	}
+
+	switch {
+	case !seen:
+		return ColumnTypeText
+	// Check numbers first, since 0 and 1 are also booleans.
+	case isNumber:
+		return ColumnTypeNumber
+	case isBool:
+		return ColumnTypeBool
+	case isTime:
+		return ColumnTypeTime
+	default:
+		return ColumnTypeText
+	}
+}
+
+// SetTypeSniffing is a configuration, defaulted to false, that can be toggled
+// on to infer the type of each column from its values when rendering. Columns
+// are then aligned by type: text to the left, numbers and times to the right
+// and booleans to the center. Booleans and times are also normalized to a
+// consistent format: times in RFC 3339 format, without an offset if they had
+// none, and dates as they are. Explicit alignments and formatters take
+// precedence.
+func (table *Table) SetTypeSniffing(sniffTypes bool) {
+	table.sniffTypes = sniffTypes
+}
+
+// WithTypeSniffing toggles type sniffing. See Table.SetTypeSniffing.
+func WithTypeSniffing(sniffTypes bool) Option {
+	return func(table *Table) error {
+		table.SetTypeSniffing(sniffTypes)
+		return nil
+	}
+}
+
+// applyTo fills in the alignment and formatter of a column of this type,
+// unless they are already set.
+func (columnType ColumnType) applyTo(columnDef ColumnDef) ColumnDef {
+	var alignment Alignment
+	var formatter Formatter
+	switch columnType {
+	case ColumnTypeNumber:
+		alignment = RightJustify
+	case ColumnTypeBool:
+		alignment = CenterJustify
+		formatter = formatSniffedBool
+	case ColumnTypeTime:
+		alignment = RightJustify
+		formatter = formatSniffedTime
+	default:
+		alignment = LeftJustify
+	}
+
+	if columnDef.alignment == nil {
+		columnDef.alignment = &alignment
+	}
+	if columnDef.formatter == nil {
+		columnDef.formatter = formatter
+	}
+	return columnDef
+}
+
+// parseSniffedTime parses value in the first sniffed layout it matches, and
+// returns that layout. Times without an offset are parsed as UTC.
+func parseSniffedTime(value string) (time.Time, sniffTimeLayout, bool) {
+	for _, candidate := range sniffTimeLayouts {
+		t, err := time.Parse(candidate.layout, value)
+		if err == nil {
+			return t, candidate, true
+		}
+	}
+	return time.Time{}, sniffTimeLayout{}, false
+}
+
+func formatSniffedBool(value interface{}) string {
+	str := formatValue(value)
+	b, err := strconv.ParseBool(strings.TrimSpace(str))
+	if err != nil {
+		return str
+	}
+	return strconv.FormatBool(b)
+}
+
+func formatSniffedTime(value interface{}) string {
+	if t, ok := value.(time.Time); ok {
+		// Times converted to a time zone are formatted as they are.
+		return t.Format(time.RFC3339)
+	}
+	str := formatValue(value)
+	t, layout, ok := parseSniffedTime(strings.TrimSpace(str))
+	switch {
+	case !ok:
+		return str
+	case layout.dateOnly:
+		return t.Format("2006-01-02")
+	case layout.zoneless:
+		return t.Format("2006-01-02T15:04:05")
+	}
+	return t.Format(time.RFC3339)
+}
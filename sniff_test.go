@@ -0,0 +1,34 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSniffColumnType(t *testing.T) {
+	assert.Equal(t, ColumnTypeNumber, SniffColumnType([]string{"1", "0", ""}))
+	assert.Equal(t, ColumnTypeNumber, SniffColumnType([]string{"-2.5", "1e3"}))
+	assert.Equal(t, ColumnTypeBool, SniffColumnType([]string{"true", "F"}))
+	assert.Equal(
+		t,
+		ColumnTypeTime,
+		SniffColumnType([]string{"2018-04-01", "2018-04-01T12:30:00Z"}))
+	assert.Equal(t, ColumnTypeText, SniffColumnType([]string{"1", "one"}))
+	assert.Equal(t, ColumnTypeText, SniffColumnType([]string{"", " "}))
+}
+
+func TestTableFromCSVWithTypeSniffing(t *testing.T) {
+	const inventoryCSV = `Name,Disks,Protected,Last Backup
+web-1,2,TRUE,2018-04-01T12:30:00Z
+build-1,12,f,2018-04-02 08:00:00
+db-1,,1,2018-04-03
+`
+	table, err := TableFromCSV(
+		strings.NewReader(inventoryCSV),
+		true,
+		WithTypeSniffing(true))
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_with_type_sniffing.txt")
+}
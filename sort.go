@@ -0,0 +1,135 @@
+package pretty
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// SortBy sorts the rows of the table by the values of the named column. Values
+// that are both numeric are compared as numbers, and all others as strings.
+// Rows with equal values keep their relative order.
+func (table *Table) SortBy(column string, descending bool) error {
+	if err := table.loadSpilledRows(); err != nil {
+		return err
+	}
+	index, err := table.columnIndex(column)
+	if err != nil {
+		return err
+	}
+
+	values := make([]string, len(table.rows))
+	order := make([]int, len(table.rows))
+	for i := range table.rows {
+		values[i] = table.cellValue(i, index)
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		comparison := compareValues(values[order[i]], values[order[j]])
+		if descending {
+			return comparison > 0
+		}
+		return comparison < 0
+	})
+
+	table.reorderRows(order)
+	table.sortColumn = column
+	table.sortDescending = descending
+	return nil
+}
+
+// ShowSortIndicator toggles an arrow next to the name of the column the table
+// was last sorted by with SortBy: ▲ for ascending order and ▼ for
+// descending, or ^ and v with ASCII borders.
+func (table *Table) ShowSortIndicator(show bool) {
+	table.showSortIndicator = show
+}
+
+// WithSortIndicator toggles the arrow next to the name of the sorted column.
+// See Table.ShowSortIndicator.
+func WithSortIndicator(show bool) Option {
+	return func(table *Table) error {
+		table.ShowSortIndicator(show)
+		return nil
+	}
+}
+
+// displayedColumnName returns the name of a column as shown above its values,
+// with its unit and the sort indicator if it is the sorted column.
+func (table *Table) displayedColumnName(columnDef ColumnDef) string {
+	label := table.columnLabel(columnDef)
+	if !table.showSortIndicator || table.sortColumn != columnDef.name {
+		return label
+	}
+	ascii := isASCII(table.borderStyle().Vertical)
+	switch {
+	case table.sortDescending && ascii:
+		return label + " v"
+	case table.sortDescending:
+		return label + " ▼"
+	case ascii:
+		return label + " ^"
+	default:
+		return label + " ▲"
+	}
+}
+
+// reorderRows rearranges the rows so that row i is the row previously at
+// order[i], carrying along any per-row settings.
+func (table *Table) reorderRows(order []int) {
+	rows := make([][]string, len(order))
+	var values [][]interface{}
+	if table.values != nil {
+		values = make([][]interface{}, len(order))
+	}
+	var rowColorOverrides map[int]color.Attribute
+	if table.rowColorOverrides != nil {
+		rowColorOverrides = make(map[int]color.Attribute)
+	}
+	var rowChanges map[int]RowChange
+	if table.rowChanges != nil {
+		rowChanges = make(map[int]RowChange)
+	}
+	var rowAnnotations map[int]string
+	if table.rowAnnotations != nil {
+		rowAnnotations = make(map[int]string)
+	}
+	for i, previous := range order {
+		rows[i] = table.rows[previous]
+		if values != nil {
+			values[i] = table.rowValues(previous)
+		}
+		if override, ok := table.rowColorOverrides[previous]; ok {
+			rowColorOverrides[i] = override
+		}
+		if change, ok := table.rowChanges[previous]; ok {
+			rowChanges[i] = change
+		}
+		if annotation, ok := table.rowAnnotations[previous]; ok {
+			rowAnnotations[i] = annotation
+		}
+	}
+	table.rows = rows
+	table.values = values
+	table.rowColorOverrides = rowColorOverrides
+	table.rowChanges = rowChanges
+	table.rowAnnotations = rowAnnotations
+}
+
+// compareValues compares two cell values, numerically if both are numbers.
+func compareValues(a string, b string) int {
+	aNumber, aOk, aErr := parseNumeric(a)
+	bNumber, bOk, bErr := parseNumeric(b)
+	if aOk && bOk && aErr == nil && bErr == nil {
+		switch {
+		case aNumber < bNumber:
+			return -1
+		case aNumber > bNumber:
+			return 1
+		default:
+			return 0
+		}
+	}
+	return strings.Compare(a, b)
+}
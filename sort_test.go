@@ -0,0 +1,54 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSortIndicator(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Size")},
+		WithColor(false),
+		WithSortIndicator(true),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{{"a", "10"}, {"b", "9"}}))
+
+	assert.Nil(t, table.SortBy("Size", false))
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+--------+\n"+
+			"| Name | Size ^ |\n"+
+			"+------+--------+\n"+
+			"|    b |      9 |\n"+
+			"|    a |     10 |\n"+
+			"+------+--------+\n",
+		rendered,
+	)
+
+	assert.Nil(t, table.SortBy("Name", true))
+	table.SetBorderStyle(BorderLight)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"┌────────┬──────┐\n"+
+			"│ Name ▼ │ Size │\n"+
+			"├────────┼──────┤\n"+
+			"│      b │    9 │\n"+
+			"│      a │   10 │\n"+
+			"└────────┴──────┘\n",
+		rendered,
+	)
+
+	assert.Nil(t, table.SetRows([][]string{{"c", "1"}}))
+	assert.DeepEqual(t, []string{"Name", "Size"}, table.ColumnNames())
+	assert.EqualString(
+		t,
+		"Name",
+		table.displayedColumnName(table.columnDefs[0]),
+	)
+}
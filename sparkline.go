@@ -0,0 +1,113 @@
+package pretty
+
+import (
+	"math"
+	"reflect"
+	"strings"
+)
+
+// sparkLevels are the characters of a sparkline, from lowest to highest.
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders a series of numbers as a line of block characters whose
+// heights are scaled between the smallest and largest value, such as
+// "▁▂▃▅▇". A series of equal values is drawn at the lowest level, and NaN
+// and infinite values are drawn as spaces.
+func Sparkline(values []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, value := range values {
+		if !isFinite(value) {
+			continue
+		}
+		low = math.Min(low, value)
+		high = math.Max(high, value)
+	}
+
+	var builder strings.Builder
+	for _, value := range values {
+		if !isFinite(value) {
+			builder.WriteRune(' ')
+			continue
+		}
+		level := 0
+		if high > low {
+			level = int(math.Round(
+				(value - low) / (high - low) * float64(len(sparkLevels)-1)))
+		}
+		builder.WriteRune(sparkLevels[level])
+	}
+	return builder.String()
+}
+
+// FormatSparkline is a Formatter that renders a series of numbers as a
+// Sparkline, so that a trend can be shown in a single cell. Values may be
+// slices or arrays of any numeric type, or strings of numbers separated by
+// spaces or commas, as stored by AddRow. Other values are formatted with
+// fmt.Sprint.
+func FormatSparkline(value interface{}) string {
+	series, ok := numericSeries(value)
+	if !ok {
+		return formatValue(value)
+	}
+	return Sparkline(series)
+}
+
+// numericSeries converts a slice or array of numbers, or a string of numbers
+// separated by spaces or commas, into a series of float64. It returns false if
+// any element is not numeric.
+func numericSeries(value interface{}) ([]float64, bool) {
+	if str, ok := value.(string); ok {
+		fields := strings.FieldsFunc(str, func(r rune) bool {
+			return r == ',' || r == ' ' || r == '\t'
+		})
+		series := make([]float64, 0, len(fields))
+		for _, field := range fields {
+			number, ok, err := parseNumeric(field)
+			if !ok || err != nil {
+				return nil, false
+			}
+			series = append(series, number)
+		}
+		return series, true
+	}
+
+	reflected := reflect.ValueOf(value)
+	if kind := reflected.Kind(); kind != reflect.Slice && kind != reflect.Array {
+		return nil, false
+	}
+	series := make([]float64, reflected.Len())
+	for i := range series {
+		number, ok := numericValue(reflected.Index(i))
+		if !ok {
+			return nil, false
+		}
+		series[i] = number
+	}
+	return series, true
+}
+
+// numericValue converts a value of any integer or floating point kind, or an
+// interface or pointer holding one, into a float64.
+func numericValue(value reflect.Value) (float64, bool) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return 0, false
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32,
+		reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// isFinite reports whether value is neither NaN nor infinite.
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,42 @@
+package pretty
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.EqualString(t, "▁▂▄▅▇█", Sparkline([]float64{0, 1, 2, 3, 4, 5}))
+	assert.EqualString(t, "█▁ ▁", Sparkline([]float64{10, 3, math.NaN(), 3}))
+	assert.EqualString(t, "▁▁▁", Sparkline([]float64{7, 7, 7}))
+	assert.EqualString(t, "", Sparkline(nil))
+	assert.EqualString(
+		t,
+		"▁ █ ",
+		Sparkline([]float64{1, math.Inf(1), 2, math.Inf(-1)}))
+}
+
+func TestFormatSparkline(t *testing.T) {
+	assert.EqualString(t, "▁▅█", FormatSparkline([]int{1, 2, 3}))
+	assert.EqualString(t, "▁▅█", FormatSparkline([3]float32{1, 2, 3}))
+	assert.EqualString(t, "▁▅█", FormatSparkline("1, 2, 3"))
+	assert.EqualString(t, "1, a", FormatSparkline("1, a"))
+	assert.EqualString(t, "▁█ ", FormatSparkline("1 2 +Inf"))
+	assert.EqualString(t, "[a b]", FormatSparkline([]string{"a", "b"}))
+	assert.EqualString(t, "", FormatSparkline(nil))
+
+	table, err := NewPrettyTable(
+		NewColumnDef("Name").WithAlignment(LeftJustify),
+		NewColumnDef("Trend").WithFormatter(FormatSparkline))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	assert.Nil(t, table.AddValues("vm-1", []int64{5, 4, 3, 2, 1}))
+	assert.Nil(t, table.AddRow("vm-2", "1 3 2 4"))
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(output, "| vm-1 | █▆▅▃▁ |"))
+	assert.True(t, strings.Contains(output, "| vm-2 |  ▁▆▃█ |"))
+}
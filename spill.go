@@ -0,0 +1,278 @@
+package pretty
+
+import (
+	"bufio"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// rowSpill holds the rows of a table that have been written to a temporary
+// file to limit memory use. Spilled rows come before the rows in memory.
+type rowSpill struct {
+	threshold int
+	file      *os.File
+	writer    *bufio.Writer
+	encoder   *gob.Encoder
+	rowCount  int
+	// widths is the widest stored value of each column among the spilled
+	// rows.
+	widths []int
+	// failed is set once writing a batch of rows fails, after which the
+	// file may end with part of that batch. Only the rowCount rows before it
+	// are read back, and later rows are kept in memory.
+	failed bool
+}
+
+// SetSpillThreshold limits the number of rows kept in memory. Once the table
+// holds threshold rows, they are written to a temporary file and read back
+// one at a time when the table is rendered, so that tables too large for
+// memory can still be printed. Rows added with AddValues are converted to
+// strings when spilled, and type sniffing only considers the rows in memory.
+//
+// Operations that need every row at once, such as SortBy, SelectColumns or
+// WriteFormat with a format other than FormatTable, first read the spilled
+// rows back into memory. A threshold of 0 disables spilling, reading back any
+// spilled rows. The temporary file is removed by Close. If writing to it
+// fails, the error is returned once, and later rows are kept in memory.
+//
+// The temporary file can only be read by the current user, but it holds the
+// values as they are stored, before formatting, so the values of columns
+// hidden with FormatRedacted are written to disk in full.
+func (table *Table) SetSpillThreshold(threshold int) error {
+	if threshold < 0 {
+		return fmt.Errorf("spill threshold %d must not be negative", threshold)
+	}
+	if threshold == 0 {
+		if err := table.loadSpilledRows(); err != nil {
+			return err
+		}
+		table.spill = nil
+		return nil
+	}
+
+	if table.spill == nil {
+		table.spill = &rowSpill{}
+	}
+	table.spill.threshold = threshold
+	return table.spillRows()
+}
+
+// WithSpillThreshold limits the number of rows kept in memory. See
+// Table.SetSpillThreshold.
+func WithSpillThreshold(threshold int) Option {
+	return func(table *Table) error {
+		return table.SetSpillThreshold(threshold)
+	}
+}
+
+// Close removes the temporary file holding any rows spilled to disk, which
+// are lost. It does nothing for tables that do not spill rows.
+func (table *Table) Close() error {
+	if table.spill == nil {
+		return nil
+	}
+	err := table.spill.discard()
+	table.spill = nil
+	return err
+}
+
+// rowCount returns the number of rows in the table, including spilled rows.
+func (table *Table) rowCount() int {
+	if table.spill == nil {
+		return len(table.rows)
+	}
+	return table.spill.rowCount + len(table.rows)
+}
+
+// spilledRowCount returns the number of rows written to disk, which precede
+// the rows in memory.
+func (table *Table) spilledRowCount() int {
+	if table.spill == nil {
+		return 0
+	}
+	return table.spill.rowCount
+}
+
+// spillRows writes the rows in memory to disk if there are at least as many
+// as the spill threshold.
+func (table *Table) spillRows() error {
+	spill := table.spill
+	if spill == nil || spill.failed || len(table.rows) < spill.threshold {
+		return nil
+	}
+
+	if spill.file == nil {
+		// CreateTemp creates the file with permissions 0600.
+		file, err := os.CreateTemp("", "pretty-spill-")
+		if err != nil {
+			return fmt.Errorf("cannot spill rows: %v", err)
+		}
+		spill.file = file
+		spill.writer = bufio.NewWriter(file)
+		spill.encoder = gob.NewEncoder(spill.writer)
+		spill.widths = make([]int, len(table.columnDefs))
+	}
+
+	// The batch is flushed, so that a failure leaves the earlier batches
+	// complete on disk, and the rows stay in memory until it succeeds.
+	widths := append([]int(nil), spill.widths...)
+	for i, row := range table.rows {
+		if values := table.rowValues(i); values != nil {
+			row = make([]string, len(table.columnDefs))
+			for j, columnDef := range table.columnDefs {
+				if columnDef.derive == nil {
+					row[j] = formatValue(values[j])
+				}
+			}
+		}
+		if err := spill.encoder.Encode(row); err != nil {
+			spill.failed = true
+			return fmt.Errorf("cannot spill rows: %v", err)
+		}
+		for j, value := range row {
+			if width := strLengthWithEncoding(value); width > widths[j] {
+				widths[j] = width
+			}
+		}
+	}
+	if err := spill.writer.Flush(); err != nil {
+		spill.failed = true
+		return fmt.Errorf("cannot spill rows: %v", err)
+	}
+
+	spill.widths = widths
+	spill.rowCount += len(table.rows)
+	table.rows = make([][]string, 0, spill.threshold)
+	table.values = nil
+	table.resetColumnWidths()
+	return nil
+}
+
+// loadSpilledRows reads any spilled rows back into memory, for operations
+// that need every row at once.
+func (table *Table) loadSpilledRows() error {
+	spill := table.spill
+	if spill == nil || spill.rowCount == 0 {
+		return nil
+	}
+
+	rows := make([][]string, 0, spill.rowCount+len(table.rows))
+	err := spill.each(func(row []string) error {
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if table.values != nil {
+		table.values = append(
+			make([][]interface{}, spill.rowCount),
+			table.values...)
+	}
+	table.rows = append(rows, table.rows...)
+	table.resetColumnWidths()
+	return spill.discard()
+}
+
+// each calls f with every spilled row, in order.
+func (spill *rowSpill) each(f func(row []string) error) error {
+	if spill.rowCount == 0 {
+		return nil
+	}
+
+	// Read through a separate reader, leaving the file offset at the end for
+	// further writes.
+	reader := io.NewSectionReader(spill.file, 0, math.MaxInt64)
+	decoder := gob.NewDecoder(bufio.NewReader(reader))
+	for i := 0; i < spill.rowCount; i++ {
+		var row []string
+		if err := decoder.Decode(&row); err != nil {
+			return fmt.Errorf("cannot read spilled rows: %v", err)
+		}
+		if err := f(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// discard removes the spilled rows, keeping the threshold.
+func (spill *rowSpill) discard() error {
+	file := spill.file
+	*spill = rowSpill{threshold: spill.threshold}
+	if file == nil {
+		return nil
+	}
+	err := file.Close()
+	if removeErr := os.Remove(file.Name()); err == nil {
+		err = removeErr
+	}
+	return err
+}
+
+// measureSpilledRows returns the width of each column needed to fit the
+// spilled rows.
+func (table *Table) measureSpilledRows(columnDefs []ColumnDef) ([]int, error) {
+	spill := table.spill
+	widths := make([]int, len(columnDefs))
+	copy(widths, spill.widths)
+
+	// Sanitized and expanded values may be wider than the stored ones.
+	storedDisplayed := table.sanitization == SanitizeNone &&
+		!table.expandsHyperlinks()
+	var renderedColumns []int
+	for i, columnDef := range columnDefs {
+		if !columnDef.isPlain() || !storedDisplayed {
+			renderedColumns = append(renderedColumns, i)
+			widths[i] = 0
+		}
+	}
+	if len(renderedColumns) == 0 {
+		return widths, nil
+	}
+
+	// The displayed values of other columns must be computed to be measured.
+	err := spill.each(func(row []string) error {
+		rendered := table.renderStoredRow(columnDefs, row, nil)
+		for _, i := range renderedColumns {
+			if width := strLengthWithEncoding(rendered[i]); width > widths[i] {
+				widths[i] = width
+			}
+		}
+		return nil
+	})
+	return widths, err
+}
+
+// renderSpilledRows writes the spilled rows, which are the first rows of the
+// table.
+func (table *Table) renderSpilledRows(
+	ctx context.Context,
+	w renderWriter,
+	renderer *rowRenderer,
+	columnDefs []ColumnDef,
+	justifications []Alignment,
+) error {
+	index := 0
+	return table.spill.each(func(row []string) error {
+		rendered := table.renderStoredRow(columnDefs, row, nil)
+		table.recordTruncations(
+			columnDefs,
+			[][]string{rendered},
+			index,
+			renderer)
+		err := table.renderRows(
+			ctx,
+			w,
+			renderer,
+			[][]string{rendered},
+			index,
+			justifications)
+		index++
+		return err
+	})
+}
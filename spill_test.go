@@ -0,0 +1,178 @@
+package pretty
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/gob"
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSpilledTableMatchesInMemory(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetHeader("Employees")
+	table.ShowRowCount(true)
+	table.SetColor(true)
+	table.rowColorOverrides = map[int]color.Attribute{1: color.FgRed}
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	// Spill every 3 rows, leaving the last row in memory.
+	spilled, err := NewTable(
+		table.columnDefs,
+		WithHeader("Employees"),
+		WithRowCount(true),
+		WithColor(true),
+		WithSpillThreshold(3))
+	assert.Nil(t, err)
+	defer spilled.Close()
+	spilled.rowColorOverrides = map[int]color.Attribute{1: color.FgRed}
+	for _, row := range table.rows {
+		assert.Nil(t, spilled.AddRow(row...))
+	}
+	assert.EqualInt(t, 3, spilled.spilledRowCount())
+	assert.EqualInt(t, 1, len(spilled.rows))
+
+	output, err := spilled.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, output)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, spilled.Fprint(&buffer))
+	assert.EqualString(t, expected+"\n", buffer.String())
+}
+
+func TestSpilledTableWithRenderedColumns(t *testing.T) {
+	columnDefs := []ColumnDef{
+		NewColumnDef("Name").WithFormatter(func(value interface{}) string {
+			return strings.ToUpper(value.(string))
+		}),
+		NewColumnDef("Size"),
+		NewDerivedColumnDef("Label", func(row map[string]string) string {
+			return row["Name"] + "-" + row["Size"]
+		}),
+	}
+	table, err := NewTable(columnDefs, WithColor(false))
+	assert.Nil(t, err)
+	spilled, err := NewTable(
+		columnDefs,
+		WithColor(false),
+		WithSpillThreshold(2))
+	assert.Nil(t, err)
+	defer spilled.Close()
+
+	for _, tbl := range []*Table{table, spilled} {
+		assert.Nil(t, tbl.AddRow("alpha", "1"))
+		assert.Nil(t, tbl.AddValues("a much longer name", 20))
+		assert.Nil(t, tbl.AddRow("c", "300"))
+	}
+	assert.EqualInt(t, 2, spilled.spilledRowCount())
+
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+	output, err := spilled.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, output)
+}
+
+func TestSpilledTableWithPrefix(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Price").WithPrefix("USD $")},
+		WithColor(false),
+		WithSpillThreshold(1))
+	assert.Nil(t, err)
+	defer table.Close()
+	assert.Nil(t, table.AddRow("1"))
+	assert.Nil(t, table.AddRow("22"))
+	assert.Nil(t, table.AddRow("333"))
+	assert.EqualInt(t, 3, table.spilledRowCount())
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+----------+\n"+
+			"| Price    |\n"+
+			"+----------+\n"+
+			"|   USD $1 |\n"+
+			"|  USD $22 |\n"+
+			"| USD $333 |\n"+
+			"+----------+\n",
+		output)
+}
+
+func TestSpillFileIsPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions are not supported on Windows")
+	}
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Token").WithFormatter(
+			FormatRedacted(RedactFull))},
+		WithSpillThreshold(1))
+	assert.Nil(t, err)
+	defer table.Close()
+	assert.Nil(t, table.AddRow("secret"))
+
+	info, err := os.Stat(table.spill.file.Name())
+	assert.Nil(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+}
+
+func TestSpilledRowsReadBack(t *testing.T) {
+	table := createBasicTable(t)
+	assert.NotNil(t, table.SetSpillThreshold(-1))
+	assert.Nil(t, table.SetSpillThreshold(2))
+	fileName := table.spill.file.Name()
+
+	// Sorting reads every row back into memory and removes the file.
+	assert.Nil(t, table.SortBy("Name", false))
+	assert.EqualInt(t, 0, table.spilledRowCount())
+	_, err := os.Stat(fileName)
+	assert.True(t, os.IsNotExist(err))
+	assertExpectedTable(t, table, "basic_table_sorted_by_name.txt")
+
+	// Adding a row spills the table again, until it is closed.
+	assert.Nil(t, table.AddRow("7", "Zed", "Human", "555-0100"))
+	assert.EqualInt(t, 5, table.spilledRowCount())
+	fileName = table.spill.file.Name()
+	assert.Nil(t, table.Close())
+	_, err = os.Stat(fileName)
+	assert.True(t, os.IsNotExist(err))
+	assert.EqualInt(t, 0, table.rowCount())
+}
+
+func TestSpillFailureKeepsRows(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetColor(false)
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	spilled, err := NewTable(
+		table.columnDefs,
+		WithColor(false),
+		WithSpillThreshold(2))
+	assert.Nil(t, err)
+	defer spilled.Close()
+	assert.Nil(t, spilled.AddRows(table.rows[:2]))
+	assert.EqualInt(t, 2, spilled.spilledRowCount())
+
+	// The second batch fails partway, leaving its rows in memory.
+	spill := spilled.spill
+	spill.writer = bufio.NewWriterSize(failingWriter{}, 16)
+	spill.encoder = gob.NewEncoder(spill.writer)
+	assert.Nil(t, spilled.AddRow(table.rows[2]...))
+	assert.NotNil(t, spilled.AddRow(table.rows[3]...))
+	assert.EqualInt(t, 2, spilled.spilledRowCount())
+	assert.EqualInt(t, 4, spilled.rowCount())
+
+	output, err := spilled.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, output)
+	assert.Nil(t, spilled.AddRow("5", "Eve", "Human", "555-0100"))
+	assert.EqualInt(t, 3, len(spilled.rows))
+}
@@ -0,0 +1,147 @@
+package pretty
+
+import "context"
+
+// SetStacked toggles stacked mode, in which a table wider than its maximum
+// width is split into several tables printed one above the other, like the
+// output of vmstat or sar, instead of having its values truncated. Each
+// segment starts with the given key columns, such as a name or timestamp,
+// so that its rows can be told apart, followed by as many of the other
+// columns as fit. When printing to a terminal, tables without a maximum width
+// are split at the width of the terminal.
+func (table *Table) SetStacked(stacked bool, keyColumns ...string) error {
+	for _, column := range keyColumns {
+		if _, err := table.columnIndex(column); err != nil {
+			return err
+		}
+	}
+	table.stacked = stacked
+	table.stackKeyColumns = keyColumns
+	return nil
+}
+
+// WithStacked turns on stacked mode with the given key columns. See
+// Table.SetStacked.
+func WithStacked(keyColumns ...string) Option {
+	return func(table *Table) error {
+		return table.SetStacked(true, keyColumns...)
+	}
+}
+
+// renderStacked writes the table as stacked segments if it is wider than its
+// maximum width, and reports whether it did.
+func (table *Table) renderStacked(
+	ctx context.Context,
+	w renderWriter,
+) (bool, error) {
+	if err := table.loadSpilledRows(); err != nil {
+		return false, err
+	}
+	segments, err := table.stackSegments()
+	if err != nil || len(segments) <= 1 {
+		return false, err
+	}
+
+	var truncations []Truncation
+	for i, segment := range segments {
+		if i > 0 {
+			w.WriteString("\n")
+		}
+		err := table.renderSegment(ctx, w, segment, i, len(segments))
+		if err != nil {
+			return true, err
+		}
+		truncations = append(truncations, table.truncations...)
+	}
+	table.truncations = truncations
+	return true, nil
+}
+
+// stackSegments returns the indices of the columns of each segment, starting
+// with the key columns, that keep each segment within the maximum width.
+func (table *Table) stackSegments() ([][]int, error) {
+	keys := make([]int, len(table.stackKeyColumns))
+	isKey := make(map[int]bool, len(keys))
+	for i, column := range table.stackKeyColumns {
+		index, err := table.columnIndex(column)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = index
+		isKey[index] = true
+	}
+
+	// Measure the columns as they would be without a maximum width.
+	maxWidth := table.maxWidth
+	table.maxWidth = 0
+	columnDefs := table.resolvedColumnDefs()
+	columnSizes := table.columnSizes(
+		columnDefs,
+		table.renderedRows(columnDefs),
+		nil)
+	table.maxWidth = maxWidth
+
+	// Each column is padded by a space on each side and followed by a
+	// border, plus the border at the start of the row.
+	keysWidth := 1
+	for _, index := range keys {
+		keysWidth += columnSizes[index] + 3
+	}
+	var segments [][]int
+	segment := keys
+	width := keysWidth
+	for index, columnSize := range columnSizes {
+		if isKey[index] {
+			continue
+		}
+		if len(segment) > len(keys) && width+columnSize+3 > maxWidth {
+			segments = append(segments, segment)
+			segment, width = keys, keysWidth
+		}
+		segment = append(segment[:len(segment):len(segment)], index)
+		width += columnSize + 3
+	}
+	return append(segments, segment), nil
+}
+
+// renderSegment renders the given columns of the table as the segment at
+// index of count segments. Only the first has the header and only the last
+// has the row count and footnotes.
+func (table *Table) renderSegment(
+	ctx context.Context,
+	w renderWriter,
+	columns []int,
+	index int,
+	count int,
+) error {
+	segment := *table
+	segment.stacked = false
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = table.columnDefs[column].name
+	}
+	if err := segment.SelectColumns(names...); err != nil {
+		return err
+	}
+	if table.fixedWidths != nil {
+		segment.fixedWidths = make([]int, len(columns))
+		for i, column := range columns {
+			segment.fixedWidths[i] = table.fixedWidths[column]
+		}
+	}
+	if index > 0 {
+		segment.header = nil
+		if table.rowCountPosition == RowCountAbove {
+			segment.shouldPrintRowCount = false
+		}
+	}
+	if index < count-1 {
+		segment.truncationNote = ""
+		if table.rowCountPosition == RowCountBelow {
+			segment.shouldPrintRowCount = false
+		}
+	}
+	err := segment.render(ctx, w)
+	table.truncations = segment.truncations
+	return err
+}
@@ -0,0 +1,77 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestStacked(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Time"),
+			NewColumnDef("CPU"),
+			NewColumnDef("Memory"),
+			NewColumnDef("Disk"),
+		},
+		WithHeader("Usage"),
+		WithColor(false),
+		WithRowCount(true),
+		WithMaxWidth(24),
+		WithStacked("Time"),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"10:00", "12%", "4.1 GiB", "70%"},
+		{"10:01", "9%", "4.2 GiB", "71%"},
+	}))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"-------\n"+
+			" Usage |\n"+
+			"+-------+-----+\n"+
+			"| Time  | CPU |\n"+
+			"+-------+-----+\n"+
+			"| 10:00 | 12% |\n"+
+			"| 10:01 |  9% |\n"+
+			"+-------+-----+\n"+
+			"\n"+
+			"+-------+---------+\n"+
+			"| Time  | Memory  |\n"+
+			"+-------+---------+\n"+
+			"| 10:00 | 4.1 GiB |\n"+
+			"| 10:01 | 4.2 GiB |\n"+
+			"+-------+---------+\n"+
+			"\n"+
+			"+-------+------+\n"+
+			"| Time  | Disk |\n"+
+			"+-------+------+\n"+
+			"| 10:00 |  70% |\n"+
+			"| 10:01 |  71% |\n"+
+			"+-------+------+\n"+
+			"Count: 2\n",
+		rendered,
+	)
+
+	table.SetMaxWidth(0)
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"-------\n"+
+			" Usage |\n"+
+			"+-------+-----+---------+------+\n"+
+			"| Time  | CPU | Memory  | Disk |\n"+
+			"+-------+-----+---------+------+\n"+
+			"| 10:00 | 12% | 4.1 GiB |  70% |\n"+
+			"| 10:01 |  9% | 4.2 GiB |  71% |\n"+
+			"+-------+-----+---------+------+\n"+
+			"Count: 2\n",
+		rendered,
+	)
+
+	assert.NotNil(t, table.SetStacked(true, "Host"))
+}
@@ -0,0 +1,136 @@
+package pretty
+
+import (
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Status is the outcome or state of a task, as shown by status symbols.
+type Status uint
+
+const (
+	// StatusUnknown is a status that is not recognized.
+	StatusUnknown Status = iota
+	// StatusSuccess is shown as a green ✓, or + without Unicode.
+	StatusSuccess
+	// StatusFailure is shown as a red ✗, or x without Unicode.
+	StatusFailure
+	// StatusWarning is shown as a yellow ⚠, or ! without Unicode.
+	StatusWarning
+	// StatusRunning is shown as a cyan ●, or * without Unicode.
+	StatusRunning
+)
+
+// statusNames maps the status strings recognized by ParseStatus, in lower
+// case, to their statuses.
+var statusNames = map[string]Status{
+	"success":     StatusSuccess,
+	"succeeded":   StatusSuccess,
+	"successful":  StatusSuccess,
+	"ok":          StatusSuccess,
+	"pass":        StatusSuccess,
+	"passed":      StatusSuccess,
+	"done":        StatusSuccess,
+	"complete":    StatusSuccess,
+	"completed":   StatusSuccess,
+	"healthy":     StatusSuccess,
+	"failure":     StatusFailure,
+	"fail":        StatusFailure,
+	"failed":      StatusFailure,
+	"error":       StatusFailure,
+	"errored":     StatusFailure,
+	"unhealthy":   StatusFailure,
+	"warning":     StatusWarning,
+	"warn":        StatusWarning,
+	"degraded":    StatusWarning,
+	"running":     StatusRunning,
+	"in progress": StatusRunning,
+	"in_progress": StatusRunning,
+	"pending":     StatusRunning,
+	"queued":      StatusRunning,
+	"starting":    StatusRunning,
+}
+
+var (
+	statusSymbols      = []string{"", "✓", "✗", "⚠", "●"}
+	asciiStatusSymbols = []string{"", "+", "x", "!", "*"}
+	statusColors       = []color.Attribute{
+		color.Reset,
+		color.FgGreen,
+		color.FgRed,
+		color.FgYellow,
+		color.FgCyan,
+	}
+)
+
+// ParseStatus returns the status named by value, such as "succeeded",
+// "FAILED" or "in progress", ignoring case and a leading status symbol.
+func ParseStatus(value string) Status {
+	value = strings.ToLower(strings.TrimSpace(value))
+	for _, symbols := range [][]string{statusSymbols, asciiStatusSymbols} {
+		// The first symbol is that of StatusUnknown, which has none.
+		for _, symbol := range symbols[1:] {
+			if rest, ok := strings.CutPrefix(value, symbol+" "); ok {
+				return statusNames[rest]
+			}
+		}
+	}
+	return statusNames[value]
+}
+
+// Symbol returns the symbol of the status, in ASCII if ascii is true, or an
+// empty string for StatusUnknown.
+func (status Status) Symbol(ascii bool) string {
+	if int(status) >= len(statusSymbols) {
+		return ""
+	}
+	if ascii {
+		return asciiStatusSymbols[status]
+	}
+	return statusSymbols[status]
+}
+
+// Color returns the color of the status, or color.Reset for StatusUnknown.
+func (status Status) Color() color.Attribute {
+	if int(status) >= len(statusColors) {
+		return color.Reset
+	}
+	return statusColors[status]
+}
+
+// FormatStatus is a Formatter that puts the symbol of a recognized status
+// before it, as in "✓ succeeded". Other values are formatted with
+// fmt.Sprint. See ColumnDef.WithStatusSymbols to also color the values and
+// fall back to ASCII symbols.
+func FormatStatus(value interface{}) string {
+	return statusLabel(formatValue(value), false)
+}
+
+// FormatStatusASCII is like FormatStatus with the ASCII symbols, as in
+// "+ succeeded".
+func FormatStatusASCII(value interface{}) string {
+	return statusLabel(formatValue(value), true)
+}
+
+// WithStatusSymbols returns a copy of the ColumnDef whose recognized
+// statuses are shown with their symbols and colors, after any formatter is
+// applied. The symbols are in ASCII if the table's borders are.
+func (columnDef ColumnDef) WithStatusSymbols() ColumnDef {
+	columnDef.statusSymbols = true
+	return columnDef
+}
+
+// statusLabel puts the symbol of the status named by value before it.
+func statusLabel(value string, ascii bool) string {
+	symbol := ParseStatus(value).Symbol(ascii)
+	if symbol == "" || strings.HasPrefix(value, symbol+" ") {
+		return value
+	}
+	return symbol + " " + value
+}
+
+// statusColor returns the color of the status named by value.
+func statusColor(value string) color.Attribute {
+	return ParseStatus(value).Color()
+}
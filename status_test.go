@@ -0,0 +1,67 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestParseStatus(t *testing.T) {
+	assert.Equal(t, StatusSuccess, ParseStatus("Succeeded"))
+	assert.Equal(t, StatusFailure, ParseStatus(" FAILED "))
+	assert.Equal(t, StatusWarning, ParseStatus("⚠ degraded"))
+	assert.Equal(t, StatusRunning, ParseStatus("* in progress"))
+	assert.Equal(t, StatusUnknown, ParseStatus("paused"))
+}
+
+func TestFormatStatus(t *testing.T) {
+	assert.EqualString(t, "✓ ok", FormatStatus("ok"))
+	assert.EqualString(t, "✗ error", FormatStatus("error"))
+	assert.EqualString(t, "✗ error", FormatStatus("✗ error"))
+	assert.EqualString(t, "paused", FormatStatus("paused"))
+	assert.EqualString(t, "* running", FormatStatusASCII("running"))
+}
+
+func TestColumnStatusSymbols(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Job"),
+			NewColumnDef("Status").
+				WithAlignment(LeftJustify).
+				WithStatusSymbols(),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"backup", "succeeded"},
+		{"restore", "failed"},
+		{"archive", "paused"},
+	}))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---------+-------------+\n"+
+			"| Job     | Status      |\n"+
+			"+---------+-------------+\n"+
+			"|  backup | + succeeded |\n"+
+			"| restore | x failed    |\n"+
+			"| archive | paused      |\n"+
+			"+---------+-------------+\n",
+		rendered)
+
+	columnDefs := table.resolvedColumnDefs()
+	renderer := table.newRowRenderer(columnDefs, []int{7, 11})
+	rows := table.renderedRows(columnDefs)
+	assert.DeepEqual(
+		t,
+		[]color.Attribute{color.FgYellow, color.FgRed},
+		renderer.cellColors(rows[1], rowColors))
+	assert.DeepEqual(
+		t,
+		rowColors,
+		renderer.cellColors(rows[2], rowColors))
+}
@@ -0,0 +1,154 @@
+package pretty
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+)
+
+// StreamWriter writes a table to an io.Writer row by row, so that tables too
+// large to hold in memory can still be rendered. Since the column widths must
+// be known before the first row is written, they are either given up front
+// with SetColumnWidths or measured from a sample of the first rows; later
+// rows that are too wide are truncated. For the same reason, the row count
+// can only be shown below the table.
+type StreamWriter struct {
+	table      *Table
+	writer     *bufio.Writer
+	sampleSize int
+
+	columnDefs     []ColumnDef
+	renderer       *rowRenderer
+	justifications []Alignment
+	rowCount       int
+	closed         bool
+}
+
+// NewStreamWriter creates a StreamWriter rendering rows to w with the columns
+// and settings of table, whose own rows are not written. Up to sampleSize
+// rows are buffered to measure the column widths.
+func NewStreamWriter(w io.Writer, table *Table, sampleSize int) *StreamWriter {
+	settings := *table
+	settings.rows = nil
+	settings.values = nil
+	settings.spill = nil
+	settings.rowColorOverrides = nil
+	settings.rowAnnotations = nil
+	settings.resetColumnWidths()
+	if sampleSize < 1 {
+		sampleSize = 1
+	}
+	return &StreamWriter{
+		table:      &settings,
+		writer:     bufio.NewWriter(consoleWriter(w)),
+		sampleSize: sampleSize,
+	}
+}
+
+// SetColumnWidths fixes the width of each column instead of measuring them
+// from a sample, so that every row is written as soon as it is added. The
+// widths do not include the padding of the columns, as with
+// Table.SetFixedLayout. It must be called before any rows are written.
+func (stream *StreamWriter) SetColumnWidths(widths ...int) error {
+	if stream.renderer != nil || len(stream.table.rows) > 0 {
+		return fmt.Errorf("column widths must be set before writing rows")
+	}
+	if len(widths) != len(stream.table.columnDefs) {
+		return fmt.Errorf(
+			"widths length %d must match columns %d",
+			len(widths),
+			len(stream.table.columnDefs))
+	}
+	stream.table.fixedWidths = append([]int(nil), widths...)
+	return nil
+}
+
+// WriteRow adds a row to the table. Rows are buffered until the sample is
+// complete, and written immediately afterwards.
+func (stream *StreamWriter) WriteRow(row ...string) error {
+	if stream.closed {
+		return fmt.Errorf("stream is closed")
+	}
+	err := stream.table.validateRow(row, stream.rowCount+1)
+	if err != nil {
+		return err
+	}
+	row = stream.table.expandRow(row)
+	stream.rowCount++
+
+	if stream.renderer != nil {
+		// Only the row being written is kept.
+		stream.table.rows = append(stream.table.rows[:0], row)
+		return stream.writeRow(0)
+	}
+	stream.table.rows = append(stream.table.rows, row)
+	if stream.table.fixedWidths != nil ||
+		len(stream.table.rows) >= stream.sampleSize {
+		return stream.start()
+	}
+	return nil
+}
+
+// Close writes any buffered rows and the bottom of the table, and flushes the
+// output. It does not close the underlying writer. Closing it again does
+// nothing.
+func (stream *StreamWriter) Close() error {
+	if stream.closed {
+		return nil
+	}
+	stream.closed = true
+	if stream.renderer == nil {
+		if err := stream.start(); err != nil {
+			return err
+		}
+	}
+	table := stream.table
+	table.renderBottom(stream.writer, stream.renderer, stream.rowCount)
+	return stream.writer.Flush()
+}
+
+// start fixes the column widths, then writes the top of the table and the
+// buffered rows.
+func (stream *StreamWriter) start() error {
+	table := stream.table
+	if table.shouldPrintRowCount && table.rowCountPosition == RowCountAbove {
+		return fmt.Errorf("row count above the table cannot be streamed")
+	}
+	if err := table.validateFixedLayout(); err != nil {
+		return err
+	}
+	stream.columnDefs = table.resolvedColumnDefs()
+	stream.justifications = columnJustifications(stream.columnDefs)
+	columnSizes := table.columnSizes(
+		stream.columnDefs,
+		table.renderedRows(stream.columnDefs),
+		nil)
+	stream.renderer = table.newRowRenderer(stream.columnDefs, columnSizes)
+
+	if err := table.renderTop(stream.writer, stream.renderer); err != nil {
+		return err
+	}
+	for i := range table.rows {
+		if err := stream.writeRow(i); err != nil {
+			return err
+		}
+	}
+	table.rows = table.rows[:0]
+	table.resetColumnWidths()
+	return nil
+}
+
+// writeRow writes the buffered row at index.
+func (stream *StreamWriter) writeRow(index int) error {
+	row := stream.table.renderedRow(stream.columnDefs, index)
+	err := stream.renderer.renderRow(
+		stream.writer,
+		row,
+		stream.renderer.cellColors(row, stream.renderer.dataColors),
+		stream.justifications)
+	if err != nil {
+		return err
+	}
+	_, err = stream.writer.WriteString("\n")
+	return err
+}
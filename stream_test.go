@@ -0,0 +1,85 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestStreamWriterMatchesPrettyString(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetHeader("Employees")
+	table.ShowRowCount(true)
+	table.SetColor(false)
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	// A sample covering every row measures the same widths as the table.
+	for _, sampleSize := range []int{len(table.rows), 100} {
+		var builder strings.Builder
+		stream := NewStreamWriter(&builder, table, sampleSize)
+		for _, row := range table.rows {
+			assert.Nil(t, stream.WriteRow(row...))
+		}
+		assert.Nil(t, stream.Close())
+		assert.Equal(t, expected, builder.String())
+	}
+}
+
+func TestStreamWriterWithFixedWidths(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetColor(false)
+
+	var builder strings.Builder
+	stream := NewStreamWriter(&builder, table, 100)
+	assert.NotNil(t, stream.SetColumnWidths(4, 6))
+	assert.Nil(t, stream.SetColumnWidths(4, 6, 6, 12))
+	for _, row := range table.rows {
+		assert.Nil(t, stream.WriteRow(row...))
+	}
+	assert.NotNil(t, stream.SetColumnWidths(4, 6, 6, 12))
+	assert.NotNil(t, stream.WriteRow("1"))
+	assert.Nil(t, stream.Close())
+
+	expected := readFileAsString(t, "test/stream_with_fixed_widths.txt")
+	assert.Equal(t, expected, builder.String())
+}
+
+func TestStreamWriterPadding(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Type")},
+		WithColor(false))
+	assert.Nil(t, err)
+	padding := 2
+	assert.Nil(t, table.SetStyle(Style{Padding: &padding}))
+
+	// The padding is added to the given widths, as in a fixed layout.
+	var builder strings.Builder
+	stream := NewStreamWriter(&builder, table, 100)
+	assert.Nil(t, stream.SetColumnWidths(4, 6))
+	assert.Nil(t, stream.WriteRow("Noel", "Human"))
+	assert.Nil(t, stream.Close())
+	assert.Nil(t, table.SetFixedLayout(4, 6))
+	assert.Nil(t, table.AddRow("Noel", "Human"))
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, builder.String())
+	assert.True(t, strings.Contains(expected, "|  Noel  |"))
+}
+
+func TestStreamWriterCloseTwice(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetColor(false)
+
+	var builder strings.Builder
+	stream := NewStreamWriter(&builder, table, 100)
+	assert.Nil(t, stream.WriteRow("23", "Noel", "Human", "(123) 456-7899"))
+	assert.Nil(t, stream.Close())
+	output := builder.String()
+	assert.Nil(t, stream.Close())
+	assert.Equal(t, output, builder.String())
+	assert.NotNil(
+		t,
+		stream.WriteRow("83", "David", "Cyborg", "987-654-3211"))
+}
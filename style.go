@@ -0,0 +1,228 @@
+package pretty
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+)
+
+// Overflow is how a value wider than its column is displayed.
+type Overflow uint
+
+const (
+	// OverflowInherit uses the overflow of the table's style, which is
+	// OverflowTruncate unless set.
+	OverflowInherit Overflow = iota
+	// OverflowTruncate cuts off the end of the value, marking it with an
+	// ellipsis.
+	OverflowTruncate
+	// OverflowWrap wraps the value onto as many lines as it needs.
+	OverflowWrap
+	// OverflowTruncateMiddle cuts out the middle of the value, marking it
+	// with an ellipsis, which keeps both ends of values such as IDs and
+	// paths.
+	OverflowTruncateMiddle
+	// OverflowClip cuts off the end of the value without an ellipsis.
+	OverflowClip
+	// OverflowHyphenate wraps the value like OverflowWrap, but hyphenates
+	// words that do not fit, breaking them at soft hyphens where possible.
+	// See WrapHyphenated.
+	OverflowHyphenate
+)
+
+// wraps reports whether values that overflow are wrapped onto more lines.
+func (overflow Overflow) wraps() bool {
+	return overflow == OverflowWrap || overflow == OverflowHyphenate
+}
+
+// Style holds display settings for a table. Columns inherit the table's style
+// unless their own style overrides it, and fields that are not set keep the
+// default behavior.
+type Style struct {
+	// Color is the color of the values. By default, columns alternate
+	// between yellow and green.
+	Color color.Attribute
+	// HeaderColor is the color of the column names. By default, columns
+	// cycle through red, magenta, blue and white.
+	HeaderColor color.Attribute
+	// Alignment is the alignment of the values, which are right justified by
+	// default. An alignment set with ColumnDef.WithAlignment takes
+	// precedence.
+	Alignment *Alignment
+	// Padding is the number of spaces on each side of the values, which is
+	// 1 by default.
+	Padding *int
+	// Overflow is how values wider than their column are displayed.
+	Overflow Overflow
+}
+
+func (style Style) valueColor() color.Attribute {
+	return style.Color
+}
+
+func (style Style) headerColor() color.Attribute {
+	return style.HeaderColor
+}
+
+// inherit returns the style with its unset fields taken from parent.
+func (style Style) inherit(parent Style) Style {
+	if style.Color == color.Reset {
+		style.Color = parent.Color
+	}
+	if style.HeaderColor == color.Reset {
+		style.HeaderColor = parent.HeaderColor
+	}
+	if style.Alignment == nil {
+		style.Alignment = parent.Alignment
+	}
+	if style.Padding == nil {
+		style.Padding = parent.Padding
+	}
+	if style.Overflow == OverflowInherit {
+		style.Overflow = parent.Overflow
+	}
+	return style
+}
+
+// SetStyle sets the style inherited by all columns.
+func (table *Table) SetStyle(style Style) error {
+	if style.Padding != nil && *style.Padding < 0 {
+		return fmt.Errorf("padding %d must not be negative", *style.Padding)
+	}
+	table.style = style
+	return nil
+}
+
+// WithStyle sets the style inherited by all columns. See Table.SetStyle.
+func WithStyle(style Style) Option {
+	return func(table *Table) error {
+		return table.SetStyle(style)
+	}
+}
+
+// WithStyle sets the style of the column, overriding the table's style for
+// the fields that are set.
+func (columnDef ColumnDef) WithStyle(style Style) ColumnDef {
+	columnDef.style = &style
+	return columnDef
+}
+
+// WithOverflow sets how values wider than the column are displayed,
+// overriding the table's style.
+func (columnDef ColumnDef) WithOverflow(overflow Overflow) ColumnDef {
+	var style Style
+	if columnDef.style != nil {
+		style = *columnDef.style
+	}
+	style.Overflow = overflow
+	columnDef.style = &style
+	return columnDef
+}
+
+// fitOverflow shortens content to width columns according to overflow.
+// Wrapped content is shortened as if it were truncated, since it only
+// reaches here when it is a single line.
+func fitOverflow(content string, width int, overflow Overflow) string {
+	switch overflow {
+	case OverflowTruncateMiddle:
+		return TruncateMiddle(content, width)
+	case OverflowClip:
+		return truncateStringWithEncoding(content, width)
+	}
+	return Truncate(content, width)
+}
+
+// columnStyles returns the style of each column, with the settings inherited
+// from the table filled in.
+func (table *Table) columnStyles(columnDefs []ColumnDef) []Style {
+	styles := make([]Style, len(columnDefs))
+	for i, columnDef := range columnDefs {
+		styles[i] = table.style
+		if columnDef.style != nil {
+			styles[i] = columnDef.style.inherit(table.style)
+		}
+	}
+	return styles
+}
+
+// styledColumnDefs returns the column definitions with the alignments of
+// their styles applied.
+func (table *Table) styledColumnDefs() []ColumnDef {
+	columnDefs := table.columnDefs
+	copied := false
+	for i, style := range table.columnStyles(columnDefs) {
+		if style.Alignment == nil || columnDefs[i].alignment != nil {
+			continue
+		}
+		if !copied {
+			columnDefs = append([]ColumnDef(nil), columnDefs...)
+			copied = true
+		}
+		alignment := *style.Alignment
+		columnDefs[i].alignment = &alignment
+	}
+	return columnDefs
+}
+
+// columnPaddings returns the number of spaces on each side of the values in
+// each column, beyond the single space that columns always have. It is
+// negative for columns without padding.
+func (table *Table) columnPaddings(columnDefs []ColumnDef) []int {
+	var paddings []int
+	for i, style := range table.columnStyles(columnDefs) {
+		if style.Padding == nil || *style.Padding == 1 {
+			continue
+		}
+		if paddings == nil {
+			paddings = make([]int, len(columnDefs))
+		}
+		paddings[i] = -1
+		if *style.Padding > 0 {
+			paddings[i] = *style.Padding - 1
+		}
+	}
+	return paddings
+}
+
+// styleColors returns the colors of the cells of each column, using the
+// colors of the styles where set and cycling through defaults otherwise.
+func styleColors(
+	styles []Style,
+	defaults []color.Attribute,
+	styleColor func(Style) color.Attribute,
+) []color.Attribute {
+	var colors []color.Attribute
+	for i, style := range styles {
+		if styleColor(style) == color.Reset {
+			continue
+		}
+		if colors == nil {
+			colors = make([]color.Attribute, len(styles))
+			for j := range colors {
+				colors[j] = defaults[j%len(defaults)]
+			}
+		}
+		colors[i] = styleColor(style)
+	}
+	if colors == nil {
+		return defaults
+	}
+	return colors
+}
+
+// columnOverflows returns the overflow of each column, or nil if all columns
+// are truncated.
+func columnOverflows(styles []Style) []Overflow {
+	var overflows []Overflow
+	for i, style := range styles {
+		if style.Overflow == OverflowInherit ||
+			style.Overflow == OverflowTruncate {
+			continue
+		}
+		if overflows == nil {
+			overflows = make([]Overflow, len(styles))
+		}
+		overflows[i] = style.Overflow
+	}
+	return overflows
+}
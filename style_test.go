@@ -0,0 +1,188 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestStyleInherited(t *testing.T) {
+	left := LeftJustify
+	padding := 2
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Name"),
+			NewColumnDef("Size").WithStyle(Style{Padding: new(int)}),
+			NewColumnDef("Count").WithAlignment(RightJustify),
+		},
+		WithColor(false),
+		WithStyle(Style{Alignment: &left, Padding: &padding}),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("a", "10", "1"))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+--------+----+---------+\n"+
+			"|  Name  |Size|  Count  |\n"+
+			"+--------+----+---------+\n"+
+			"|  a     |10  |      1  |\n"+
+			"+--------+----+---------+\n",
+		rendered)
+}
+
+func TestStyleWrap(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("ID"),
+			NewColumnDefWithWidth("Description", 11).
+				WithAlignment(LeftJustify),
+		},
+		WithColor(false),
+		WithStyle(Style{Overflow: OverflowWrap}),
+		WithTruncationNote("truncated"),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"1", "a long value to wrap"},
+		{"2", "short"},
+	}))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+----+-------------+\n"+
+			"| ID | Description |\n"+
+			"+----+-------------+\n"+
+			"|  1 | a long      |\n"+
+			"|    | value to    |\n"+
+			"|    | wrap        |\n"+
+			"|  2 | short       |\n"+
+			"+----+-------------+\n",
+		rendered)
+	assert.Equal(t, 0, len(table.Truncations()))
+}
+
+func TestStyleColors(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("A"),
+			NewColumnDef("B").WithStyle(Style{Color: color.FgCyan}),
+		},
+		WithStyle(Style{HeaderColor: color.FgBlue}),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.SetRows([][]string{{"1", "2"}, {"3", "4"}}))
+
+	renderer := table.newRowRenderer(table.columnDefs, []int{1, 1})
+	assert.DeepEqual(
+		t,
+		[]color.Attribute{color.FgYellow, color.FgCyan},
+		renderer.dataColors)
+	assert.DeepEqual(
+		t,
+		[]color.Attribute{color.FgBlue, color.FgBlue},
+		renderer.headerColors)
+}
+
+func TestSetStyleNegativePadding(t *testing.T) {
+	table, err := NewTable([]ColumnDef{NewColumnDef("A")})
+	assert.Nil(t, err)
+	padding := -1
+	assert.True(t, table.SetStyle(Style{Padding: &padding}) != nil)
+}
+
+func TestColumnOverflows(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDefWithWidth("ID", 9).
+				WithOverflow(OverflowTruncateMiddle),
+			NewColumnDefWithWidth("Code", 4).
+				WithOverflow(OverflowClip),
+			NewColumnDefWithWidth("Message", 8).
+				WithAlignment(LeftJustify).
+				WithOverflow(OverflowWrap),
+			NewColumnDefWithWidth("Host", 6),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow(
+		"0123456789abcdef",
+		"E100234",
+		"disk is almost full",
+		"storage01"))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+-----------+------+----------+--------+\n"+
+			"| ID        | Code | Message  | Host   |\n"+
+			"+-----------+------+----------+--------+\n"+
+			"| 012...def | E100 | disk is  | sto... |\n"+
+			"|           |      | almost   |        |\n"+
+			"|           |      | full     |        |\n"+
+			"+-----------+------+----------+--------+\n",
+		rendered)
+	assert.Equal(t, 3, len(table.Truncations()))
+}
+
+func TestWrappedColoredValue(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDefWithWidth("Msg", 6).
+				WithOverflow(OverflowWrap),
+			NewColumnDef("X"),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("\x1b[31mred words here\x1b[0m", "x"))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+--------+---+\n"+
+			"| Msg    | X |\n"+
+			"+--------+---+\n"+
+			"|    \x1b[31mred\x1b[0m | x |\n"+
+			"|  \x1b[31mwords\x1b[0m |   |\n"+
+			"|   \x1b[31mhere\x1b[0m |   |\n"+
+			"+--------+---+\n",
+		rendered)
+}
+
+func TestPaddedColumnsShrink(t *testing.T) {
+	padding := 5
+	for _, strategy := range []ShrinkStrategy{
+		ShrinkLongestFirst,
+		ShrinkEqual,
+	} {
+		table, err := NewTable(
+			[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Value")},
+			WithStyle(Style{Padding: &padding}),
+			WithMaxWidth(10),
+			WithShrinkStrategy(strategy),
+			WithColor(false),
+		)
+		assert.Nil(t, err)
+		assert.Nil(t, table.AddRow("a long name", "a long value"))
+
+		rendered, err := table.PrettyString()
+		assert.Nil(t, err)
+		assert.EqualString(
+			t,
+			"+--------------+--------------+\n"+
+				"|     Name     |     V...     |\n"+
+				"+--------------+--------------+\n"+
+				"|     a...     |     a...     |\n"+
+				"+--------------+--------------+\n",
+			rendered)
+	}
+}
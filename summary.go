@@ -0,0 +1,153 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+)
+
+// defaultSummarySeparator separates the segments of a Summary.
+const defaultSummarySeparator = " · "
+
+// Summary composes the line printed after a table from segments, such as
+// counts, durations and statuses, each with its own colors, so that commands
+// end their output the same way:
+//
+//	12 VMs · 3 failed · Took: 4.213s
+//
+// Segments that do not fit in the width of the terminal start a new line.
+type Summary struct {
+	segments []summarySegment
+
+	separator    string
+	width        int
+	colorEnabled *bool
+}
+
+type summarySegment struct {
+	text       string
+	attributes []color.Attribute
+}
+
+// NewSummary creates an empty Summary.
+func NewSummary() *Summary {
+	return &Summary{separator: defaultSummarySeparator}
+}
+
+// AddText adds a segment of text, such as a status, and returns the Summary.
+func (summary *Summary) AddText(
+	text string,
+	attributes ...color.Attribute,
+) *Summary {
+	summary.segments = append(
+		summary.segments,
+		summarySegment{text: text, attributes: attributes})
+	return summary
+}
+
+// Add adds a segment showing a labeled value, as in "Cluster: prod", and
+// returns the Summary. The value is formatted like AddValues formats it.
+func (summary *Summary) Add(
+	label string,
+	value interface{},
+	attributes ...color.Attribute,
+) *Summary {
+	return summary.AddText(label+": "+formatValue(value), attributes...)
+}
+
+// AddCount adds a segment counting things, as in "12 VMs", and returns the
+// Summary. The plural is used for counts other than 1.
+func (summary *Summary) AddCount(
+	count int,
+	singular string,
+	plural string,
+	attributes ...color.Attribute,
+) *Summary {
+	noun := plural
+	if count == 1 {
+		noun = singular
+	}
+	return summary.AddText(fmt.Sprintf("%d %s", count, noun), attributes...)
+}
+
+// AddDuration adds a segment showing a labeled duration, rounded to a
+// thousandth of its largest unit as in "Took: 4.213s", and returns the
+// Summary.
+func (summary *Summary) AddDuration(
+	label string,
+	duration time.Duration,
+	attributes ...color.Attribute,
+) *Summary {
+	return summary.Add(label, roundDuration(duration), attributes...)
+}
+
+// SetSeparator sets the text between segments, which defaults to " · ".
+func (summary *Summary) SetSeparator(separator string) {
+	summary.separator = separator
+}
+
+// SetWidth sets the width of a line. A width of 0, the default, uses the width
+// of the terminal, and puts every segment on one line when not writing to
+// one.
+func (summary *Summary) SetWidth(width int) {
+	summary.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (summary *Summary) SetColor(enabled bool) {
+	summary.colorEnabled = &enabled
+}
+
+// PrettyString renders the summary, sized as if written to stdout.
+func (summary *Summary) PrettyString() string {
+	return summary.render(os.Stdout)
+}
+
+// Print prints the summary to stdout.
+func (summary *Summary) Print() error {
+	return summary.Fprint(os.Stdout)
+}
+
+// Fprint prints the summary to w.
+func (summary *Summary) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), summary.render(w))
+	return err
+}
+
+// render renders the segments, wrapped to the width of w if it is a terminal.
+func (summary *Summary) render(w io.Writer) string {
+	if len(summary.segments) == 0 {
+		return ""
+	}
+	width := summary.width
+	if width == 0 {
+		width, _ = terminalWidth(w)
+	}
+	colorEnabled := colorsEnabled(summary.colorEnabled)
+	separatorWidth := strLengthWithEncoding(summary.separator)
+
+	var builder strings.Builder
+	lineWidth := 0
+	for i, segment := range summary.segments {
+		segmentWidth := strLengthWithEncoding(segment.text)
+		if i > 0 {
+			if width > 0 && lineWidth+separatorWidth+segmentWidth > width {
+				builder.WriteString("\n")
+				lineWidth = 0
+			} else {
+				builder.WriteString(summary.separator)
+				lineWidth += separatorWidth
+			}
+		}
+		builder.WriteString(
+			colorize(segment.text, colorEnabled, segment.attributes...))
+		lineWidth += segmentWidth
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
@@ -0,0 +1,38 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSummary(t *testing.T) {
+	summary := NewSummary().
+		AddCount(12, "VM", "VMs").
+		AddCount(1, "failure", "failures", color.FgRed).
+		AddDuration("Took", 4213*time.Millisecond).
+		Add("Cluster", "prod")
+	summary.SetColor(false)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, summary.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"12 VMs · 1 failure · Took: 4.213s · Cluster: prod\n",
+		buffer.String())
+
+	// Segments that do not fit start a new line.
+	summary.SetWidth(30)
+	summary.SetSeparator(", ")
+	summary.SetColor(true)
+	assert.EqualString(
+		t,
+		"12 VMs, \x1b[31m1 failure\x1b[0m\n"+
+			"Took: 4.213s, Cluster: prod\n",
+		summary.PrettyString())
+
+	assert.EqualString(t, "", NewSummary().PrettyString())
+}
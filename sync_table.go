@@ -0,0 +1,71 @@
+package pretty
+
+import (
+	"io"
+	"sync"
+)
+
+// SyncTable wraps a Table so that it can be used from multiple goroutines,
+// such as workers adding their results in parallel. A Table itself is not
+// safe for concurrent use.
+type SyncTable struct {
+	mu    sync.Mutex
+	table *Table
+}
+
+// NewSyncTable creates a SyncTable wrapping table. The table must not be used
+// directly while it is wrapped.
+func NewSyncTable(table *Table) *SyncTable {
+	return &SyncTable{table: table}
+}
+
+// AddRow adds a row to the table. See Table.AddRow.
+func (syncTable *SyncTable) AddRow(row ...string) error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.AddRow(row...)
+}
+
+// AddRows adds rows to the table. See Table.AddRows.
+func (syncTable *SyncTable) AddRows(rows [][]string) error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.AddRows(rows)
+}
+
+// SetRows sets the rows of the table. See Table.SetRows.
+func (syncTable *SyncTable) SetRows(rows [][]string) error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.SetRows(rows)
+}
+
+// Do calls f with exclusive access to the underlying table, for operations
+// that SyncTable does not wrap. The table must not be retained after f
+// returns.
+func (syncTable *SyncTable) Do(f func(table *Table) error) error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return f(syncTable.table)
+}
+
+// PrettyString creates the pretty string representing this table.
+func (syncTable *SyncTable) PrettyString() (string, error) {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.PrettyString()
+}
+
+// Print prints the table to stdout.
+func (syncTable *SyncTable) Print() error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.Print()
+}
+
+// Fprint prints the table to w. See Table.Fprint.
+func (syncTable *SyncTable) Fprint(w io.Writer) error {
+	syncTable.mu.Lock()
+	defer syncTable.mu.Unlock()
+	return syncTable.table.Fprint(w)
+}
@@ -0,0 +1,41 @@
+package pretty
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestSyncTableConcurrentAddRow(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Worker"),
+		NewColumnDef("Result"))
+	assert.Nil(t, err)
+	syncTable := NewSyncTable(table)
+
+	const workers = 8
+	const rowsPerWorker = 100
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for i := 0; i < rowsPerWorker; i++ {
+				err := syncTable.AddRow(strconv.Itoa(worker), strconv.Itoa(i))
+				assert.Nil(t, err)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	err = syncTable.Do(func(table *Table) error {
+		assert.EqualInt(t, workers*rowsPerWorker, len(table.rows))
+		return table.SortBy("Result", false)
+	})
+	assert.Nil(t, err)
+
+	_, err = syncTable.PrettyString()
+	assert.Nil(t, err)
+}
@@ -1,10 +1,15 @@
 package pretty
 
 import (
+	"fmt"
 	"io/ioutil"
 	"path"
+	"strconv"
+	"strings"
 	"testing"
+	"unicode"
 
+	"github.com/fatih/color"
 	"github.com/rubrikinc/testwell/assert"
 )
 
@@ -188,3 +193,271 @@ func readFileAsString(t *testing.T, filename string) string {
 	assert.Nil(t, err)
 	return string(b)
 }
+
+func TestTableWithDerivedColumn(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Volume"),
+		NewColumnDef("Used"),
+		NewColumnDef("Capacity"),
+		NewDerivedColumnDef("Used %", func(row map[string]string) string {
+			used, _ := strconv.ParseFloat(row["Used"], 64)
+			capacity, _ := strconv.ParseFloat(row["Capacity"], 64)
+			if capacity == 0 {
+				return "-"
+			}
+			return fmt.Sprintf("%.1f%%", 100*used/capacity)
+		}))
+	assert.Nil(t, err)
+
+	err = table.AddRow("vol-a", "25", "100")
+	assert.Nil(t, err)
+	err = table.SetRows([][]string{
+		{"vol-a", "25", "100"},
+		{"vol-b", "300", "400"},
+	})
+	assert.Nil(t, err)
+	err = table.AddRow("vol-c", "0", "0")
+	assert.Nil(t, err)
+
+	// Derived columns do not take a value.
+	err = table.AddRow("vol-d", "1", "2", "50%")
+	assert.NotNil(t, err)
+
+	assertExpectedTable(t, table, "table_with_derived_column.txt")
+}
+
+func TestColumnPrefixAndSuffix(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Item"),
+			NewColumnDef("Price").WithPrefix("$"),
+			NewColumnDefWithWidth("Size", 8).WithSuffix(" GiB"),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"disk", "120", "1024"},
+		{"spare", "", "100000"},
+	}))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+-------+-------+----------+\n"+
+			"| Item  | Price | Size     |\n"+
+			"+-------+-------+----------+\n"+
+			"|  disk |  $120 | 1024 GiB |\n"+
+			"| spare |       | 10000... |\n"+
+			"+-------+-------+----------+\n",
+		rendered)
+}
+
+func BenchmarkPrettyStringRerender(b *testing.B) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Phone Number"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < 10000; i++ {
+		err = table.AddRow(strconv.Itoa(i), "Noel", "Human", "(123) 456-7899")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := table.PrettyString(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestColumnWidthsTrackRowChanges(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDefWithWidth("Words", 10))
+	assert.Nil(t, err)
+	err = table.AddRow("A", "Short")
+	assert.Nil(t, err)
+	_, err = table.PrettyString()
+	assert.Nil(t, err)
+
+	// Rows added after rendering widen the columns.
+	err = table.AddRow("B", "short one")
+	assert.Nil(t, err)
+	err = table.AddRow("C", "exactly 10")
+	assert.Nil(t, err)
+	err = table.AddRow("D", "one too big")
+	assert.Nil(t, err)
+	err = table.AddRow("E", "this one is way too long")
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_with_column_limit.txt")
+
+	// Replacing the rows narrows them again.
+	err = table.SetRows([][]string{
+		{"Only Column", ""},
+		{"Some stuff", ""},
+	})
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "table_with_replaced_rows.txt")
+}
+
+func TestRowsAreCopied(t *testing.T) {
+	table, err := NewPrettyTable(NewColumnDef("Name"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+	row := []string{"A"}
+	rows := [][]string{{"B"}}
+	assert.Nil(t, table.AddRow(row...))
+	assert.Nil(t, table.AddRows(rows))
+	expected, err := table.PrettyString()
+	assert.Nil(t, err)
+
+	// Changing the slices passed in changes neither the values nor the
+	// widths measured from them.
+	row[0] = "a much longer name"
+	rows[0][0] = "another long name"
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, expected, output)
+}
+
+func TestAddRows(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Employee Number"),
+		NewColumnDef("Name"),
+		NewColumnDef("Type"),
+		NewColumnDef("Phone Number"))
+	assert.Nil(t, err)
+
+	table.Grow(4)
+	assert.EqualInt(t, 4, cap(table.rows))
+	err = table.AddRows([][]string{
+		{"23", "Noel", "Human", "(123) 456-7899"},
+		{"83", "David", "Cyborg", "987-654-3211"},
+	})
+	assert.Nil(t, err)
+
+	// A row of the wrong length rejects the whole batch.
+	err = table.AddRows([][]string{
+		{"52", "Pranava", "Crusher", "1-800-123-4567"},
+		{"1182", "Postnava", "Kitten"},
+	})
+	assert.NotNil(t, err)
+	assert.EqualInt(t, 2, len(table.rows))
+
+	err = table.AddRows([][]string{
+		{"52", "Pranava", "Crusher", "1-800-123-4567"},
+		{"1182", "Postnava", "Kitten", "1 (800) 987-6543"},
+	})
+	assert.Nil(t, err)
+	assertExpectedTable(t, table, "basic_table.txt")
+}
+
+func TestColumnNamesAndRows(t *testing.T) {
+	table := createBasicTable(t)
+	assert.DeepEqual(
+		t,
+		[]string{"Employee Number", "Name", "Type", "Phone Number"},
+		table.ColumnNames())
+	rows, err := table.Rows()
+	assert.Nil(t, err)
+	assert.EqualInt(t, 4, len(rows))
+
+	// The rows are copies, shown as they are displayed.
+	rows[0][0] = "24"
+	err = table.SetFormatter("Name", func(value interface{}) string {
+		return strings.ToUpper(value.(string))
+	})
+	assert.Nil(t, err)
+	rows, err = table.Rows()
+	assert.Nil(t, err)
+	assert.DeepEqual(
+		t,
+		[]string{"23", "NOEL", "Human", "(123) 456-7899"},
+		rows[0])
+}
+
+func TestEstimateSizeCoversOutput(t *testing.T) {
+	table := createBasicTable(t)
+	table.SetHeader("Employees")
+	table.ShowRowCount(true)
+	table.SetColor(false)
+
+	for _, style := range []BorderStyle{BorderASCII, BorderDouble} {
+		table.SetBorderStyle(style)
+		output, err := table.PrettyString()
+		assert.Nil(t, err)
+
+		estimate := table.estimateSize(
+			&rowRenderer{
+				columnSizes:  []int{15, 8, 7, 16},
+				border:       style,
+				colorEnabled: table.colorEnabled,
+			},
+			len(table.rows))
+		assert.True(t, estimate >= len(output))
+		assert.True(t, estimate < len(output)*5/4)
+	}
+}
+
+func TestRenderCellMatchesColorPrinter(t *testing.T) {
+	enabled := true
+	renderer := &rowRenderer{colorEnabled: &enabled}
+	for _, attribute := range []color.Attribute{color.FgGreen, color.FgRed} {
+		printer := color.New(attribute, color.Bold)
+		printer.EnableColor()
+
+		var builder strings.Builder
+		err := renderer.renderCell(
+			&builder,
+			"abc",
+			7,
+			1,
+			CenterJustify,
+			attribute)
+		assert.Nil(t, err)
+		assert.Equal(t, printer.Sprintf(" %s ", "  abc  "), builder.String())
+	}
+}
+
+func TestShouldCountEncodedRuneMatchesUnicode(t *testing.T) {
+	for r := rune(0); r <= unicode.MaxRune; r++ {
+		if shouldCountEncodedRune(r) == unicode.IsMark(r) {
+			t.Fatalf("rune %U counted as mark: %t", r, unicode.IsMark(r))
+		}
+	}
+}
+
+func TestTruncateStringWithEncoding(t *testing.T) {
+	assert.Equal(t, "ab", truncateStringWithEncoding("abcd", 2))
+	assert.Equal(t, "abcd", truncateStringWithEncoding("abcd", 8))
+	assert.Equal(t, "ét", truncateStringWithEncoding("été", 2))
+	assert.Equal(t, "日", truncateStringWithEncoding("日本語", 3))
+	assert.Equal(
+		t,
+		"\x1b[31mab\x1b[0m",
+		truncateStringWithEncoding("\x1b[31mabc\x1b[0m", 2))
+	assert.EqualInt(t, 6, strLengthWithEncoding("日本語"))
+	assert.EqualInt(t, 2, strLengthWithEncoding("\x1b[1;31mok\x1b[0m"))
+	assert.EqualInt(
+		t,
+		4,
+		strLengthWithEncoding("\x1b]8;;http://x\x1b\\link\x1b]8;;\a"))
+	assert.EqualInt(t, 3, strLengthWithEncoding("été"))
+}
+
+func BenchmarkStrLengthWithEncoding(b *testing.B) {
+	values := []string{"1-800-123-4567", "Postnava", "Crème brûlée", "日本語"}
+	for i := 0; i < b.N; i++ {
+		for _, value := range values {
+			strLengthWithEncoding(value)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package pretty
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// FuncMap returns functions that render with this package for use in
+// text/template and html/template, so that reports and status pages show
+// values the way a command line does. Nothing is colored. Tables and other
+// multi-line output are best placed in a <pre> element of an HTML template.
+// The functions are:
+//
+//	prettyTable TABLE          the table, as PrettyString renders it
+//	prettyKV MAP               the entries of a map as KV pairs, sorted by key
+//	prettyBytes NUMBER         a number of bytes, as FormatBytes formats it
+//	prettySparkline NUMBERS    a series of numbers as a Sparkline
+//	prettyJSON VALUE           a value as indented JSON
+//	prettyYAML VALUE           a value as YAML
+//	prettyDump VALUE           a value as Dump renders it
+//	prettyHeading TITLE        a title underlined like a Heading
+//	prettyWrap WIDTH TEXT      text wrapped to WIDTH
+//	prettyIndent SPACES TEXT   text with each line indented by SPACES
+//	prettyTruncate WIDTH TEXT  text truncated to WIDTH, as Truncate does
+//
+// The last argument of each function may be piped, as in
+// {{.Description | prettyWrap 60}}. For html/template, convert the result
+// with html/template.FuncMap(pretty.FuncMap()).
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"prettyTable":     templateTable,
+		"prettyKV":        templateKV,
+		"prettyBytes":     FormatBytes,
+		"prettySparkline": FormatSparkline,
+		"prettyJSON":      templateJSON,
+		"prettyYAML":      templateYAML,
+		"prettyDump":      templateDump,
+		"prettyHeading":   Heading,
+		"prettyWrap":      templateWrap,
+		"prettyIndent":    templateIndent,
+		"prettyTruncate":  templateTruncate,
+	}
+}
+
+func templateTable(table *Table) (string, error) {
+	return table.plainString()
+}
+
+func templateKV(m interface{}) (string, error) {
+	reflected := reflect.ValueOf(m)
+	if reflected.Kind() != reflect.Map {
+		return "", fmt.Errorf("prettyKV of %T, must be a map", m)
+	}
+	keys := make([]string, 0, reflected.Len())
+	values := make(map[string]string, reflected.Len())
+	iter := reflected.MapRange()
+	for iter.Next() {
+		key := formatValue(iter.Key().Interface())
+		keys = append(keys, key)
+		values[key] = formatValue(iter.Value().Interface())
+	}
+	sort.Strings(keys)
+
+	kv := NewKV()
+	kv.SetColor(false)
+	for _, key := range keys {
+		kv.Add(key, values[key])
+	}
+	// A buffer is not a terminal, so values are not wrapped.
+	var buffer bytes.Buffer
+	err := kv.Fprint(&buffer)
+	return buffer.String(), err
+}
+
+func templateJSON(v interface{}) (string, error) {
+	printer := NewJSONPrinter()
+	printer.SetColor(false)
+	return printer.Format(v)
+}
+
+func templateYAML(v interface{}) (string, error) {
+	printer := NewYAMLPrinter()
+	printer.SetColor(false)
+	return printer.Format(v)
+}
+
+func templateDump(v interface{}) string {
+	printer := NewValuePrinter()
+	printer.SetColor(false)
+	return printer.Format(v)
+}
+
+func templateWrap(width int, text string) string {
+	return strings.Join(Wrap(text, width), "\n")
+}
+
+func templateIndent(spaces int, text string) string {
+	return Indent(text, spaces)
+}
+
+func templateTruncate(width int, text string) string {
+	return Truncate(text, width)
+}
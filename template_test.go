@@ -0,0 +1,63 @@
+package pretty
+
+import (
+	htmltemplate "html/template"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFuncMap(t *testing.T) {
+	tmpl, err := template.New("report").Funcs(FuncMap()).Parse(
+		"{{prettyHeading .Title}}" +
+			"{{prettyTable .Table}}\n" +
+			"Used: {{prettyBytes .Used}}\n" +
+			"{{prettyKV .Labels}}" +
+			"{{.Notes | prettyWrap 10 | prettyIndent 2}}\n")
+	assert.Nil(t, err)
+
+	table := createBasicTable(t)
+	table.SetColor(true)
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, map[string]interface{}{
+		"Title":  "Staff",
+		"Table":  table,
+		"Used":   1536,
+		"Labels": map[string]int{"zone": 2, "rack": 14},
+		"Notes":  "Nobody is on call this week.",
+	})
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"Staff\n=====\n"+
+			readFileAsString(t, "test/basic_table.txt")+"\n"+
+			"Used: 1.5 KiB\n"+
+			"rack: 14\n"+
+			"zone: 2\n"+
+			"  Nobody is\n"+
+			"  on call\n"+
+			"  this week.\n",
+		builder.String())
+	assert.True(t, *table.colorEnabled)
+
+	tmpl, err = template.New("").Funcs(FuncMap()).Parse("{{prettyKV 1}}")
+	assert.Nil(t, err)
+	assert.True(t, tmpl.Execute(&builder, nil) != nil)
+}
+
+func TestFuncMapWithHTMLTemplate(t *testing.T) {
+	tmpl, err := htmltemplate.New("page").
+		Funcs(htmltemplate.FuncMap(FuncMap())).
+		Parse("<pre>{{prettyJSON .}}</pre>")
+	assert.Nil(t, err)
+
+	var builder strings.Builder
+	err = tmpl.Execute(&builder, map[string]string{"name": "<vm-1>"})
+	assert.Nil(t, err)
+	assert.Equal(
+		t,
+		"<pre>{\n  &#34;name&#34;: &#34;&lt;vm-1&gt;&#34;\n}\n</pre>",
+		builder.String())
+}
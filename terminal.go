@@ -0,0 +1,39 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/mattn/go-isatty"
+)
+
+// isTerminal returns whether w writes to a terminal.
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return false
+	}
+	fd := file.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// terminalWidth returns the width in columns of the terminal w writes to. If
+// w is not a terminal, it returns false. The COLUMNS environment variable is
+// used if the width cannot be queried.
+func terminalWidth(w io.Writer) (int, bool) {
+	if !isTerminal(w) {
+		return 0, false
+	}
+	if width, ok := fdWidth(w.(interface{ Fd() uintptr }).Fd()); ok {
+		return width, true
+	}
+	columns, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err == nil && columns > 0 {
+		return columns, true
+	}
+	return defaultTerminalWidth, true
+}
+
+// defaultTerminalWidth is assumed for terminals whose width is unknown.
+const defaultTerminalWidth = 80
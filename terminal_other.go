@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd && !solaris
+
+package pretty
+
+// fdWidth returns the width in columns of the terminal open as fd, which is
+// not supported on this platform.
+func fdWidth(fd uintptr) (int, bool) {
+	return 0, false
+}
+
+// fdSize returns the width in columns and height in lines of the terminal
+// open as fd, which is not supported on this platform.
+func fdSize(fd uintptr) (int, int, bool) {
+	return 0, 0, false
+}
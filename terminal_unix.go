@@ -0,0 +1,21 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd || solaris
+
+package pretty
+
+import "golang.org/x/sys/unix"
+
+// fdWidth returns the width in columns of the terminal open as fd.
+func fdWidth(fd uintptr) (int, bool) {
+	width, _, ok := fdSize(fd)
+	return width, ok
+}
+
+// fdSize returns the width in columns and height in lines of the terminal
+// open as fd.
+func fdSize(fd uintptr) (int, int, bool) {
+	size, err := unix.IoctlGetWinsize(int(fd), unix.TIOCGWINSZ)
+	if err != nil || size.Col == 0 || size.Row == 0 {
+		return 0, 0, false
+	}
+	return int(size.Col), int(size.Row), true
+}
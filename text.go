@@ -0,0 +1,231 @@
+package pretty
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Wrap splits text into lines no wider than width columns, breaking at spaces
+// where possible, for help text, captions and other prose. Widths are
+// measured as in a terminal: escape sequences such as colors take no
+// columns, and East Asian wide characters take two. Colors are reset at the
+// end of each line and set again at the start of the next. Existing line
+// breaks are kept, and runs of spaces within a line are collapsed. Words
+// wider than a line are broken. A width of 0 or less only splits at line
+// breaks.
+func Wrap(text string, width int) []string {
+	return wrap(text, width, false)
+}
+
+// WrapHyphenated splits text into lines like Wrap, but breaks words that do
+// not fit at the end of a line with a hyphen instead of moving them to the
+// next line whole. Words are broken at their soft hyphens (U+00AD) where
+// possible, and only words wider than a line are broken elsewhere. East
+// Asian wide text is broken between any two characters, without a hyphen.
+// Soft hyphens that are not used are removed.
+func WrapHyphenated(text string, width int) []string {
+	return wrap(text, width, true)
+}
+
+// softHyphen marks where a word may be hyphenated.
+const softHyphen = "\u00ad"
+
+func wrap(text string, width int, hyphenate bool) []string {
+	if width <= 0 {
+		return closeColors(strings.Split(text, "\n"))
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(text, "\n") {
+		paragraphStart := len(lines)
+		line := ""
+		lineWidth := 0
+		for _, word := range strings.Fields(paragraph) {
+			for hyphenate {
+				available := width - lineWidth
+				if lineWidth > 0 {
+					available--
+				}
+				head, rest := hyphenateWord(
+					word,
+					available,
+					lineWidth == 0)
+				if rest == "" {
+					word = head
+					break
+				}
+				if head != "" {
+					if lineWidth > 0 {
+						line += " "
+					}
+					line += head
+				}
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+				word = rest
+			}
+			wordWidth := strLengthWithEncoding(word)
+
+			// Words too long for a line of their own are split.
+			for wordWidth > width {
+				if lineWidth > 0 {
+					lines = append(lines, line)
+					line, lineWidth = "", 0
+				}
+				head := truncateWidth(word, width)
+				if strLengthWithEncoding(head) == 0 {
+					// A character wider than the line gets a line of its
+					// own.
+					head = truncateWidth(word, width+1)
+				}
+				lines = append(lines, head)
+				word = word[len(head):]
+				wordWidth = strLengthWithEncoding(word)
+			}
+			if wordWidth == 0 {
+				// Keep escape sequences left after splitting a word.
+				line += word
+				continue
+			}
+
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+			if lineWidth > 0 {
+				line += " "
+				lineWidth++
+			}
+			line += word
+			lineWidth += wordWidth
+		}
+		if line != "" || len(lines) == paragraphStart {
+			lines = append(lines, line)
+		}
+	}
+	return closeColors(lines)
+}
+
+// closeColors makes each line set its own colors, so that colors do not run
+// over whatever is printed between the lines: colors still in effect at the
+// end of a line are reset there and set again at the start of the next line.
+func closeColors(lines []string) []string {
+	active := ""
+	for i, line := range lines {
+		next := activeColors(active, line)
+		lines[i] = active + line
+		if next != "" {
+			lines[i] += colorReset
+		}
+		active = next
+	}
+	return lines
+}
+
+// hyphenateWord splits word so that its head, ending with a hyphen, fits in
+// the available columns, and returns the rest of the word. It returns the
+// whole word without soft hyphens and no rest if it fits or cannot be
+// hyphenated, and only the rest if the word should start a new line.
+func hyphenateWord(
+	word string,
+	available int,
+	lineStart bool,
+) (string, string) {
+	plain := strings.ReplaceAll(word, softHyphen, "")
+	if strLengthWithEncoding(plain) <= available {
+		return plain, ""
+	}
+
+	// Prefer the last soft hyphen that leaves room for the hyphen.
+	parts := strings.Split(word, softHyphen)
+	for i := len(parts) - 1; i > 0; i-- {
+		head := strings.Join(parts[:i], "")
+		if strLengthWithEncoding(head)+1 <= available {
+			return head + "-", strings.Join(parts[i:], softHyphen)
+		}
+	}
+	head := truncateWidth(plain, available)
+	if breaksWide(head, plain[len(head):]) && (head != "" || !lineStart) {
+		return head, plain[len(head):]
+	}
+	if !lineStart {
+		return "", word
+	}
+	if available >= 2 {
+		head := truncateWidth(plain, available-1)
+		if strLengthWithEncoding(head) > 0 {
+			return head + "-", plain[len(head):]
+		}
+	}
+	return plain, ""
+}
+
+// breaksWide returns whether the break between head and rest is next to a
+// wide character, where East Asian text breaks without a hyphen.
+func breaksWide(head string, rest string) bool {
+	last, _ := utf8.DecodeLastRuneInString(head)
+	first, _ := utf8.DecodeRuneInString(rest)
+	return unicode.Is(wideRunes, last) || unicode.Is(wideRunes, first)
+}
+
+// Indent indents each non-blank line of text by the given number of spaces,
+// for nesting one block of output in another.
+func Indent(text string, spaces int) string {
+	return prefixLines(text, strings.Repeat(" ", spaces), true)
+}
+
+// PrefixLines adds prefix to the start of each line of text, such as "│ " to
+// mark a nested block. A final line break is not followed by a prefix.
+//
+// Colors set by escape sequences in text do not apply to the prefixes: they
+// are reset at the end of each line and set again after the prefix.
+func PrefixLines(text string, prefix string) string {
+	return prefixLines(text, prefix, false)
+}
+
+func prefixLines(text string, prefix string, skipBlank bool) string {
+	var builder strings.Builder
+	// active holds the color sequences in effect at the end of the previous
+	// line.
+	active := ""
+	for _, line := range strings.SplitAfter(text, "\n") {
+		if line == "" {
+			continue
+		}
+		content := strings.TrimSuffix(line, "\n")
+		if !skipBlank || strings.TrimSpace(content) != "" {
+			builder.WriteString(prefix)
+		}
+		builder.WriteString(active)
+		builder.WriteString(content)
+
+		active = activeColors(active, content)
+		if active != "" && len(content) < len(line) {
+			builder.WriteString(colorReset)
+		}
+		builder.WriteString(line[len(content):])
+	}
+	return builder.String()
+}
+
+// activeColors returns the color sequences in effect after line, given those
+// in effect before it. Resets clear the colors set before them.
+func activeColors(active string, line string) string {
+	for i := 0; i < len(line); i++ {
+		n := escapeSequenceLength(line[i:])
+		if n == 0 {
+			continue
+		}
+		sequence := line[i : i+n]
+		switch {
+		case sequence == colorReset || sequence == "\x1b[m":
+			active = ""
+		case strings.HasPrefix(sequence, "\x1b[") &&
+			strings.HasSuffix(sequence, "m"):
+			active += sequence
+		}
+		i += n - 1
+	}
+	return active
+}
@@ -0,0 +1,342 @@
+package pretty
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Colors of the lines of a diff.
+var (
+	diffRemovedColor = color.FgRed
+	diffAddedColor   = color.FgGreen
+	diffHunkColor    = color.FgCyan
+)
+
+// defaultDiffContext is the default number of unchanged lines shown around
+// each change.
+const defaultDiffContext = 3
+
+// Diff returns the changes from a to b as a unified diff, with colors if
+// stdout is a terminal, or an empty string if they are the same. See
+// DiffPrinter for other settings.
+func Diff(a string, b string) string {
+	return NewDiffPrinter().Format(a, b)
+}
+
+// DiffPrinter shows the changes between two texts line by line, for changes
+// to configuration or policies. Changes are shown as a unified diff, or side
+// by side:
+//
+//	@@ -1,3 +1,3 @@
+//	 retention: 30d
+//	-schedule: daily
+//	+schedule: hourly
+//	 replicas: 2
+type DiffPrinter struct {
+	context      int
+	sideBySide   bool
+	width        int
+	colorEnabled *bool
+}
+
+// NewDiffPrinter creates a DiffPrinter showing a unified diff with 3 lines of
+// context.
+func NewDiffPrinter() *DiffPrinter {
+	return &DiffPrinter{context: defaultDiffContext}
+}
+
+// SetContext sets the number of unchanged lines shown around each change.
+// Negative numbers are treated as 0.
+func (printer *DiffPrinter) SetContext(lines int) {
+	if lines < 0 {
+		lines = 0
+	}
+	printer.context = lines
+}
+
+// SetSideBySide sets whether the texts are shown side by side, with markers
+// between them: "<" for removed lines, ">" for added lines and "|" for
+// changed lines.
+func (printer *DiffPrinter) SetSideBySide(sideBySide bool) {
+	printer.sideBySide = sideBySide
+}
+
+// SetWidth sets the total width of a side by side diff. A width of 0, the
+// default, uses the width of the terminal, or defaultTerminalWidth when not
+// writing to one. Longer lines are truncated.
+func (printer *DiffPrinter) SetWidth(width int) {
+	printer.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (printer *DiffPrinter) SetColor(enabled bool) {
+	printer.colorEnabled = &enabled
+}
+
+// Format returns the changes from a to b, sized as if written to stdout, or
+// an empty string if they are the same.
+func (printer *DiffPrinter) Format(a string, b string) string {
+	return printer.render(os.Stdout, a, b)
+}
+
+// Print prints the changes from a to b to stdout.
+func (printer *DiffPrinter) Print(a string, b string) error {
+	return printer.Fprint(os.Stdout, a, b)
+}
+
+// Fprint prints the changes from a to b to w.
+func (printer *DiffPrinter) Fprint(w io.Writer, a string, b string) error {
+	_, err := io.WriteString(consoleWriter(w), printer.render(w, a, b))
+	return err
+}
+
+// diffOpKind is the kind of a diffOp.
+type diffOpKind uint
+
+const (
+	diffEqual diffOpKind = iota
+	diffDelete
+	diffInsert
+)
+
+// diffOp is a line kept, deleted from a or inserted from b.
+type diffOp struct {
+	kind diffOpKind
+	line string
+	// aLine and bLine are the 0-based positions in a and b before the
+	// operation.
+	aLine int
+	bLine int
+}
+
+// render lays out the diff to fit the width of w if it is a terminal.
+func (printer *DiffPrinter) render(w io.Writer, a string, b string) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+	colorEnabled := colorsEnabled(printer.colorEnabled)
+
+	var builder strings.Builder
+	for _, hunk := range diffHunks(ops, printer.context) {
+		first, last := ops[hunk[0]], ops[hunk[1]-1]
+		aEnd, bEnd := last.aLine, last.bLine
+		if last.kind != diffInsert {
+			aEnd++
+		}
+		if last.kind != diffDelete {
+			bEnd++
+		}
+		builder.WriteString(colorize(
+			fmt.Sprintf(
+				"@@ -%s +%s @@",
+				hunkRange(first.aLine, aEnd),
+				hunkRange(first.bLine, bEnd)),
+			colorEnabled,
+			diffHunkColor))
+		builder.WriteString("\n")
+
+		if printer.sideBySide {
+			width := printer.width
+			if width == 0 {
+				var ok bool
+				if width, ok = terminalWidth(w); !ok {
+					width = defaultTerminalWidth
+				}
+			}
+			writeSideBySide(&builder, ops[hunk[0]:hunk[1]], width, colorEnabled)
+			continue
+		}
+		for _, op := range ops[hunk[0]:hunk[1]] {
+			switch op.kind {
+			case diffEqual:
+				builder.WriteString(" " + op.line)
+			case diffDelete:
+				builder.WriteString(
+					colorize("-"+op.line, colorEnabled, diffRemovedColor))
+			case diffInsert:
+				builder.WriteString(
+					colorize("+"+op.line, colorEnabled, diffAddedColor))
+			}
+			builder.WriteString("\n")
+		}
+	}
+	return builder.String()
+}
+
+// writeSideBySide writes the operations of a hunk as two columns, pairing
+// each run of deleted lines with the inserted lines that follow it.
+func writeSideBySide(
+	builder *strings.Builder,
+	ops []diffOp,
+	width int,
+	colorEnabled bool,
+) {
+	// Each side is followed or preceded by a space around the marker.
+	columnWidth := (width - 3) / 2
+	if columnWidth < minWrapWidth {
+		columnWidth = minWrapWidth
+	}
+	cell := func(line string, attributes ...color.Attribute) string {
+		line = truncateStringWithEncoding(line, columnWidth)
+		padding := strings.Repeat(
+			" ",
+			columnWidth-strLengthWithEncoding(line))
+		return colorize(line, colorEnabled, attributes...) + padding
+	}
+	writeRow := func(left string, marker string, right string) {
+		builder.WriteString(strings.TrimRight(
+			left+" "+marker+" "+right,
+			" "))
+		builder.WriteString("\n")
+	}
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == diffEqual {
+			writeRow(cell(ops[i].line), " ", cell(ops[i].line))
+			i++
+			continue
+		}
+		var deleted, inserted []string
+		for ; i < len(ops) && ops[i].kind == diffDelete; i++ {
+			deleted = append(deleted, ops[i].line)
+		}
+		for ; i < len(ops) && ops[i].kind == diffInsert; i++ {
+			inserted = append(inserted, ops[i].line)
+		}
+		for j := 0; j < len(deleted) || j < len(inserted); j++ {
+			switch {
+			case j >= len(inserted):
+				writeRow(
+					cell(deleted[j], diffRemovedColor),
+					"<",
+					"")
+			case j >= len(deleted):
+				writeRow(
+					strings.Repeat(" ", columnWidth),
+					">",
+					cell(inserted[j], diffAddedColor))
+			default:
+				writeRow(
+					cell(deleted[j], diffRemovedColor),
+					"|",
+					cell(inserted[j], diffAddedColor))
+			}
+		}
+	}
+}
+
+// splitLines splits text into lines, ignoring a final line break.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines finds the shortest sequence of operations that turns a into b,
+// using the algorithm of Myers.
+func diffLines(a []string, b []string) []diffOp {
+	n, m := len(a), len(b)
+	offset := n + m + 1
+	v := make([]int, 2*offset+1)
+	var trace [][]int
+
+search:
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				break search
+			}
+		}
+	}
+
+	// Walk back from the end through the furthest reaching paths.
+	var ops []diffOp
+	add := func(kind diffOpKind, line string, x int, y int) {
+		ops = append(ops, diffOp{kind: kind, line: line, aLine: x, bLine: y})
+	}
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		previousK := k - 1
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			previousK = k + 1
+		}
+		previousX := v[offset+previousK]
+		previousY := previousX - previousK
+		for x > previousX && y > previousY {
+			x--
+			y--
+			add(diffEqual, a[x], x, y)
+		}
+		if d > 0 {
+			if x == previousX {
+				y--
+				add(diffInsert, b[y], x, y)
+			} else {
+				x--
+				add(diffDelete, a[x], x, y)
+			}
+		}
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// diffHunks returns the start and end indices of the ranges of ops that
+// contain changes and up to context unchanged lines around them. Ranges
+// whose context would overlap are merged.
+func diffHunks(ops []diffOp, context int) [][2]int {
+	var hunks [][2]int
+	for i, op := range ops {
+		if op.kind == diffEqual {
+			continue
+		}
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		end := i + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		if last := len(hunks) - 1; last >= 0 && start <= hunks[last][1] {
+			hunks[last][1] = end
+		} else {
+			hunks = append(hunks, [2]int{start, end})
+		}
+	}
+	return hunks
+}
+
+// hunkRange formats the lines from start to end, 0-based and exclusive, as
+// in the header of a hunk of a unified diff.
+func hunkRange(start int, end int) string {
+	switch count := end - start; count {
+	case 0:
+		return fmt.Sprintf("%d,0", start)
+	case 1:
+		return fmt.Sprintf("%d", start+1)
+	default:
+		return fmt.Sprintf("%d,%d", start+1, count)
+	}
+}
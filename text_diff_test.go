@@ -0,0 +1,85 @@
+package pretty
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestDiffLines(t *testing.T) {
+	ops := diffLines(
+		strings.Split("a b c a b b a", " "),
+		strings.Split("c b a b a c", " "))
+	var edits []string
+	for _, op := range ops {
+		edits = append(edits, []string{" ", "-", "+"}[op.kind]+op.line)
+	}
+	// The shortest edit script has 5 edits.
+	assert.DeepEqual(
+		t,
+		[]string{"-a", "-b", " c", "+b", " a", " b", "-b", " a", "+c"},
+		edits)
+	assert.EqualInt(t, 0, len(diffLines(nil, nil)))
+}
+
+func TestDiffPrinter(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n"
+	after := "0\n1\n2\n3\n4\n5\n6\nseven\n8\n9\n"
+	printer := NewDiffPrinter()
+	printer.SetColor(false)
+	printer.SetContext(1)
+	assert.EqualString(
+		t,
+		"@@ -1 +1,2 @@\n"+
+			"+0\n"+
+			" 1\n"+
+			"@@ -6,3 +7,3 @@\n"+
+			" 6\n"+
+			"-7\n"+
+			"+seven\n"+
+			" 8\n",
+		printer.Format(before, after))
+	assert.EqualString(t, "", printer.Format(before, before))
+
+	printer.SetContext(0)
+	assert.EqualString(
+		t,
+		"@@ -1,2 +0,0 @@\n-a\n-b\n",
+		printer.Format("a\nb", ""))
+
+	printer.SetContext(-1)
+	assert.EqualString(
+		t,
+		"@@ -2 +2 @@\n-b\n+x\n",
+		printer.Format("a\nb\nc", "a\nx\nc"))
+}
+
+func TestDiffPrinterSideBySide(t *testing.T) {
+	printer := NewDiffPrinter()
+	printer.SetColor(false)
+	printer.SetSideBySide(true)
+	printer.SetWidth(27)
+	assert.EqualString(
+		t,
+		"@@ -1,4 +1,4 @@\n"+
+			"keep           keep\n"+
+			"schedule     | schedule: ho\n"+
+			"old          <\n"+
+			"end            end\n"+
+			"             > new\n",
+		printer.Format(
+			"keep\nschedule\nold\nend",
+			"keep\nschedule: hourly\nend\nnew\n"))
+}
+
+func TestDiffPrinterColors(t *testing.T) {
+	printer := NewDiffPrinter()
+	printer.SetColor(true)
+	assert.EqualString(
+		t,
+		"\x1b[36m@@ -1 +1 @@\x1b[0m\n"+
+			"\x1b[31m-a\x1b[0m\n"+
+			"\x1b[32m+b\x1b[0m\n",
+		printer.Format("a", "b"))
+}
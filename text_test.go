@@ -0,0 +1,77 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestWrap(t *testing.T) {
+	assert.DeepEqual(
+		t,
+		[]string{"the quick", "brown fox", "", "jumps"},
+		Wrap("the quick brown fox\n\njumps", 10))
+	assert.DeepEqual(
+		t,
+		[]string{"a", "abcde", "fghij", "k b"},
+		Wrap("a abcdefghijk b", 5))
+	assert.DeepEqual(t, []string{"a  b"}, Wrap("a  b", 0))
+}
+
+func TestWrapHyphenated(t *testing.T) {
+	assert.DeepEqual(
+		t,
+		[]string{"the in-", "terna-", "tional", "date"},
+		WrapHyphenated("the in\u00adter\u00adna\u00adtional date", 7))
+	assert.DeepEqual(
+		t,
+		[]string{"a", "abcde-", "fghijk", "b"},
+		WrapHyphenated("a abcdefghijk b", 6))
+	assert.DeepEqual(
+		t,
+		[]string{"no soft", "hyphens"},
+		WrapHyphenated("no soft\u00ad hyphens", 7))
+	assert.DeepEqual(
+		t,
+		[]string{"a 日本", "語の文", "章"},
+		WrapHyphenated("a 日本語の文章", 6))
+}
+
+func TestWrapEscapeSequences(t *testing.T) {
+	red := "\x1b[31m"
+	reset := "\x1b[0m"
+	assert.DeepEqual(
+		t,
+		[]string{red + "error:" + reset, "disk full"},
+		Wrap(red+"error:"+reset+" disk full", 9))
+	assert.DeepEqual(
+		t,
+		[]string{red + "abc" + reset, red + "def" + reset},
+		Wrap(red+"abcdef"+reset, 3))
+}
+
+func TestWrapWideCharacters(t *testing.T) {
+	assert.DeepEqual(
+		t,
+		[]string{"日本", "語 ok"},
+		Wrap("日本語 ok", 5))
+	assert.DeepEqual(t, []string{"日", "本"}, Wrap("日本", 1))
+}
+
+func TestIndent(t *testing.T) {
+	assert.EqualString(t, "  a\n\n    b\n", Indent("a\n\n  b\n", 2))
+	assert.EqualString(t, "", Indent("", 2))
+}
+
+func TestPrefixLines(t *testing.T) {
+	assert.EqualString(t, "│ a\n│ \n│ b", PrefixLines("a\n\nb", "│ "))
+
+	// Colors spanning lines do not apply to the prefix.
+	red := "\x1b[31m"
+	reset := "\x1b[0m"
+	assert.EqualString(
+		t,
+		"> "+red+"a"+reset+"\n"+
+			"> "+red+"b"+reset+" c\n",
+		PrefixLines(red+"a\nb"+reset+" c\n", "> "))
+}
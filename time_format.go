@@ -0,0 +1,66 @@
+package pretty
+
+import (
+	"strings"
+	"time"
+)
+
+// timeLayoutPresets are the names accepted by FormatTime for common layouts,
+// matching the constants of the time package.
+var timeLayoutPresets = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// FormatTime returns a Formatter that formats times with layout, which is
+// either a layout as accepted by time.Time.Format or the name of one of the
+// layouts of the time package, such as "RFC3339" or "Kitchen". Values may be
+// time.Time values or pointers to them, or strings in the formats recognized
+// by type sniffing, such as RFC 3339. Other values are formatted with
+// fmt.Sprint.
+func FormatTime(layout string) Formatter {
+	if preset, ok := timeLayoutPresets[layout]; ok {
+		layout = preset
+	}
+	return func(value interface{}) string {
+		t, ok := timeValue(value)
+		if !ok {
+			return formatValue(value)
+		}
+		return t.Format(layout)
+	}
+}
+
+// timeValue converts a time.Time, a pointer to one or a string in a sniffed
+// time layout into a time.Time.
+func timeValue(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, true
+	case *time.Time:
+		if v == nil {
+			return time.Time{}, false
+		}
+		return *v, true
+	case string:
+		t, _, ok := parseSniffedTime(strings.TrimSpace(v))
+		return t, ok
+	}
+	return time.Time{}, false
+}
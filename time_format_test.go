@@ -0,0 +1,47 @@
+package pretty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFormatTime(t *testing.T) {
+	moment := time.Date(2024, time.March, 5, 14, 30, 0, 0, time.UTC)
+	assert.EqualString(t, "2:30PM", FormatTime("Kitchen")(moment))
+	assert.EqualString(t, "2024-03-05", FormatTime("DateOnly")(&moment))
+	assert.EqualString(
+		t,
+		"05 Mar 24 14:30",
+		FormatTime("02 Jan 06 15:04")("2024-03-05T14:30:00Z"))
+	assert.EqualString(t, "never", FormatTime(time.RFC3339)("never"))
+	assert.EqualString(t, "", FormatTime(time.RFC3339)((*time.Time)(nil)))
+}
+
+func TestFormatTimeColumn(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Event"),
+			NewColumnDef("At").WithFormatter(FormatTime("TimeOnly")),
+		},
+		WithColor(false),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("boot", "2024-03-05 09:15:00"))
+	assert.Nil(t, table.AddValues(
+		"login",
+		time.Date(2024, time.March, 5, 9, 16, 30, 0, time.UTC)))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+-------+----------+\n"+
+			"| Event | At       |\n"+
+			"+-------+----------+\n"+
+			"|  boot | 09:15:00 |\n"+
+			"| login | 09:16:30 |\n"+
+			"+-------+----------+\n",
+		rendered)
+}
@@ -0,0 +1,179 @@
+package pretty
+
+import (
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+)
+
+// Timeline draws labeled spans of time as bars on a shared time axis, like a
+// Gantt chart, so that the scheduling and overlap of jobs can be seen:
+//
+//	backup  │████████████        │ 45m0s
+//	restore │       █████████████│ 50m0s
+//	        └────────────────────┘
+//	         10:00          11:20
+//
+// Each bar is followed by the duration of its span.
+type Timeline struct {
+	spans []timelineSpan
+
+	width        int
+	colorEnabled *bool
+}
+
+type timelineSpan struct {
+	label string
+	start time.Time
+	end   time.Time
+}
+
+// minTimelineWidth is the narrowest a Timeline draws its time axis.
+const minTimelineWidth = 10
+
+// NewTimeline creates an empty Timeline.
+func NewTimeline() *Timeline {
+	return &Timeline{}
+}
+
+// Add adds a row for the span from start to end, and returns the Timeline. A
+// span ending before it starts is drawn as a single point.
+func (timeline *Timeline) Add(
+	label string,
+	start time.Time,
+	end time.Time,
+) *Timeline {
+	if end.Before(start) {
+		end = start
+	}
+	timeline.spans = append(
+		timeline.spans,
+		timelineSpan{label: label, start: start, end: end})
+	return timeline
+}
+
+// SetWidth sets the total width of a line. A width of 0, the default, uses
+// the width of the terminal, or defaultTerminalWidth when not writing to one.
+func (timeline *Timeline) SetWidth(width int) {
+	timeline.width = width
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (timeline *Timeline) SetColor(enabled bool) {
+	timeline.colorEnabled = &enabled
+}
+
+// PrettyString renders the timeline, sized as if written to stdout.
+func (timeline *Timeline) PrettyString() string {
+	return timeline.render(os.Stdout)
+}
+
+// Print prints the timeline to stdout.
+func (timeline *Timeline) Print() error {
+	return timeline.Fprint(os.Stdout)
+}
+
+// Fprint prints the timeline to w.
+func (timeline *Timeline) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), timeline.render(w))
+	return err
+}
+
+// render draws the timeline to fit the width of w if it is a terminal.
+func (timeline *Timeline) render(w io.Writer) string {
+	if len(timeline.spans) == 0 {
+		return ""
+	}
+	width := timeline.width
+	if width == 0 {
+		var ok bool
+		if width, ok = terminalWidth(w); !ok {
+			width = defaultTerminalWidth
+		}
+	}
+	colorEnabled := colorsEnabled(timeline.colorEnabled)
+
+	first := timeline.spans[0].start
+	last := timeline.spans[0].end
+	labelWidth := 0
+	durationWidth := 0
+	durations := make([]string, len(timeline.spans))
+	for i, span := range timeline.spans {
+		if span.start.Before(first) {
+			first = span.start
+		}
+		if span.end.After(last) {
+			last = span.end
+		}
+		if length := strLengthWithEncoding(span.label); length > labelWidth {
+			labelWidth = length
+		}
+		durations[i] = span.end.Sub(span.start).Round(time.Second).String()
+		if len(durations[i]) > durationWidth {
+			durationWidth = len(durations[i])
+		}
+	}
+
+	// Each line has the label, a space and a border before the axis, and a
+	// border, a space and the duration after it.
+	axisWidth := width - labelWidth - durationWidth - 4
+	if axisWidth < minTimelineWidth {
+		axisWidth = minTimelineWidth
+	}
+	total := last.Sub(first)
+	column := func(t time.Time, round func(float64) float64) int {
+		if total <= 0 {
+			return 0
+		}
+		return int(round(
+			float64(t.Sub(first)) / float64(total) * float64(axisWidth)))
+	}
+
+	var builder strings.Builder
+	for i, span := range timeline.spans {
+		start := column(span.start, math.Floor)
+		end := column(span.end, math.Ceil)
+		if end == start {
+			if start == axisWidth {
+				start--
+			} else {
+				end++
+			}
+		}
+		builder.WriteString(span.label)
+		builder.WriteString(strings.Repeat(
+			" ",
+			labelWidth-strLengthWithEncoding(span.label)+1))
+		builder.WriteString(BorderLight.Vertical)
+		builder.WriteString(strings.Repeat(" ", start))
+		builder.WriteString(colorize(
+			strings.Repeat("█", end-start),
+			colorEnabled,
+			columnColors[i%len(columnColors)]))
+		builder.WriteString(strings.Repeat(" ", axisWidth-end))
+		builder.WriteString(BorderLight.Vertical + " " + durations[i] + "\n")
+	}
+
+	indent := strings.Repeat(" ", labelWidth+1)
+	builder.WriteString(indent + BorderLight.BottomLeft +
+		strings.Repeat(BorderLight.Horizontal, axisWidth) +
+		BorderLight.BottomRight + "\n")
+
+	// Times are shown with their date unless all spans are within a day.
+	layout := "15:04"
+	if first.YearDay() != last.YearDay() || first.Year() != last.Year() {
+		layout = "Jan 2 15:04"
+	}
+	startLabel := first.Format(layout)
+	endLabel := last.Format(layout)
+	gap := axisWidth - len(startLabel) - len(endLabel)
+	if gap < 1 {
+		gap = 1
+	}
+	builder.WriteString(indent + " " + startLabel + strings.Repeat(" ", gap) +
+		endLabel + "\n")
+	return builder.String()
+}
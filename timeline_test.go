@@ -0,0 +1,40 @@
+package pretty
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTimeline(t *testing.T) {
+	timeline := NewTimeline()
+	timeline.SetColor(false)
+	assert.EqualString(t, "", timeline.PrettyString())
+
+	start := time.Date(2019, 3, 14, 10, 0, 0, 0, time.UTC)
+	timeline.
+		Add("backup", start, start.Add(45*time.Minute)).
+		Add("restore", start.Add(30*time.Minute), start.Add(80*time.Minute)).
+		Add("check", start.Add(80*time.Minute), start)
+	timeline.SetWidth(36)
+
+	var buffer bytes.Buffer
+	assert.Nil(t, timeline.Fprint(&buffer))
+	assert.EqualString(
+		t,
+		"backup  │████████████        │ 45m0s\n"+
+			"restore │       █████████████│ 50m0s\n"+
+			"check   │                   █│ 0s\n"+
+			"        └────────────────────┘\n"+
+			"         10:00          11:20\n",
+		buffer.String())
+
+	// Times are shown with dates if the spans cross midnight.
+	timeline.Add("late", start.AddDate(0, 0, 1), start.AddDate(0, 0, 1))
+	output := timeline.PrettyString()
+	assert.True(t, strings.Contains(output, " Mar 14 10:00 "))
+	assert.True(t, strings.HasSuffix(output, " Mar 15 10:00\n"))
+}
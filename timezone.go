@@ -0,0 +1,70 @@
+package pretty
+
+import (
+	"strings"
+	"time"
+)
+
+// SetTimeZone sets the time zone in which times are displayed, such as
+// time.Local, unless their column sets its own with ColumnDef.WithTimeZone.
+// Time values, and strings in the formats recognized by type sniffing, are
+// converted when the table is rendered and displayed in RFC 3339 format, or
+// passed to the formatter of their column. Strings without an offset are
+// taken to be in UTC, and dates without a time of day are left as they are.
+// A nil location displays times as they are stored.
+func (table *Table) SetTimeZone(location *time.Location) {
+	table.timeZone = location
+}
+
+// WithTimeZone sets the time zone in which times are displayed. See
+// Table.SetTimeZone.
+func WithTimeZone(location *time.Location) Option {
+	return func(table *Table) error {
+		table.SetTimeZone(location)
+		return nil
+	}
+}
+
+// WithTimeZone returns a copy of the ColumnDef whose times are displayed in
+// the given time zone, overriding the time zone of the table. See
+// Table.SetTimeZone.
+func (columnDef ColumnDef) WithTimeZone(location *time.Location) ColumnDef {
+	columnDef.timeZone = location
+	return columnDef
+}
+
+// zonedColumnDefs returns the column definitions with the time zone of the
+// table filled in.
+func (table *Table) zonedColumnDefs(columnDefs []ColumnDef) []ColumnDef {
+	if table.timeZone == nil {
+		return columnDefs
+	}
+	zoned := make([]ColumnDef, len(columnDefs))
+	for i, columnDef := range columnDefs {
+		if columnDef.timeZone == nil {
+			columnDef.timeZone = table.timeZone
+		}
+		zoned[i] = columnDef
+	}
+	return zoned
+}
+
+// inTimeZone converts value to the time zone of the column if it is a time
+// with a time of day, and returns it unchanged otherwise.
+func (columnDef ColumnDef) inTimeZone(value interface{}) interface{} {
+	if columnDef.timeZone == nil {
+		return value
+	}
+	if str, ok := value.(string); ok {
+		t, layout, ok := parseSniffedTime(strings.TrimSpace(str))
+		if !ok || layout.dateOnly {
+			return value
+		}
+		return t.In(columnDef.timeZone)
+	}
+	t, ok := timeValue(value)
+	if !ok {
+		return value
+	}
+	return t.In(columnDef.timeZone)
+}
@@ -0,0 +1,64 @@
+package pretty
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTimeZone(t *testing.T) {
+	eastern := time.FixedZone("EST", -5*60*60)
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("Event"),
+			NewColumnDef("At").WithAlignment(LeftJustify),
+			NewColumnDef("Local").
+				WithFormatter(FormatTime(time.Kitchen)).
+				WithTimeZone(time.FixedZone("CET", 60*60)),
+		},
+		WithColor(false),
+		WithTimeZone(eastern),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow(
+		"boot",
+		"2024-03-05T14:30:00Z",
+		"2024-03-05 14:30:00"))
+	assert.Nil(t, table.AddRow("holiday", "2024-03-05", "-"))
+	assert.Nil(t, table.AddValues(
+		"login",
+		time.Date(2024, time.March, 5, 15, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 5, 15, 0, 0, 0, time.UTC)))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+---------+---------------------------+--------+\n"+
+			"| Event   | At                        | Local  |\n"+
+			"+---------+---------------------------+--------+\n"+
+			"|    boot | 2024-03-05T09:30:00-05:00 | 3:30PM |\n"+
+			"| holiday | 2024-03-05                |      - |\n"+
+			"|   login | 2024-03-05T10:00:00-05:00 | 4:00PM |\n"+
+			"+---------+---------------------------+--------+\n",
+		rendered)
+}
+
+func TestOutputFlagsTimeZone(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("At")},
+		WithTimeZone(time.FixedZone("EST", -5*60*60)),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("2024-03-05T14:30:00+01:00"))
+
+	flags := OutputFlags{Output: "csv", TimeZone: "UTC"}
+	var buffer bytes.Buffer
+	assert.Nil(t, flags.Print(&buffer, table))
+	assert.EqualString(t, "At\n2024-03-05T13:30:00Z\n", buffer.String())
+
+	flags.TimeZone = "Nowhere/Special"
+	assert.True(t, flags.Print(&buffer, table) != nil)
+}
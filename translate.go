@@ -0,0 +1,63 @@
+package pretty
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Translator translates the built-in text of this package into the language
+// of the user. It is given the English format of a message, as passed to
+// fmt.Sprintf, and returns the format to use instead, with the same verbs, or
+// the format unchanged if it has no translation. The formats are:
+//
+//	Count: %d                       the row count of a table
+//	Row %d: %s.                     a row in the accessible rendering
+//	Note: %s                        a row annotation, read out
+//	%d-%d of %d, %d of %d           the rows shown by Interactive
+//	column: %s, search: %s          the column and search of Interactive
+//	(s sort, / search, q quit)      the keys of Interactive
+//	Go version, Goroutines, ...     the statistics of RuntimeStatsTable
+//
+// Dates and times are formatted in English by the time package.
+type Translator func(format string) string
+
+// Catalog is a Translator that looks up formats in a map, for a fixed set of
+// translations:
+//
+//	pretty.SetTranslator(pretty.Catalog{
+//		"Count: %d": "Anzahl: %d",
+//	}.Translate)
+type Catalog map[string]string
+
+// Translate returns the translation of format in the catalog, or format if
+// there is none.
+func (catalog Catalog) Translate(format string) string {
+	if translation, ok := catalog[format]; ok {
+		return translation
+	}
+	return format
+}
+
+var (
+	translatorMu sync.RWMutex
+	translator   Translator
+)
+
+// SetTranslator sets the Translator of built-in text for the whole program.
+// A nil translator, the default, leaves the text in English.
+func SetTranslator(newTranslator Translator) {
+	translatorMu.Lock()
+	defer translatorMu.Unlock()
+	translator = newTranslator
+}
+
+// translate formats a built-in message, translating its format first.
+func translate(format string, args ...interface{}) string {
+	translatorMu.RLock()
+	translate := translator
+	translatorMu.RUnlock()
+	if translate != nil {
+		format = translate(format)
+	}
+	return fmt.Sprintf(format, args...)
+}
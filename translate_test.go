@@ -0,0 +1,41 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTranslator(t *testing.T) {
+	SetTranslator(Catalog{
+		"Count: %d":   "Anzahl: %d",
+		"Row %d: %s.": "Zeile %d: %s.",
+	}.Translate)
+	defer SetTranslator(nil)
+
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name")},
+		WithRowCount(true),
+		WithColor(false))
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("Noel"))
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+------+\n"+
+			"| Name |\n"+
+			"+------+\n"+
+			"| Noel |\n"+
+			"+------+\n"+
+			"Anzahl: 1\n",
+		output)
+
+	table.SetAccessible(true)
+	output, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(t, "Zeile 1: Name=Noel.\nAnzahl: 1\n", output)
+
+	// Formats without a translation are kept.
+	assert.Equal(t, "search: vm", translate("search: %s", "vm"))
+}
@@ -0,0 +1,167 @@
+package pretty
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Tree renders hierarchical data, with each node on its own line below its
+// parent, joined by connectors such as:
+//
+//	cluster
+//	├── node-1
+//	│   └── disk-1
+//	└── node-2
+//
+// Every node is itself a Tree. Only the settings of the node being rendered
+// apply; those of its descendants are ignored.
+type Tree struct {
+	label      string
+	attributes []color.Attribute
+	children   []*Tree
+
+	maxDepth     int
+	border       BorderStyle
+	colorEnabled *bool
+}
+
+// NewTree creates a Tree with the given label and no children.
+func NewTree(label string) *Tree {
+	return &Tree{label: label}
+}
+
+// AddChild adds a node with the given label below this one, and returns it so
+// that its own children can be added.
+func (tree *Tree) AddChild(label string) *Tree {
+	child := NewTree(label)
+	tree.children = append(tree.children, child)
+	return child
+}
+
+// AddTree adds existing trees below this node, and returns this node.
+func (tree *Tree) AddTree(children ...*Tree) *Tree {
+	tree.children = append(tree.children, children...)
+	return tree
+}
+
+// SetLabelColor sets the color attributes of the label of this node, such as
+// color.FgRed or color.Bold.
+func (tree *Tree) SetLabelColor(attributes ...color.Attribute) {
+	tree.attributes = attributes
+}
+
+// SetMaxDepth limits the number of levels rendered below this node. Nodes
+// with hidden children are followed by a "..." node. A maxDepth of 0 removes
+// the limit.
+func (tree *Tree) SetMaxDepth(maxDepth int) {
+	tree.maxDepth = maxDepth
+}
+
+// SetBorderStyle sets the characters used to draw the connectors, which
+// default to BorderLight.
+func (tree *Tree) SetBorderStyle(style BorderStyle) {
+	tree.border = style
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (tree *Tree) SetColor(enabled bool) {
+	tree.colorEnabled = &enabled
+}
+
+// PrettyString renders the tree.
+func (tree *Tree) PrettyString() string {
+	style := tree.border
+	if style == (BorderStyle{}) {
+		style = BorderLight
+	}
+	connector := strings.Repeat(style.Horizontal, 2) + " "
+	renderer := &treeRenderer{
+		branch:       style.MiddleLeft + connector,
+		lastBranch:   style.BottomLeft + connector,
+		indent:       style.Vertical + "   ",
+		lastIndent:   "    ",
+		maxDepth:     tree.maxDepth,
+		colorEnabled: colorsEnabled(tree.colorEnabled),
+	}
+
+	var builder strings.Builder
+	renderer.renderNode(&builder, tree, "", "", 0)
+	return builder.String()
+}
+
+// Print prints the tree to stdout.
+func (tree *Tree) Print() error {
+	return tree.Fprint(os.Stdout)
+}
+
+// Fprint prints the tree to w.
+func (tree *Tree) Fprint(w io.Writer) error {
+	_, err := io.WriteString(consoleWriter(w), tree.PrettyString())
+	return err
+}
+
+// treeRenderer holds the settings used to render every node of a tree.
+type treeRenderer struct {
+	branch       string
+	lastBranch   string
+	indent       string
+	lastIndent   string
+	maxDepth     int
+	colorEnabled bool
+}
+
+// renderNode writes a node and its descendants. prefix starts the first line
+// of the node, and childPrefix every line below it.
+func (renderer *treeRenderer) renderNode(
+	builder *strings.Builder,
+	node *Tree,
+	prefix string,
+	childPrefix string,
+	depth int,
+) {
+	children := node.children
+	truncated := renderer.maxDepth > 0 && depth == renderer.maxDepth &&
+		len(children) > 0
+	if truncated {
+		children = []*Tree{{label: "..."}}
+	}
+
+	// Continuation lines of a multi-line label keep the line to the children
+	// going.
+	continuation := childPrefix + "  "
+	if len(children) > 0 {
+		continuation = childPrefix + strings.TrimSuffix(renderer.indent, "  ")
+	}
+	for i, line := range strings.Split(node.label, "\n") {
+		if i == 0 {
+			builder.WriteString(prefix)
+		} else {
+			builder.WriteString(continuation)
+		}
+		builder.WriteString(
+			colorize(line, renderer.colorEnabled, node.attributes...))
+		builder.WriteString("\n")
+	}
+
+	for i, child := range children {
+		if i == len(children)-1 {
+			renderer.renderNode(
+				builder,
+				child,
+				childPrefix+renderer.lastBranch,
+				childPrefix+renderer.lastIndent,
+				depth+1)
+		} else {
+			renderer.renderNode(
+				builder,
+				child,
+				childPrefix+renderer.branch,
+				childPrefix+renderer.indent,
+				depth+1)
+		}
+	}
+}
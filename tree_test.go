@@ -0,0 +1,52 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func createClusterTree() *Tree {
+	tree := NewTree("cluster")
+	node := tree.AddChild("node-1")
+	node.AddChild("disk-1")
+	node.AddChild("disk-2\n(degraded)").AddChild("partition-1")
+	tree.AddChild("node-2").AddChild("disk-3")
+	tree.AddTree(NewTree("node-3"))
+	return tree
+}
+
+func TestTree(t *testing.T) {
+	tree := createClusterTree()
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/tree.txt"),
+		tree.PrettyString())
+}
+
+func TestTreeWithMaxDepthAndASCII(t *testing.T) {
+	tree := createClusterTree()
+	tree.SetMaxDepth(1)
+	tree.SetBorderStyle(BorderASCII)
+	assert.EqualString(
+		t,
+		readFileAsString(t, "test/tree_with_max_depth.txt"),
+		tree.PrettyString())
+}
+
+func TestTreeLabelColor(t *testing.T) {
+	tree := NewTree("root")
+	tree.AddChild("failed").SetLabelColor(color.FgRed)
+	tree.SetColor(true)
+
+	printer := color.New(color.FgRed)
+	printer.EnableColor()
+	assert.EqualString(
+		t,
+		"root\n└── "+printer.Sprint("failed")+"\n",
+		tree.PrettyString())
+
+	tree.SetColor(false)
+	assert.EqualString(t, "root\n└── failed\n", tree.PrettyString())
+}
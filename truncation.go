@@ -0,0 +1,67 @@
+package pretty
+
+// Truncation describes a cell whose value was cut to fit its column.
+type Truncation struct {
+	// Row is the number of the row in the table, counting from 1.
+	Row int
+	// Column is the name of the column.
+	Column string
+	// Length is the width of the whole value, in terminal columns.
+	Length int
+	// Width is the width it was cut to.
+	Width int
+}
+
+// Truncations returns the cells that were truncated by the last rendering of
+// the table, in row order.
+func (table *Table) Truncations() []Truncation {
+	return table.truncations
+}
+
+// SetTruncationNote sets a line printed below the table when any value was
+// truncated, so that readers know data was cut, as in
+// "* some values truncated; use --wide". An empty note, the default, prints
+// nothing.
+func (table *Table) SetTruncationNote(note string) {
+	table.truncationNote = note
+}
+
+// WithTruncationNote sets a line printed below the table when any value was
+// truncated. See Table.SetTruncationNote.
+func WithTruncationNote(note string) Option {
+	return func(table *Table) error {
+		table.SetTruncationNote(note)
+		return nil
+	}
+}
+
+// recordTruncations records the cells of the given rendered rows that are
+// wider than their columns and not wrapped by the renderer. The first row is
+// the row at index offset in the table.
+func (table *Table) recordTruncations(
+	columnDefs []ColumnDef,
+	rows [][]string,
+	offset int,
+	renderer *rowRenderer,
+) {
+	for i, row := range rows {
+		for column, cell := range row {
+			width := renderer.contentWidth(column)
+			// A value is never wider than it is long in bytes.
+			if len(cell) <= width ||
+				renderer.overflow(column).wraps() {
+				continue
+			}
+			length := strLengthWithEncoding(cell)
+			if length <= width {
+				continue
+			}
+			table.truncations = append(table.truncations, Truncation{
+				Row:    offset + i + 1,
+				Column: columnDefs[column].name,
+				Length: length,
+				Width:  width,
+			})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestTruncations(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDefWithWidth("Name", 6),
+			NewColumnDef("Path"),
+		},
+		WithColor(false),
+		WithTruncationNote("* some values truncated; use --wide"),
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRows([][]string{
+		{"short", "/a"},
+		{"much longer", "/b"},
+	}))
+
+	rendered, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"+--------+------+\n"+
+			"| Name   | Path |\n"+
+			"+--------+------+\n"+
+			"|  short |   /a |\n"+
+			"| muc... |   /b |\n"+
+			"+--------+------+\n"+
+			"* some values truncated; use --wide\n",
+		rendered,
+	)
+	assert.DeepEqual(
+		t,
+		[]Truncation{{Row: 2, Column: "Name", Length: 11, Width: 6}},
+		table.Truncations(),
+	)
+
+	assert.Nil(t, table.SetRows([][]string{{"short", "/a"}}))
+	rendered, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.EqualInt(t, 0, len(table.Truncations()))
+	assert.EqualString(
+		t,
+		"+-------+------+\n"+
+			"| Name  | Path |\n"+
+			"+-------+------+\n"+
+			"| short |   /a |\n"+
+			"+-------+------+\n",
+		rendered,
+	)
+}
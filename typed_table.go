@@ -0,0 +1,120 @@
+package pretty
+
+import "sort"
+
+// TypedColumn is a column of a TypedTable, whose values are selected from
+// each item by a function.
+type TypedColumn[T any] struct {
+	columnDef ColumnDef
+	value     func(item T) string
+}
+
+// NewTypedColumn creates a TypedColumn displaying the value selected from each
+// item by value.
+func NewTypedColumn[T any](
+	columnDef ColumnDef,
+	value func(item T) string,
+) TypedColumn[T] {
+	return TypedColumn[T]{
+		columnDef: columnDef,
+		value:     value,
+	}
+}
+
+// TypedTable is a table whose rows are values of type T rather than lists of
+// strings, so that rows can be added, sorted and filtered with compile-time
+// type safety. It is converted to a Table for rendering.
+type TypedTable[T any] struct {
+	columns []TypedColumn[T]
+	items   []T
+}
+
+// NewTypedTable creates a new TypedTable with the given columns.
+func NewTypedTable[T any](columns ...TypedColumn[T]) (*TypedTable[T], error) {
+	columnDefs := make([]ColumnDef, len(columns))
+	for i, column := range columns {
+		columnDefs[i] = column.columnDef
+	}
+	// Validate the column definitions the same way as a regular table.
+	if _, err := NewPrettyTable(columnDefs...); err != nil {
+		return nil, err
+	}
+
+	return &TypedTable[T]{columns: columns}, nil
+}
+
+// Add adds items to the table, one row per item.
+func (table *TypedTable[T]) Add(items ...T) {
+	table.items = append(table.items, items...)
+}
+
+// Items returns the items of the table in display order.
+func (table *TypedTable[T]) Items() []T {
+	return table.items
+}
+
+// Sort sorts the items of the table by less, keeping equal items in their
+// original order.
+func (table *TypedTable[T]) Sort(less func(a, b T) bool) {
+	sort.SliceStable(table.items, func(i, j int) bool {
+		return less(table.items[i], table.items[j])
+	})
+}
+
+// Filter removes the items of the table for which keep returns false.
+func (table *TypedTable[T]) Filter(keep func(item T) bool) {
+	kept := table.items[:0]
+	for _, item := range table.items {
+		if keep(item) {
+			kept = append(kept, item)
+		}
+	}
+	table.items = kept
+}
+
+// Table converts the items into a Table configured by opts.
+func (table *TypedTable[T]) Table(opts ...Option) (*Table, error) {
+	columnDefs := make([]ColumnDef, len(table.columns))
+	for i, column := range table.columns {
+		columnDefs[i] = column.columnDef
+	}
+	prettyTable, err := NewPrettyTable(columnDefs...)
+	if err != nil {
+		return nil, err
+	}
+	if err := prettyTable.applyOptions(opts); err != nil {
+		return nil, err
+	}
+
+	for _, item := range table.items {
+		row := make([]string, 0, len(table.columns))
+		for _, column := range table.columns {
+			// Derived columns are computed by the Table itself.
+			if column.columnDef.derive == nil {
+				row = append(row, column.value(item))
+			}
+		}
+		if err := prettyTable.AddRow(row...); err != nil {
+			return nil, err
+		}
+	}
+	return prettyTable, nil
+}
+
+// PrettyString creates the pretty string representing this table.
+func (table *TypedTable[T]) PrettyString() (string, error) {
+	prettyTable, err := table.Table()
+	if err != nil {
+		return "", err
+	}
+	return prettyTable.PrettyString()
+}
+
+// Print prints the table to stdout.
+func (table *TypedTable[T]) Print() error {
+	prettyTable, err := table.Table()
+	if err != nil {
+		return err
+	}
+	return prettyTable.Print()
+}
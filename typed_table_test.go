@@ -0,0 +1,53 @@
+package pretty
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+type person struct {
+	number int
+	name   string
+	kind   string
+	phone  string
+}
+
+func TestTypedTable(t *testing.T) {
+	table, err := NewTypedTable(
+		NewTypedColumn(
+			NewColumnDef("Employee Number"),
+			func(p person) string { return strconv.Itoa(p.number) }),
+		NewTypedColumn(
+			NewColumnDef("Name"),
+			func(p person) string { return p.name }),
+		NewTypedColumn(
+			NewColumnDef("Type"),
+			func(p person) string { return p.kind }),
+		NewTypedColumn(
+			NewColumnDef("Phone Number"),
+			func(p person) string { return p.phone }))
+	assert.Nil(t, err)
+
+	table.Add(
+		person{1182, "Postnava", "Kitten", "1 (800) 987-6543"},
+		person{52, "Pranava", "Crusher", "1-800-123-4567"},
+		person{7, "Ada", "Ghost", "555-0100"},
+		person{23, "Noel", "Human", "(123) 456-7899"},
+		person{83, "David", "Cyborg", "987-654-3211"})
+
+	table.Filter(func(p person) bool { return p.kind != "Ghost" })
+	table.Sort(func(a, b person) bool { return a.name > b.name })
+	assert.EqualInt(t, 4, len(table.Items()))
+
+	prettyTable, err := table.Table(WithHeader("Employees"))
+	assert.Nil(t, err)
+	assertExpectedTable(t, prettyTable, "typed_table.txt")
+}
+
+func TestTypedTableWithInvalidColumns(t *testing.T) {
+	table, err := NewTypedTable[person]()
+	assert.NotNil(t, err)
+	assert.Nil(t, table)
+}
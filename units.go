@@ -0,0 +1,46 @@
+package pretty
+
+import (
+	"math"
+	"reflect"
+)
+
+// byteUnits are the binary units of FormatBytes, each 1024 times the last.
+var byteUnits = []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+
+// FormatBytes is a Formatter that renders a number of bytes in binary units,
+// rounded to one decimal, such as "512 B", "1.5 KiB" or "16 GiB". Values may
+// be of any numeric type, or numeric strings as stored by AddRow. Other values
+// are formatted with fmt.Sprint.
+func FormatBytes(value interface{}) string {
+	number, ok := numberValue(value)
+	if !ok {
+		return formatValue(value)
+	}
+	return formatBytes(number)
+}
+
+func formatBytes(bytes float64) string {
+	unit := 0
+	for math.Abs(bytes) >= 1024 && unit < len(byteUnits)-1 {
+		bytes /= 1024
+		unit++
+	}
+	rounded := math.Round(bytes*10) / 10
+	// Rounding may reach the next unit, as 1023.96 KiB does.
+	if math.Abs(rounded) >= 1024 && unit < len(byteUnits)-1 {
+		rounded = math.Round(bytes/1024*10) / 10
+		unit++
+	}
+	return formatNumeric(rounded) + " " + byteUnits[unit]
+}
+
+// numberValue converts a value of any numeric type, or a numeric string, into
+// a float64.
+func numberValue(value interface{}) (float64, bool) {
+	if str, ok := value.(string); ok {
+		number, ok, err := parseNumeric(str)
+		return number, ok && err == nil
+	}
+	return numericValue(reflect.ValueOf(value))
+}
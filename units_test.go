@@ -0,0 +1,18 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "0 B", FormatBytes(0))
+	assert.Equal(t, "1023 B", FormatBytes(uint16(1023)))
+	assert.Equal(t, "1.5 KiB", FormatBytes(1536))
+	assert.Equal(t, "1 MiB", FormatBytes(1048565))
+	assert.Equal(t, "16 GiB", FormatBytes("17179869184"))
+	assert.Equal(t, "-2 KiB", FormatBytes(-2048.0))
+	assert.Equal(t, "many", FormatBytes("many"))
+	assert.Equal(t, "", FormatBytes(nil))
+}
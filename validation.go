@@ -0,0 +1,166 @@
+package pretty
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidationError describes a row that was rejected when it was added to a
+// table. Several are joined with errors.Join when more than one row or cell
+// is at fault; errors.As finds the first.
+type ValidationError struct {
+	// Row is the number of the row in the table, counting from 1.
+	Row int
+	// Column is the name of the offending column, or empty if the problem
+	// is with the row as a whole, such as its length.
+	Column string
+	// Value is the offending cell value, or the whole row if Column is
+	// empty.
+	Value interface{}
+	// Err describes the problem.
+	Err error
+}
+
+func (err *ValidationError) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "row %d", err.Row)
+	if err.Column != "" {
+		fmt.Fprintf(&builder, ", column %q", err.Column)
+	}
+	fmt.Fprintf(&builder, ": %v", err.Err)
+	switch value := err.Value.(type) {
+	case nil:
+	case string, []string:
+		fmt.Fprintf(&builder, " (got %q)", value)
+	default:
+		fmt.Fprintf(&builder, " (got %v)", value)
+	}
+	return builder.String()
+}
+
+func (err *ValidationError) Unwrap() error {
+	return err.Err
+}
+
+// validateRow checks the length and values of a row to be added as the given
+// row number.
+func (table *Table) validateRow(row []string, number int) error {
+	if len(row) != table.inputColumnCount() {
+		return &ValidationError{
+			Row:   number,
+			Value: row,
+			Err: fmt.Errorf(
+				"length %d must match columns %d",
+				len(row),
+				table.inputColumnCount()),
+		}
+	}
+	return validateValues(table, row, number)
+}
+
+// validateRows checks rows to be added after offset rows, reporting every
+// invalid row.
+func (table *Table) validateRows(rows [][]string, offset int) error {
+	var errs []error
+	for i, row := range rows {
+		if err := table.validateRow(row, offset+i+1); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Validator checks a value before it is added to a column, returning an error
+// that describes why the value is invalid.
+type Validator func(value string) error
+
+// WithValidators returns a copy of the ColumnDef whose values are checked by
+// validators when rows are added, so that malformed data is rejected early
+// rather than found in an export. Raw values added with AddValues are checked
+// as strings if they are strings, and not otherwise.
+func (columnDef ColumnDef) WithValidators(validators ...Validator) ColumnDef {
+	combined := make(
+		[]Validator,
+		0,
+		len(columnDef.validators)+len(validators),
+	)
+	combined = append(combined, columnDef.validators...)
+	columnDef.validators = append(combined, validators...)
+	return columnDef
+}
+
+// ValidatePattern returns a Validator that accepts values matching pattern.
+func ValidatePattern(pattern *regexp.Regexp) Validator {
+	return func(value string) error {
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("must match %q", pattern)
+		}
+		return nil
+	}
+}
+
+// ValidateMaxLength returns a Validator that accepts values of at most
+// maxLength characters.
+func ValidateMaxLength(maxLength int) Validator {
+	return func(value string) error {
+		if length := utf8.RuneCountInString(value); length > maxLength {
+			return fmt.Errorf("length %d exceeds %d", length, maxLength)
+		}
+		return nil
+	}
+}
+
+// ValidateOneOf returns a Validator that accepts only the given values.
+func ValidateOneOf(values ...string) Validator {
+	allowed := make(map[string]bool, len(values))
+	for _, value := range values {
+		allowed[value] = true
+	}
+	return func(value string) error {
+		if !allowed[value] {
+			return fmt.Errorf("must be one of %q", values)
+		}
+		return nil
+	}
+}
+
+// validatesValues reports whether any column has validators.
+func (table *Table) validatesValues() bool {
+	for _, columnDef := range table.columnDefs {
+		if len(columnDef.validators) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// validateValues runs the validators of each input column on the values of a
+// row to be added as the given row number, reporting every invalid value.
+func validateValues[T any](table *Table, row []T, number int) error {
+	var errs []error
+	input := 0
+	for _, columnDef := range table.columnDefs {
+		if columnDef.derive != nil {
+			continue
+		}
+		value, ok := interface{}(row[input]).(string)
+		input++
+		if !ok {
+			continue
+		}
+		for _, validator := range columnDef.validators {
+			if err := validator(value); err != nil {
+				errs = append(errs, &ValidationError{
+					Row:    number,
+					Column: columnDef.name,
+					Value:  value,
+					Err:    err,
+				})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+package pretty
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestValidationError(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{NewColumnDef("Name"), NewColumnDef("Count")},
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("a", "1"))
+
+	err = table.AddRow("b")
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.EqualInt(t, 2, validationErr.Row)
+	assert.EqualString(t, "", validationErr.Column)
+	assert.DeepEqual(t, []string{"b"}, validationErr.Value)
+	assert.EqualString(
+		t,
+		`row 2: length 1 must match columns 2 (got ["b"])`,
+		err.Error(),
+	)
+
+	err = table.AddRows([][]string{{"c", "3"}, {"d"}, {"e", "5", "x"}})
+	assert.EqualString(
+		t,
+		"row 3: length 1 must match columns 2 (got [\"d\"])\n"+
+			"row 4: length 3 must match columns 2 (got [\"e\" \"5\" \"x\"])",
+		err.Error(),
+	)
+	assert.EqualInt(t, 1, table.rowCount())
+
+	message := (&ValidationError{
+		Row:    1,
+		Column: "Count",
+		Value:  -1,
+		Err:    errors.New("must not be negative"),
+	}).Error()
+	assert.EqualString(
+		t,
+		`row 1, column "Count": must not be negative (got -1)`,
+		message,
+	)
+}
+
+func TestValidators(t *testing.T) {
+	table, err := NewTable(
+		[]ColumnDef{
+			NewColumnDef("ID").WithValidators(
+				ValidatePattern(regexp.MustCompile(`^[a-z]+-\d+$`)),
+			),
+			NewDerivedColumnDef("Upper", func(row map[string]string) string {
+				return strings.ToUpper(row["ID"])
+			}),
+			NewColumnDef("State").WithValidators(
+				ValidateOneOf("ok", "failed"),
+				ValidateMaxLength(4),
+			),
+		},
+	)
+	assert.Nil(t, err)
+	assert.Nil(t, table.AddRow("node-1", "ok"))
+
+	err = table.AddRow("Node 2", "failed")
+	assert.EqualString(
+		t,
+		"row 2, column \"ID\": must match \"^[a-z]+-\\\\d+$\" "+
+			"(got \"Node 2\")\n"+
+			"row 2, column \"State\": length 6 exceeds 4 (got \"failed\")",
+		err.Error(),
+	)
+
+	err = table.AddRows([][]string{{"node-2", "ok"}, {"node-3", "gone"}})
+	assert.EqualString(
+		t,
+		"row 3, column \"State\": must be one of [\"ok\" \"failed\"] "+
+			"(got \"gone\")",
+		err.Error(),
+	)
+	assert.EqualInt(t, 1, table.rowCount())
+
+	assert.NotNil(t, table.AddValues("node 4", 4))
+	assert.Nil(t, table.AddValues("node-4", 4))
+	assert.EqualInt(t, 2, table.rowCount())
+}
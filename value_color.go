@@ -0,0 +1,69 @@
+package pretty
+
+import (
+	"github.com/fatih/color"
+)
+
+// WithValueColor returns a copy of the ColumnDef whose cells are colored by
+// valueColor, which is given the displayed value of each cell. Returning
+// color.Reset keeps the color the cell would otherwise have. Rows
+// highlighted by Diff keep their colors.
+func (columnDef ColumnDef) WithValueColor(
+	valueColor func(value string) color.Attribute,
+) ColumnDef {
+	columnDef.valueColor = valueColor
+	return columnDef
+}
+
+// columnValueColors returns the functions coloring the values of each
+// column, or nil if no column has one.
+func columnValueColors(
+	columnDefs []ColumnDef,
+) []func(value string) color.Attribute {
+	var valueColors []func(value string) color.Attribute
+	for i, columnDef := range columnDefs {
+		valueColor := columnDef.valueColor
+		if valueColor == nil && columnDef.statusSymbols {
+			valueColor = statusColor
+		}
+		if valueColor == nil {
+			continue
+		}
+		if valueColors == nil {
+			valueColors = make(
+				[]func(value string) color.Attribute,
+				len(columnDefs))
+		}
+		valueColors[i] = valueColor
+	}
+	return valueColors
+}
+
+// cellColors returns the colors of the cells of a data row, with the colors
+// chosen by the columns for their values in place of the given ones.
+func (renderer *rowRenderer) cellColors(
+	row []string,
+	colors []color.Attribute,
+) []color.Attribute {
+	var cellColors []color.Attribute
+	for i, valueColor := range renderer.valueColors {
+		if valueColor == nil {
+			continue
+		}
+		attribute := valueColor(row[i])
+		if attribute == color.Reset {
+			continue
+		}
+		if cellColors == nil {
+			cellColors = make([]color.Attribute, len(row))
+			for j := range cellColors {
+				cellColors[j] = colors[j%len(colors)]
+			}
+		}
+		cellColors[i] = attribute
+	}
+	if cellColors == nil {
+		return colors
+	}
+	return cellColors
+}
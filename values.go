@@ -0,0 +1,84 @@
+package pretty
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AddValues adds a row of raw values to the table. The values are kept as
+// they are and only converted to strings when the table is rendered: columns
+// with a formatter pass the raw value to it, and others are formatted with
+// fmt.Sprint, with nil values and nil pointers shown as empty cells. Derived
+// columns are skipped, as in AddRow.
+func (table *Table) AddValues(values ...interface{}) error {
+	if table.lenientRows {
+		values = fitRow(table, values, table.rowCount()+1)
+	}
+	if len(values) != table.inputColumnCount() {
+		return table.deferError(&ValidationError{
+			Row:   table.rowCount() + 1,
+			Value: values,
+			Err: fmt.Errorf(
+				"length %d must match columns %d",
+				len(values),
+				table.inputColumnCount()),
+		})
+	}
+	err := validateValues(table, values, table.rowCount()+1)
+	if err != nil {
+		return table.deferError(err)
+	}
+
+	expanded := make([]interface{}, 0, len(table.columnDefs))
+	for _, columnDef := range table.columnDefs {
+		if columnDef.derive != nil {
+			expanded = append(expanded, nil)
+			continue
+		}
+		expanded = append(expanded, values[0])
+		values = values[1:]
+	}
+
+	// Rows added as strings since the last raw row have no values.
+	for len(table.values) < len(table.rows) {
+		table.values = append(table.values, nil)
+	}
+	table.values = append(table.values, expanded)
+	table.rows = append(table.rows, make([]string, len(table.columnDefs)))
+	return table.deferError(table.spillRows())
+}
+
+// SetFormatter replaces the formatter of the named column. Since formatters
+// are applied when the table is rendered, the rows do not need to be added
+// again. A nil formatter removes it.
+func (table *Table) SetFormatter(column string, formatter Formatter) error {
+	index, err := table.columnIndex(column)
+	if err != nil {
+		return err
+	}
+
+	// The column definitions may be shared with the caller of NewPrettyTable.
+	columnDefs := make([]ColumnDef, len(table.columnDefs))
+	copy(columnDefs, table.columnDefs)
+	columnDefs[index].formatter = formatter
+	table.columnDefs = columnDefs
+	return nil
+}
+
+// formatValue formats an arbitrary value for display in a cell. Nil values
+// and nil pointers are shown as empty strings.
+func formatValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return ""
+		}
+		if _, ok := value.(fmt.Stringer); !ok {
+			return formatValue(v.Elem().Interface())
+		}
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,76 @@
+package pretty
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestAddValuesFormatsLazily(t *testing.T) {
+	var formatted []interface{}
+	table, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDef("Created").WithFormatter(func(value interface{}) string {
+			formatted = append(formatted, value)
+			return value.(time.Time).Format("2006-01-02")
+		}),
+		NewDerivedColumnDef("Label", func(row map[string]string) string {
+			return row["Name"] + "!"
+		}))
+	assert.Nil(t, err)
+	table.SetColor(false)
+
+	created := time.Date(2019, 3, 14, 15, 9, 26, 0, time.UTC)
+	assert.Nil(t, table.AddValues("vm-1", created))
+	assert.NotNil(t, table.AddValues("vm-2"))
+	assert.EqualInt(t, 0, len(formatted))
+
+	output, err := table.PrettyString()
+	assert.Nil(t, err)
+	assert.DeepEqual(t, []interface{}{created}, formatted)
+	assert.True(t, strings.Contains(output, "| vm-1 | 2019-03-14 | vm-1! |"))
+
+	// Changing the formatter applies to the stored values.
+	err = table.SetFormatter("Created", func(value interface{}) string {
+		return fmt.Sprint(value.(time.Time).Year())
+	})
+	assert.Nil(t, err)
+	output, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(output, "| vm-1 |    2019 | vm-1! |"))
+	assert.NotNil(t, table.SetFormatter("Missing", nil))
+}
+
+func TestAddValuesMixedWithRows(t *testing.T) {
+	table, err := NewPrettyTable(
+		NewColumnDef("Name"),
+		NewColumnDef("Size"))
+	assert.Nil(t, err)
+	table.SetColor(false)
+
+	assert.Nil(t, table.AddRow("b", "20"))
+	assert.Nil(t, table.AddValues("a", 100))
+	assert.Nil(t, table.AddRow("c", "3"))
+	var size *int
+	assert.Nil(t, table.AddValues("d", size))
+
+	assert.Nil(t, table.SortBy("Size", false))
+	assert.Nil(t, table.SelectColumns("Size", "Name"))
+	var sizes []interface{}
+	err = table.SetFormatter("Size", func(value interface{}) string {
+		sizes = append(sizes, value)
+		return fmt.Sprint(value)
+	})
+	assert.Nil(t, err)
+
+	_, err = table.PrettyString()
+	assert.Nil(t, err)
+	assert.DeepEqual(t, []interface{}{size, "3", "20", 100}, sizes)
+
+	stats, err := table.ColumnStats("Size")
+	assert.Nil(t, err)
+	assert.Equal(t, 123.0, stats.Sum)
+}
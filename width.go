@@ -0,0 +1,272 @@
+package pretty
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// wideRunes are the characters that terminals draw two columns wide: those
+// of East Asian width W or F in Unicode 17, which include the emoji.
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f0, Stride: 1},
+		{Lo: 0x23f3, Hi: 0x23f3, Stride: 1},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2630, Hi: 0x2637, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x267f, Stride: 1},
+		{Lo: 0x268a, Hi: 0x268f, Stride: 1},
+		{Lo: 0x2693, Hi: 0x2693, Stride: 1},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26ce, Stride: 1},
+		{Lo: 0x26d4, Hi: 0x26d4, Stride: 1},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26f5, Stride: 1},
+		{Lo: 0x26fa, Hi: 0x26fa, Stride: 1},
+		{Lo: 0x26fd, Hi: 0x26fd, Stride: 1},
+		{Lo: 0x2705, Hi: 0x2705, Stride: 1},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x2728, Stride: 1},
+		{Lo: 0x274c, Hi: 0x274c, Stride: 1},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27b0, Stride: 1},
+		{Lo: 0x27bf, Hi: 0x27bf, Stride: 1},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b50, Stride: 1},
+		{Lo: 0x2b55, Hi: 0x2b55, Stride: 1},
+		{Lo: 0x2e80, Hi: 0x2e99, Stride: 1},
+		{Lo: 0x2e9b, Hi: 0x2ef3, Stride: 1},
+		{Lo: 0x2f00, Hi: 0x2fd5, Stride: 1},
+		{Lo: 0x2ff0, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x3096, Stride: 1},
+		{Lo: 0x3099, Hi: 0x30ff, Stride: 1},
+		{Lo: 0x3105, Hi: 0x312f, Stride: 1},
+		{Lo: 0x3131, Hi: 0x318e, Stride: 1},
+		{Lo: 0x3190, Hi: 0x31e5, Stride: 1},
+		{Lo: 0x31ef, Hi: 0x321e, Stride: 1},
+		{Lo: 0x3220, Hi: 0x3247, Stride: 1},
+		{Lo: 0x3250, Hi: 0xa48c, Stride: 1},
+		{Lo: 0xa490, Hi: 0xa4c6, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97c, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe52, Stride: 1},
+		{Lo: 0xfe54, Hi: 0xfe66, Stride: 1},
+		{Lo: 0xfe68, Hi: 0xfe6b, Stride: 1},
+		{Lo: 0xff01, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x16fe4, Stride: 1},
+		{Lo: 0x16ff0, Hi: 0x16ff6, Stride: 1},
+		{Lo: 0x17000, Hi: 0x18cd5, Stride: 1},
+		{Lo: 0x18cff, Hi: 0x18d1e, Stride: 1},
+		{Lo: 0x18d80, Hi: 0x18df2, Stride: 1},
+		{Lo: 0x1aff0, Hi: 0x1aff3, Stride: 1},
+		{Lo: 0x1aff5, Hi: 0x1affb, Stride: 1},
+		{Lo: 0x1affd, Hi: 0x1affe, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b122, Stride: 1},
+		{Lo: 0x1b132, Hi: 0x1b132, Stride: 1},
+		{Lo: 0x1b150, Hi: 0x1b152, Stride: 1},
+		{Lo: 0x1b155, Hi: 0x1b155, Stride: 1},
+		{Lo: 0x1b164, Hi: 0x1b167, Stride: 1},
+		{Lo: 0x1b170, Hi: 0x1b2fb, Stride: 1},
+		{Lo: 0x1d300, Hi: 0x1d356, Stride: 1},
+		{Lo: 0x1d360, Hi: 0x1d376, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f202, Stride: 1},
+		{Lo: 0x1f210, Hi: 0x1f23b, Stride: 1},
+		{Lo: 0x1f240, Hi: 0x1f248, Stride: 1},
+		{Lo: 0x1f250, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f260, Hi: 0x1f265, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f320, Stride: 1},
+		{Lo: 0x1f32d, Hi: 0x1f335, Stride: 1},
+		{Lo: 0x1f337, Hi: 0x1f37c, Stride: 1},
+		{Lo: 0x1f37e, Hi: 0x1f393, Stride: 1},
+		{Lo: 0x1f3a0, Hi: 0x1f3ca, Stride: 1},
+		{Lo: 0x1f3cf, Hi: 0x1f3d3, Stride: 1},
+		{Lo: 0x1f3e0, Hi: 0x1f3f0, Stride: 1},
+		{Lo: 0x1f3f4, Hi: 0x1f3f4, Stride: 1},
+		{Lo: 0x1f3f8, Hi: 0x1f43e, Stride: 1},
+		{Lo: 0x1f440, Hi: 0x1f440, Stride: 1},
+		{Lo: 0x1f442, Hi: 0x1f4fc, Stride: 1},
+		{Lo: 0x1f4ff, Hi: 0x1f53d, Stride: 1},
+		{Lo: 0x1f54b, Hi: 0x1f54e, Stride: 1},
+		{Lo: 0x1f550, Hi: 0x1f567, Stride: 1},
+		{Lo: 0x1f57a, Hi: 0x1f57a, Stride: 1},
+		{Lo: 0x1f595, Hi: 0x1f596, Stride: 1},
+		{Lo: 0x1f5a4, Hi: 0x1f5a4, Stride: 1},
+		{Lo: 0x1f5fb, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6c5, Stride: 1},
+		{Lo: 0x1f6cc, Hi: 0x1f6cc, Stride: 1},
+		{Lo: 0x1f6d0, Hi: 0x1f6d2, Stride: 1},
+		{Lo: 0x1f6d5, Hi: 0x1f6d8, Stride: 1},
+		{Lo: 0x1f6dc, Hi: 0x1f6df, Stride: 1},
+		{Lo: 0x1f6eb, Hi: 0x1f6ec, Stride: 1},
+		{Lo: 0x1f6f4, Hi: 0x1f6fc, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f7f0, Hi: 0x1f7f0, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f93a, Stride: 1},
+		{Lo: 0x1f93c, Hi: 0x1f945, Stride: 1},
+		{Lo: 0x1f947, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1fa7c, Stride: 1},
+		{Lo: 0x1fa80, Hi: 0x1fa8a, Stride: 1},
+		{Lo: 0x1fa8e, Hi: 0x1fac6, Stride: 1},
+		{Lo: 0x1fac8, Hi: 0x1fac8, Stride: 1},
+		{Lo: 0x1facd, Hi: 0x1fadc, Stride: 1},
+		{Lo: 0x1fadf, Hi: 0x1faea, Stride: 1},
+		{Lo: 0x1faef, Hi: 0x1faf8, Stride: 1},
+		{Lo: 0x20000, Hi: 0x2fffd, Stride: 1},
+		{Lo: 0x30000, Hi: 0x3fffd, Stride: 1},
+	},
+}
+
+// runeWidth returns the number of columns r takes in a terminal: 0 for
+// combining marks, 2 for wide characters and 1 otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case r < utf8.RuneSelf:
+		return 1
+	case !shouldCountEncodedRune(r):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	}
+	return 1
+}
+
+// escapeSequenceLength returns the length in bytes of the ANSI escape
+// sequence at the start of str, or 0 if there is none. Control sequences
+// such as colors, operating system commands such as hyperlinks, and
+// two-character escapes are recognized.
+func escapeSequenceLength(str string) int {
+	if len(str) < 2 || str[0] != '\x1b' {
+		return 0
+	}
+	switch str[1] {
+	case '[':
+		// Parameter and intermediate bytes are followed by a final byte.
+		for i := 2; i < len(str); i++ {
+			if str[i] >= 0x40 && str[i] <= 0x7e {
+				return i + 1
+			}
+			if str[i] < 0x20 || str[i] > 0x3f {
+				return 0
+			}
+		}
+		return 0
+	case ']':
+		// Operating system commands end with BEL or ST.
+		for i := 2; i < len(str); i++ {
+			if str[i] == '\a' {
+				return i + 1
+			}
+			if str[i] == '\x1b' && i+1 < len(str) && str[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return 0
+	}
+	return 2
+}
+
+// ellipsis marks the end of truncated text.
+const ellipsis = "..."
+
+// VisibleWidth returns the number of columns str takes in a terminal, as
+// tables measure their cells: combining marks and escape sequences take
+// none, and East Asian wide characters and emoji take two.
+func VisibleWidth(str string) int {
+	return strLengthWithEncoding(str)
+}
+
+// Truncate shortens str to at most width columns as tables shorten cells
+// wider than their column, by cutting it and ending it with "...". Strings
+// that fit are returned unchanged. If width is too small for the "...", str
+// is only cut.
+func Truncate(str string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if strLengthWithEncoding(str) <= width {
+		return str
+	}
+	if width <= len(ellipsis) {
+		return truncateStringWithEncoding(str, width)
+	}
+	return truncateStringWithEncoding(str, width-len(ellipsis)) + ellipsis
+}
+
+// TruncateMiddle shortens str to at most width columns by cutting out its
+// middle and replacing it with "...", keeping both its start and end. Strings
+// that fit are returned unchanged. If width is too small for the "...", str
+// is only cut.
+func TruncateMiddle(str string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if strLengthWithEncoding(str) <= width {
+		return str
+	}
+	if width <= len(ellipsis) {
+		return truncateStringWithEncoding(str, width)
+	}
+	tailWidth := (width - len(ellipsis)) / 2
+	headWidth := width - len(ellipsis) - tailWidth
+	return truncateStringWithEncoding(str, headWidth) + ellipsis +
+		suffixWidth(str, tailWidth)
+}
+
+// suffixWidth returns the longest suffix of str that is at most width
+// columns wide.
+func suffixWidth(str string, width int) string {
+	skip := strLengthWithEncoding(str) - width
+	length := 0
+	for i := 0; i < len(str); {
+		if length >= skip {
+			return str[i:]
+		}
+		if n := escapeSequenceLength(str[i:]); n > 0 {
+			i += n
+			continue
+		}
+		strRune, size := utf8.DecodeRuneInString(str[i:])
+		length += runeWidth(strRune)
+		i += size
+	}
+	return ""
+}
+
+// Pad adds spaces to str to make it width columns wide, aligned within them
+// as given. Strings at least width columns wide are returned unchanged.
+func Pad(str string, width int, alignment Alignment) string {
+	padding := width - strLengthWithEncoding(str)
+	if padding <= 0 {
+		return str
+	}
+	switch alignment {
+	case RightJustify:
+		return strings.Repeat(" ", padding) + str
+	case CenterJustify:
+		return strings.Repeat(" ", padding/2) + str +
+			strings.Repeat(" ", padding-padding/2)
+	}
+	return str + strings.Repeat(" ", padding)
+}
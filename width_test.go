@@ -0,0 +1,39 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestVisibleWidth(t *testing.T) {
+	assert.EqualInt(t, 5, VisibleWidth("hello"))
+	assert.EqualInt(t, 4, VisibleWidth("日本"))
+	assert.EqualInt(t, 2, VisibleWidth("🚀"))
+	assert.EqualInt(t, 2, VisibleWidth("🫠"))
+	assert.EqualInt(t, 2, VisibleWidth("\x1b[31mok\x1b[0m"))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.EqualString(t, "hello", Truncate("hello", 5))
+	assert.EqualString(t, "he...", Truncate("hello world", 5))
+	assert.EqualString(t, "hel", Truncate("hello", 3))
+	assert.EqualString(t, "", Truncate("hello", 0))
+	assert.EqualString(t, "日...", Truncate("日本語", 5))
+}
+
+func TestTruncateMiddle(t *testing.T) {
+	assert.EqualString(t, "hello", TruncateMiddle("hello", 5))
+	assert.EqualString(t, "abc...xyz", TruncateMiddle("abcdefuvwxyz", 9))
+	assert.EqualString(t, "abc...yz", TruncateMiddle("abcdefuvwxyz", 8))
+	assert.EqualString(t, "hel", TruncateMiddle("hello", 3))
+	assert.EqualString(t, "日...語", TruncateMiddle("日本語日本語", 7))
+}
+
+func TestPad(t *testing.T) {
+	assert.EqualString(t, "ab   ", Pad("ab", 5, LeftJustify))
+	assert.EqualString(t, "   ab", Pad("ab", 5, RightJustify))
+	assert.EqualString(t, " ab  ", Pad("ab", 5, CenterJustify))
+	assert.EqualString(t, "日本 ", Pad("日本", 5, LeftJustify))
+	assert.EqualString(t, "abcdef", Pad("abcdef", 5, LeftJustify))
+}
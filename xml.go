@@ -0,0 +1,228 @@
+package pretty
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Colors of the parts of an XML document that are not shared with JSON.
+var (
+	attributeColor = color.FgCyan
+	commentColor   = color.FgHiBlack
+)
+
+// XML returns the XML document data re-indented, with syntax colors if stdout
+// is a terminal. See XMLPrinter for other settings.
+func XML(data string) (string, error) {
+	return NewXMLPrinter().Format([]byte(data))
+}
+
+// XMLPrinter re-indents XML documents and colors their tags and attributes,
+// for showing XML returned by other systems to people. Elements containing
+// only text are kept on one line, and whitespace between elements is
+// replaced by the indentation.
+type XMLPrinter struct {
+	indent       string
+	colorEnabled *bool
+}
+
+// NewXMLPrinter creates an XMLPrinter indenting by two spaces.
+func NewXMLPrinter() *XMLPrinter {
+	return &XMLPrinter{indent: "  "}
+}
+
+// SetIndent sets the string that each level is indented by.
+func (printer *XMLPrinter) SetIndent(indent string) {
+	printer.indent = indent
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (printer *XMLPrinter) SetColor(enabled bool) {
+	printer.colorEnabled = &enabled
+}
+
+// Format returns the XML document data re-indented. It returns an error if
+// data is not well-formed.
+func (printer *XMLPrinter) Format(data []byte) (string, error) {
+	root, err := parseXML(data)
+	if err != nil {
+		return "", err
+	}
+	var builder strings.Builder
+	colorEnabled := colorsEnabled(printer.colorEnabled)
+	for _, node := range root.children {
+		printer.write(&builder, node, 0, colorEnabled)
+	}
+	return builder.String(), nil
+}
+
+// Print prints the XML document data re-indented to stdout.
+func (printer *XMLPrinter) Print(data []byte) error {
+	return printer.Fprint(os.Stdout, data)
+}
+
+// Fprint prints the XML document data re-indented to w.
+func (printer *XMLPrinter) Fprint(w io.Writer, data []byte) error {
+	output, err := printer.Format(data)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(consoleWriter(w), output)
+	return err
+}
+
+func (printer *XMLPrinter) write(
+	builder *strings.Builder,
+	node *xmlNode,
+	depth int,
+	colorEnabled bool,
+) {
+	builder.WriteString(strings.Repeat(printer.indent, depth))
+	if node.element == nil {
+		builder.WriteString(
+			colorize(node.text, colorEnabled, node.textColor()...))
+		builder.WriteString("\n")
+		return
+	}
+
+	name := xmlName(node.element.Name)
+	tag := func(text string) {
+		builder.WriteString(colorize(text, colorEnabled, keyColor))
+	}
+	tag("<" + name)
+	for _, attr := range node.element.Attr {
+		builder.WriteString(" ")
+		builder.WriteString(
+			colorize(xmlName(attr.Name), colorEnabled, attributeColor))
+		builder.WriteString("=")
+		builder.WriteString(
+			colorize(`"`+escapeXML(attr.Value)+`"`, colorEnabled, stringColor))
+	}
+
+	switch {
+	case len(node.children) == 0:
+		tag("/>")
+	case len(node.children) == 1 && node.children[0].isText():
+		tag(">")
+		builder.WriteString(node.children[0].text)
+		tag("</" + name + ">")
+	default:
+		tag(">")
+		builder.WriteString("\n")
+		for _, child := range node.children {
+			printer.write(builder, child, depth+1, colorEnabled)
+		}
+		builder.WriteString(strings.Repeat(printer.indent, depth))
+		tag("</" + name + ">")
+	}
+	builder.WriteString("\n")
+}
+
+// xmlNode is an element, or other content such as text or a comment, of a
+// parsed XML document.
+type xmlNode struct {
+	// element is the start tag of an element, or nil for other content.
+	element  *xml.StartElement
+	children []*xmlNode
+	// text is the escaped text of other content, including its delimiters.
+	text      string
+	isComment bool
+}
+
+// isText reports whether the node is character data.
+func (node *xmlNode) isText() bool {
+	return node.element == nil && !node.isComment &&
+		!strings.HasPrefix(node.text, "<")
+}
+
+// textColor returns the color attributes of content other than elements.
+func (node *xmlNode) textColor() []color.Attribute {
+	if node.isComment {
+		return []color.Attribute{commentColor}
+	}
+	return nil
+}
+
+// parseXML parses data into a node whose children are the top-level content
+// of the document. Namespace prefixes are kept as they are written.
+func parseXML(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	root := &xmlNode{}
+	stack := []*xmlNode{root}
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		parent := stack[len(stack)-1]
+		var node *xmlNode
+		switch token := token.(type) {
+		case xml.StartElement:
+			element := token.Copy()
+			node = &xmlNode{element: &element}
+			parent.children = append(parent.children, node)
+			stack = append(stack, node)
+			continue
+		case xml.EndElement:
+			if len(stack) == 1 ||
+				xmlName(parent.element.Name) != xmlName(token.Name) {
+				return nil, fmt.Errorf(
+					"unexpected end element </%s>",
+					xmlName(token.Name))
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		case xml.CharData:
+			text := strings.TrimSpace(string(token))
+			if text == "" {
+				continue
+			}
+			node = &xmlNode{text: escapeXML(text)}
+		case xml.Comment:
+			node = &xmlNode{
+				text:      "<!--" + string(token) + "-->",
+				isComment: true,
+			}
+		case xml.ProcInst:
+			node = &xmlNode{
+				text: "<?" + token.Target + " " + string(token.Inst) + "?>",
+			}
+		case xml.Directive:
+			node = &xmlNode{text: "<!" + string(token) + ">"}
+		}
+		parent.children = append(parent.children, node)
+	}
+	if len(stack) > 1 {
+		return nil, fmt.Errorf(
+			"element <%s> is not closed",
+			xmlName(stack[len(stack)-1].element.Name))
+	}
+	return root, nil
+}
+
+// xmlName returns a name as written, with its namespace prefix if any.
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// escapeXML escapes text for use in character data or attribute values.
+func escapeXML(text string) string {
+	var buffer bytes.Buffer
+	// Writing to a bytes.Buffer does not fail.
+	_ = xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
@@ -0,0 +1,53 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+func TestXMLPrinter(t *testing.T) {
+	printer := NewXMLPrinter()
+	printer.SetColor(false)
+	output, err := printer.Format([]byte(`<?xml version="1.0"?>
+<!-- snapshots --><ns:snapshots xmlns:ns="urn:x"><snapshot id="1"
+  name="a &amp; b"><size>10</size><tags/></snapshot>
+  <snapshot id="2">text<b>bold</b></snapshot></ns:snapshots>`))
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		`<?xml version="1.0"?>
+<!-- snapshots -->
+<ns:snapshots xmlns:ns="urn:x">
+  <snapshot id="1" name="a &amp; b">
+    <size>10</size>
+    <tags/>
+  </snapshot>
+  <snapshot id="2">
+    text
+    <b>bold</b>
+  </snapshot>
+</ns:snapshots>
+`,
+		output)
+
+	for _, data := range []string{"<a>", "<a></b>", "</a>", "<a"} {
+		_, err = printer.Format([]byte(data))
+		assert.NotNil(t, err)
+	}
+}
+
+func TestXMLPrinterColors(t *testing.T) {
+	printer := NewXMLPrinter()
+	printer.SetColor(true)
+	printer.SetIndent("\t")
+	output, err := printer.Format([]byte(`<a x="1"><!--c--><b/></a>`))
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"\x1b[34m<a\x1b[0m \x1b[36mx\x1b[0m=\x1b[32m\"1\"\x1b[0m\x1b[34m>\x1b[0m\n"+
+			"\t\x1b[90m<!--c-->\x1b[0m\n"+
+			"\t\x1b[34m<b\x1b[0m\x1b[34m/>\x1b[0m\n"+
+			"\x1b[34m</a>\x1b[0m\n",
+		output)
+}
@@ -0,0 +1,220 @@
+package pretty
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// guideColor is the color of the indentation guides drawn by YAMLPrinter.
+var guideColor = color.FgHiBlack
+
+// YAML returns v encoded as YAML, with syntax colors if stdout is a terminal.
+// See YAMLPrinter for other settings.
+func YAML(v interface{}) (string, error) {
+	return NewYAMLPrinter().Format(v)
+}
+
+// YAMLPrinter encodes values as block-style, syntax-colored YAML, for showing
+// configuration to people. Values are converted as by encoding/json, so JSON
+// struct tags and Marshaler implementations are respected.
+//
+// Nested mappings and sequences can be drawn with indentation guides, which
+// make deep configuration easier to follow but are not valid YAML:
+//
+//	cluster:
+//	│ name: prod
+//	│ nodes:
+//	│ │ - name: node-1
+//	│ │   cpus: 4
+type YAMLPrinter struct {
+	sortKeys     bool
+	maxDepth     int
+	guides       bool
+	colorEnabled *bool
+}
+
+// yamlIndent is the indentation of each level of a YAML document.
+const yamlIndent = "  "
+
+// NewYAMLPrinter creates a YAMLPrinter with keys in the order they are
+// encoded, no depth limit and no indentation guides.
+func NewYAMLPrinter() *YAMLPrinter {
+	return &YAMLPrinter{}
+}
+
+// SetSortKeys sets whether the keys of mappings are sorted. By default they
+// are in the order they are encoded, which is the field order for structs
+// and sorted for maps.
+func (printer *YAMLPrinter) SetSortKeys(sortKeys bool) {
+	printer.sortKeys = sortKeys
+}
+
+// SetMaxDepth sets the number of levels of nested mappings and sequences that
+// are shown. Deeper ones are elided as {...} or [...]. A depth of 0, the
+// default, shows all levels.
+func (printer *YAMLPrinter) SetMaxDepth(maxDepth int) {
+	printer.maxDepth = maxDepth
+}
+
+// SetIndentGuides sets whether nested levels are marked by vertical lines.
+func (printer *YAMLPrinter) SetIndentGuides(guides bool) {
+	printer.guides = guides
+}
+
+// SetColor enables or disables colors, overriding the global default, which
+// is to use colors only when stdout is a terminal.
+func (printer *YAMLPrinter) SetColor(enabled bool) {
+	printer.colorEnabled = &enabled
+}
+
+// Format returns v encoded as YAML.
+func (printer *YAMLPrinter) Format(v interface{}) (string, error) {
+	node, err := parseDocument(v)
+	if err != nil {
+		return "", err
+	}
+	if printer.sortKeys {
+		node.sortKeys()
+	}
+
+	colorEnabled := colorsEnabled(printer.colorEnabled)
+	var lines []string
+	if inline, ok := printer.inline(node, 0, colorEnabled); ok {
+		lines = []string{inline}
+	} else {
+		lines = printer.lines(node, 0, colorEnabled)
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// Print prints v encoded as YAML to stdout.
+func (printer *YAMLPrinter) Print(v interface{}) error {
+	return printer.Fprint(os.Stdout, v)
+}
+
+// Fprint prints v encoded as YAML to w.
+func (printer *YAMLPrinter) Fprint(w io.Writer, v interface{}) error {
+	output, err := printer.Format(v)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(consoleWriter(w), output)
+	return err
+}
+
+// inline returns the rendering of node if it fits on the line of its key or
+// sequence marker: scalars, empty mappings and sequences, and those elided
+// by the depth limit.
+func (printer *YAMLPrinter) inline(
+	node *documentNode,
+	depth int,
+	colorEnabled bool,
+) (string, bool) {
+	open, close := "{", "}"
+	if node.kind == documentArray {
+		open, close = "[", "]"
+	}
+	switch {
+	case node.kind == documentScalar:
+		return colorize(
+			yamlScalar(node.scalar),
+			colorEnabled,
+			node.scalarColor()), true
+	case len(node.children) == 0:
+		return open + close, true
+	case printer.maxDepth > 0 && depth >= printer.maxDepth:
+		return open + "..." + close, true
+	}
+	return "", false
+}
+
+// lines renders a mapping or sequence that does not fit inline.
+func (printer *YAMLPrinter) lines(
+	node *documentNode,
+	depth int,
+	colorEnabled bool,
+) []string {
+	indent := yamlIndent
+	if printer.guides {
+		indent = colorize("│", colorEnabled, guideColor) + " "
+	}
+
+	var lines []string
+	for i, child := range node.children {
+		inline, ok := printer.inline(child, depth+1, colorEnabled)
+		if node.kind == documentArray {
+			if ok {
+				lines = append(lines, "- "+inline)
+				continue
+			}
+			// The first line of a nested item follows the marker.
+			for j, line := range printer.lines(child, depth+1, colorEnabled) {
+				if j == 0 {
+					lines = append(lines, "- "+line)
+				} else {
+					lines = append(lines, yamlIndent+line)
+				}
+			}
+			continue
+		}
+
+		key := colorize(yamlScalar(quoteJSON(node.keys[i])), colorEnabled,
+			keyColor) + ":"
+		if ok {
+			lines = append(lines, key+" "+inline)
+			continue
+		}
+		lines = append(lines, key)
+		for _, line := range printer.lines(child, depth+1, colorEnabled) {
+			lines = append(lines, indent+line)
+		}
+	}
+	return lines
+}
+
+// yamlScalar converts the JSON encoding of a scalar to YAML. Strings are left
+// unquoted unless they would be read as something else, in which case their
+// JSON encoding is a valid double-quoted YAML string.
+func yamlScalar(scalar string) string {
+	if !strings.HasPrefix(scalar, `"`) {
+		return scalar
+	}
+	var str string
+	if err := json.Unmarshal([]byte(scalar), &str); err != nil ||
+		!isPlainYAML(str) {
+		return scalar
+	}
+	return str
+}
+
+// isPlainYAML reports whether str can be written as a plain YAML scalar and
+// be read back as the same string.
+func isPlainYAML(str string) bool {
+	if str == "" || strings.TrimSpace(str) != str {
+		return false
+	}
+	switch strings.ToLower(str) {
+	case "true", "false", "yes", "no", "on", "off", "y", "n", "null", "~",
+		".inf", "-.inf", "+.inf", ".nan":
+		return false
+	}
+	if _, err := strconv.ParseFloat(str, 64); err == nil {
+		return false
+	}
+	if strings.ContainsAny(str[:1], "-?:,[]{}#&*!|>'\"%@`") ||
+		strings.Contains(str, ": ") || strings.Contains(str, " #") ||
+		strings.HasSuffix(str, ":") {
+		return false
+	}
+	for _, r := range str {
+		if r < ' ' || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
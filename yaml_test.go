@@ -0,0 +1,118 @@
+package pretty
+
+import (
+	"testing"
+
+	"github.com/rubrikinc/testwell/assert"
+)
+
+type yamlTestNode struct {
+	Name string `json:"name"`
+	CPUs int    `json:"cpus"`
+}
+
+type yamlTestCluster struct {
+	Name   string            `json:"name"`
+	Nodes  []yamlTestNode    `json:"nodes"`
+	Labels map[string]string `json:"labels"`
+	Zones  [][]string        `json:"zones"`
+	Backup *bool             `json:"backup"`
+}
+
+func TestYAMLPrinter(t *testing.T) {
+	cluster := map[string]interface{}{
+		"cluster": yamlTestCluster{
+			Name: "prod",
+			Nodes: []yamlTestNode{
+				{Name: "node-1", CPUs: 4},
+				{Name: "node-2", CPUs: 8},
+			},
+			Labels: map[string]string{
+				"owner": "ops team",
+				"tier":  "1",
+				"note":  "key: value",
+				"empty": "",
+			},
+			Zones: [][]string{{"a", "b"}, {}},
+		},
+	}
+	printer := NewYAMLPrinter()
+	printer.SetColor(false)
+	output, err := printer.Format(cluster)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		`cluster:
+  name: prod
+  nodes:
+    - name: node-1
+      cpus: 4
+    - name: node-2
+      cpus: 8
+  labels:
+    empty: ""
+    note: "key: value"
+    owner: ops team
+    tier: "1"
+  zones:
+    - - a
+      - b
+    - []
+  backup: null
+`,
+		output)
+
+	printer.SetIndentGuides(true)
+	printer.SetMaxDepth(3)
+	printer.SetSortKeys(true)
+	output, err = printer.Format(cluster)
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		`cluster:
+│ backup: null
+│ labels:
+│ │ empty: ""
+│ │ note: "key: value"
+│ │ owner: ops team
+│ │ tier: "1"
+│ name: prod
+│ nodes:
+│ │ - {...}
+│ │ - {...}
+│ zones:
+│ │ - [...]
+│ │ - []
+`,
+		output)
+
+	output, err = printer.Format("yes")
+	assert.Nil(t, err)
+	assert.EqualString(t, "\"yes\"\n", output)
+}
+
+func TestIsPlainYAML(t *testing.T) {
+	for _, str := range []string{"prod", "ops team", "a-b", "x:y", "日本"} {
+		assert.True(t, isPlainYAML(str))
+	}
+	for _, str := range []string{
+		"", " a", "No", "~", "1e3", ".inf", "-a", "#a", "a #b", "a:", "a\nb",
+	} {
+		assert.True(t, !isPlainYAML(str))
+	}
+}
+
+func TestYAMLPrinterColors(t *testing.T) {
+	printer := NewYAMLPrinter()
+	printer.SetColor(true)
+	printer.SetIndentGuides(true)
+	output, err := printer.Format(map[string]interface{}{
+		"a": map[string]int{"b": 1},
+	})
+	assert.Nil(t, err)
+	assert.EqualString(
+		t,
+		"\x1b[34ma\x1b[0m:\n"+
+			"\x1b[90m│\x1b[0m \x1b[34mb\x1b[0m: \x1b[36m1\x1b[0m\n",
+		output)
+}